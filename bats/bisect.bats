@@ -0,0 +1,123 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+SQL
+
+    dolt add .
+    dolt commit -m "added table"
+
+    for i in 1 2 3 4 5 6 7 8; do
+        c1=$i
+        if [ $i -ge 6 ]; then
+            c1=-1
+        fi
+
+        dolt sql -q "INSERT INTO test VALUES ($i, $c1)"
+        dolt add test
+        dolt commit -m "commit $i"
+    done
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "bisect finds the first bad commit by marking commits" {
+    run dolt bisect start HEAD HEAD~8
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Bisecting: 3 revisions left to test after this" ]] || false
+    [[ "$output" =~ "commit 4" ]] || false
+
+    run dolt bisect good
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "commit 6" ]] || false
+
+    run dolt bisect bad
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "commit 5" ]] || false
+
+    run dolt bisect good
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "is the first bad commit" ]] || false
+    [[ "$output" =~ "commit 6" ]] || false
+
+    run dolt bisect reset
+    [ "$status" -eq 0 ]
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+    [[ ! "$output" =~ "bisecting" ]] || false
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [[ "$output" =~ "8" ]] || false
+}
+
+@test "bisect checks out the tables of the commit being tested" {
+    dolt bisect start HEAD HEAD~8
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [[ "$output" =~ "4" ]] || false
+    run dolt status
+    [[ "$output" =~ "You are currently bisecting, started from branch 'master'" ]] || false
+    run dolt log -n 1
+    [[ "$output" =~ "commit 8" ]] || false
+}
+
+@test "bisect run marks commits using a query" {
+    dolt bisect start
+    dolt bisect bad
+    dolt bisect good HEAD~8
+    run dolt bisect run "SELECT * FROM test WHERE c1 < 0"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "is the first bad commit" ]] || false
+    [[ "$output" =~ "commit 6" ]] || false
+}
+
+@test "bisect run requires good and bad commits" {
+    dolt bisect start
+    dolt bisect bad
+    run dolt bisect run "SELECT * FROM test WHERE c1 < 0"
+    [ "$status" -eq 1 ]
+}
+
+@test "bisect skip tests another commit" {
+    dolt bisect start HEAD HEAD~8
+    run dolt bisect skip
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "commit 4" ]] || false
+    [[ "$output" =~ "Bisecting:" ]] || false
+}
+
+@test "bisect blocks commit, checkout and merge" {
+    dolt branch other
+    dolt bisect start HEAD HEAD~8
+    run dolt commit -m "not allowed"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "bisect is in progress" ]] || false
+    run dolt checkout other
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "bisect is in progress" ]] || false
+    run dolt merge other
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "bisect is in progress" ]] || false
+}
+
+@test "bisect subcommands require a bisect in progress" {
+    run dolt bisect good
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no bisect in progress" ]] || false
+    run dolt bisect reset
+    [ "$status" -eq 0 ]
+}
+
+@test "bisect start requires a clean working set" {
+    dolt sql -q "INSERT INTO test VALUES (100, 100)"
+    run dolt bisect start HEAD HEAD~8
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "local changes would be overwritten" ]] || false
+}
@@ -0,0 +1,134 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1), (2), (3);
+SQL
+    dolt add test
+    dolt commit -m "added table"
+
+    # a copy of the repository as of the first commit to fetch bundles into
+    cp -r . ../dolt-repo-copy-$$
+}
+
+teardown() {
+    teardown_common
+    rm -rf $BATS_TMPDIR/dolt-repo-copy-$$
+}
+
+get_head_commit() {
+    dolt log -n 1 | grep -m 1 commit | cut -c 8-
+}
+
+@test "bundle create, list-heads and verify" {
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (4)"
+    dolt add test
+    dolt commit -m "added a row"
+    run dolt bundle create test.bundle --all
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2 branches" ]] || false
+    run dolt bundle list-heads test.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/master" ]] || false
+    [[ "$output" =~ "refs/heads/feature" ]] || false
+    run dolt bundle verify test.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test.bundle is okay" ]] || false
+}
+
+@test "bundle create requires a branch" {
+    run dolt bundle create test.bundle
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "refusing to create an empty bundle" ]] || false
+    run dolt bundle verify not_a_file.bundle
+    [ "$status" -ne 0 ]
+}
+
+@test "fetch from a bundle and merge" {
+    base=`get_head_commit`
+    dolt sql -q "INSERT INTO test VALUES (4)"
+    dolt add test
+    dolt commit -m "added a row"
+    run dolt bundle create ../test.bundle $base..master
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1 prerequisites" ]] || false
+
+    cd ../dolt-repo-copy-$$
+    run dolt bundle verify ../test.bundle
+    [ "$status" -eq 0 ]
+    run dolt fetch ../test.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "master -> bundle/master" ]] || false
+    run dolt merge bundle/master
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Fast-forward" ]] || false
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [[ "$output" =~ "4" ]] || false
+    rm ../test.bundle
+}
+
+@test "fetch from a bundle with a refspec" {
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (4)"
+    dolt add test
+    dolt commit -m "added a row"
+    dolt bundle create ../test.bundle feature
+
+    cd ../dolt-repo-copy-$$
+    run dolt fetch ../test.bundle refs/heads/feature:refs/heads/from-bundle
+    [ "$status" -eq 0 ]
+    dolt checkout from-bundle
+    run dolt log
+    [[ "$output" =~ "added a row" ]] || false
+    run dolt fetch ../test.bundle refs/heads/feature:refs/heads/from-bundle
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "current branch" ]] || false
+    rm ../test.bundle
+}
+
+@test "bundle with missing prerequisites can't be fetched" {
+    base=`get_head_commit`
+    dolt sql -q "INSERT INTO test VALUES (4)"
+    dolt add test
+    dolt commit -m "added a row"
+    dolt bundle create ../test.bundle $base..master
+
+    mkdir ../dolt-repo-copy-$$/empty
+    cd ../dolt-repo-copy-$$/empty
+    dolt init
+    run dolt bundle verify ../../test.bundle
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "$base" ]] || false
+    run dolt fetch ../../test.bundle
+    [ "$status" -ne 0 ]
+    rm ../../test.bundle
+}
+
+@test "unbundle imports commits without updating refs" {
+    dolt sql -q "INSERT INTO test VALUES (4)"
+    dolt add test
+    dolt commit -m "added a row"
+    head=`get_head_commit`
+    dolt bundle create ../test.bundle master
+
+    cd ../dolt-repo-copy-$$
+    run dolt bundle unbundle ../test.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$head refs/heads/master" ]] || false
+    run dolt log
+    [[ ! "$output" =~ "added a row" ]] || false
+    run dolt log $head
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added a row" ]] || false
+    rm ../test.bundle
+}
@@ -0,0 +1,101 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1, 1), (2, 2);
+SQL
+
+    dolt add .
+    dolt commit -m "added table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "cherry-pick applies only the changes made by the commit" {
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt add test
+    dolt commit -m "add pk 3"
+    dolt sql -q "UPDATE test SET c1 = 20 WHERE pk = 2"
+    dolt add test
+    dolt commit -m "update pk 2" --date 2020-01-01
+
+    dolt checkout master
+    run dolt cherry-pick feature
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "update pk 2" ]] || false
+    [[ "$output" =~ "2020" ]] || false
+
+    run dolt sql -q "SELECT * FROM test WHERE pk = 2"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "20" ]] || false
+    run dolt sql -q "SELECT * FROM test WHERE pk = 3"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "3" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "working tree clean" ]] || false
+
+    run dolt cherry-pick feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "already been applied" ]] || false
+}
+
+@test "cherry-pick with conflicts" {
+    dolt checkout -b feature
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "feature update pk 1"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test SET c1 = 100 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "master update pk 1"
+
+    run dolt cherry-pick feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    run dolt status
+    [[ "$output" =~ "cherry-picking" ]] || false
+    run dolt cherry-pick feature
+    [ "$status" -ne 0 ]
+
+    run dolt cherry-pick --abort
+    [ "$status" -eq 0 ]
+    run dolt status
+    [[ ! "$output" =~ "cherry-picking" ]] || false
+    [[ "$output" =~ "working tree clean" ]] || false
+
+    dolt cherry-pick feature || true
+    dolt conflicts resolve --theirs test
+    dolt add test
+    run dolt commit -m "feature update pk 1"
+    [ "$status" -eq 0 ]
+    run dolt sql -q "SELECT * FROM test WHERE pk = 1"
+    [[ "$output" =~ "10" ]] || false
+    [[ ! "$output" =~ "100" ]] || false
+    run dolt status
+    [[ ! "$output" =~ "cherry-picking" ]] || false
+}
+
+@test "cherry-pick requires a clean working set" {
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt add test
+    dolt commit -m "add pk 3"
+
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (4, 4)"
+    run dolt cherry-pick feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "local changes" ]] || false
+}
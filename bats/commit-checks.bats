@@ -0,0 +1,83 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE prices (
+  pk BIGINT NOT NULL,
+  price BIGINT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO prices VALUES (1, 10), (2, 20);
+INSERT INTO dolt_commit_checks VALUES ('no_negative_prices', 'SELECT * FROM prices WHERE price < 0');
+SQL
+
+    dolt add .
+    dolt commit -m "added prices and a commit check"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "commit checks table is created on first insert and committed like other tables" {
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "dolt_commit_checks" ]] || false
+    run dolt sql -q "SELECT name FROM dolt_commit_checks"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "no_negative_prices" ]] || false
+    run dolt sql -q "SELECT * FROM prices"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "| 2  | 20    |" ]] || false
+}
+
+@test "commit fails and prints the rows returned by a failing check" {
+    dolt sql -q "INSERT INTO prices VALUES (3, -5), (4, -7)"
+    dolt add prices
+    run dolt commit -m "negative prices"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "commit check 'no_negative_prices' failed" ]] || false
+    [[ "$output" =~ "| 3  | -5    |" ]] || false
+    [[ "$output" =~ "| 4  | -7    |" ]] || false
+    [[ "$output" =~ "--no-verify" ]] || false
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "negative prices" ]] || false
+}
+
+@test "commit checks run against the staged tables" {
+    dolt sql -q "INSERT INTO prices VALUES (3, -5)"
+    dolt sql -q "INSERT INTO dolt_commit_checks VALUES ('always_fails', 'SELECT 1 FROM dual')"
+    run dolt commit --allow-empty -m "nothing staged"
+    [ "$status" -eq 0 ]
+}
+
+@test "commit --no-verify skips the commit checks" {
+    dolt sql -q "INSERT INTO prices VALUES (3, -5)"
+    dolt add prices
+    run dolt commit --no-verify -m "negative prices"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "negative prices" ]] || false
+}
+
+@test "commit fails if a commit check cannot be run" {
+    dolt sql -q "INSERT INTO dolt_commit_checks VALUES ('broken', 'SELECT * FROM not_a_table')"
+    dolt add .
+    run dolt commit -m "broken check"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "commit check 'broken' failed to run" ]] || false
+}
+
+@test "COMMIT function runs the commit checks" {
+    dolt sql -q "INSERT INTO prices VALUES (3, -5)"
+    run dolt sql -q "SELECT COMMIT('negative prices')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "commit check 'no_negative_prices' returned 1 rows" ]] || false
+    [[ "$output" =~ "(3, -5)" ]] || false
+    run dolt sql -q "SELECT COMMIT('negative prices', '--no-verify')"
+    [ "$status" -eq 0 ]
+    run dolt sql -q "SELECT COMMIT('negative prices', 'not-a-flag')"
+    [ "$status" -eq 1 ]
+}
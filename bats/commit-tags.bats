@@ -0,0 +1,94 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+    mkdir "dolt-repo-clones"
+    dolt sql -q "CREATE TABLE test (pk BIGINT NOT NULL COMMENT 'tag:0', PRIMARY KEY (pk));"
+    dolt add test
+    dolt commit -m "created table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "create, list, and delete tags" {
+    run dolt tag v1
+    [ $status -eq 0 ]
+    run dolt tag -m "the second tag" v2 HEAD
+    [ $status -eq 0 ]
+    run dolt tag
+    [ $status -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+    [[ "$output" =~ "v2" ]] || false
+    run dolt tag -v v2
+    [ $status -eq 0 ]
+    [[ "$output" =~ "the second tag" ]] || false
+    [[ ! "$output" =~ "v1" ]] || false
+    run dolt tag v1
+    [ $status -ne 0 ]
+    [[ "$output" =~ "already exists" ]] || false
+    run dolt tag -d v1
+    [ $status -eq 0 ]
+    run dolt tag
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "v1" ]] || false
+    run dolt branch -a
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "v2" ]] || false
+}
+
+@test "refs which are not tags are invalid tag names" {
+    run dolt tag refs/heads/master
+    [ $status -eq 1 ]
+    [[ "$output" =~ "not a valid tag name" ]] || false
+    [[ ! "$output" =~ "panic" ]] || false
+    run dolt tag -d refs/heads/master
+    [ $status -eq 1 ]
+    [[ "$output" =~ "not a valid tag name" ]] || false
+    [[ ! "$output" =~ "panic" ]] || false
+}
+
+@test "tags can be used as commit specs" {
+    dolt tag v1
+    dolt sql -q "insert into test values (1)"
+    dolt add test
+    dolt commit -m "added a row"
+    run dolt diff v1
+    [ $status -eq 0 ]
+    [[ "$output" =~ "+  | 1" ]] || false
+    run dolt checkout -b from_tag v1
+    [ $status -eq 0 ]
+    run dolt sql -q "select * from test"
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "1" ]] || false
+}
+
+@test "push, fetch, and clone tags" {
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt tag -m "first release" v1
+    dolt push origin master
+    run dolt push origin v1
+    [ $status -eq 0 ]
+
+    cd dolt-repo-clones
+    dolt clone file://../remotedir test-repo
+    cd test-repo
+    run dolt tag -v
+    [ $status -eq 0 ]
+    [[ "$output" =~ "first release" ]] || false
+
+    cd ../..
+    dolt tag v2
+    dolt push origin refs/tags/v2
+    cd dolt-repo-clones/test-repo
+    run dolt fetch
+    [ $status -eq 0 ]
+    run dolt tag
+    [ $status -eq 0 ]
+    [[ "$output" =~ "v2" ]] || false
+}
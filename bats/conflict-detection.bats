@@ -348,7 +348,6 @@ SQL
     dolt add test
     dolt commit -m "added column c0 as int"
     dolt checkout master
-    skip "This created two c0 columns with different types and tag numbers. Bug I think."
     run dolt merge add-column
     [ $status -eq 0 ]
     [[ "$output" =~ "CONFLICT" ]] || false
@@ -466,7 +465,6 @@ CREATE TABLE test (
   PRIMARY KEY (pk)
 );
 SQL
-    skip "This currently is a failed merge. I think it should be a conflict that you can resolve by modifying the schema. Basically choose a column name for the tag. The data is the same."
     dolt add test
     dolt commit -m "table created"
     dolt branch rename-column
@@ -479,10 +477,8 @@ SQL
     dolt commit -m "renamed c5 to c6"
     dolt checkout master
     run dolt merge rename-column
-    [ $status -eq 1 ]
-    [[ "$output" =~ "Bad merge" ]] || false
     [ $status -eq 0 ]
-    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "CONFLICT (schema)" ]] || false
 }
 
 @test "two branches rename different column to same name. merge. conflict" {
@@ -497,7 +493,6 @@ CREATE TABLE test (
   PRIMARY KEY (pk)
 );
 SQL
-    skip "Same as test above. This case needs some thought. My initial instinct was that this generates a tag conflict. Pick one tag and then you have a data conflict because the schemas are the same on both branches."
     dolt add test
     dolt commit -m "table created"
     dolt branch rename-column
@@ -510,10 +505,8 @@ SQL
     dolt commit -m "renamed c5 to c6"
     dolt checkout master
     run dolt merge rename-column
-    [ $status -eq 1 ]
-    [[ "$output" =~ "Bad merge" ]] || false
     [ $status -eq 0 ]
-    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "CONFLICT (schema)" ]] || false
 }
 
 # Altering types and properties of the schema are not really supported by the
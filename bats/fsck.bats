@@ -0,0 +1,52 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  v BIGINT,
+  PRIMARY KEY (pk),
+  INDEX idx_v (v)
+);
+INSERT INTO test VALUES (1, 1), (2, 2), (3, 3);
+SQL
+    dolt add test
+    dolt commit -m "added table"
+    dolt sql -q "INSERT INTO test VALUES (4, 4)"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "fsck reports no problems for a healthy repository" {
+    run dolt fsck
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"ok": true' ]] || false
+    [[ "$output" =~ '"problems": []' ]] || false
+    [[ "$output" =~ '"rows": 7' ]] || false
+}
+
+@test "fsck --connectivity-only doesn't check rows" {
+    run dolt fsck --connectivity-only
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"ok": true' ]] || false
+    [[ "$output" =~ '"rows": 0' ]] || false
+}
+
+@test "fsck reports corrupt chunks" {
+    file=`ls -S .dolt/noms | grep -v manifest | grep -v LOCK | head -n 1`
+    printf '\xff\xff' | dd of=.dolt/noms/$file bs=1 seek=10 conv=notrunc
+    run dolt fsck
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ '"ok": false' ]] || false
+    [[ "$output" =~ '"kind": "table_file"' ]] || false
+    [[ "$output" =~ "\"object\": \"$file\"" ]] || false
+}
+
+@test "fsck rejects arguments" {
+    run dolt fsck master
+    [ "$status" -ne 0 ]
+}
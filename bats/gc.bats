@@ -0,0 +1,77 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1, 1), (2, 2);
+SQL
+
+    dolt add .
+    dolt commit -m "added table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "gc on a repository with no garbage reclaims nothing" {
+    dolt gc
+    run dolt gc --dry-run
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0 of" ]] || false
+    [[ "$output" =~ "(0 bytes)" ]] || false
+}
+
+@test "gc removes tables discarded by reset --hard" {
+    dolt table import -c -pk=pk imported `batshelper 1pk5col-ints.csv`
+    dolt reset --hard
+
+    run dolt gc --dry-run
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Collecting garbage would reclaim" ]] || false
+    [[ ! "$output" =~ "(0 bytes)" ]] || false
+
+    run dolt gc
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Removed" ]] || false
+    [[ "$output" =~ "into 1" ]] || false
+
+    run dolt gc --dry-run
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "(0 bytes)" ]] || false
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,1" ]] || false
+    [[ "$output" =~ "2,2" ]] || false
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added table" ]] || false
+}
+
+@test "gc keeps the working set, branches and reflog entries" {
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt add test
+    dolt commit -m "added pk 3 on other"
+    dolt checkout master
+    dolt branch -d -f other
+    dolt sql -q "INSERT INTO test VALUES (4, 4)"
+
+    dolt gc
+
+    run dolt sql -q "SELECT * FROM test WHERE pk = 4" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4,4" ]] || false
+
+    run dolt log other@{1}
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added pk 3 on other" ]] || false
+}
@@ -2212,3 +2212,66 @@ SQL
     [ "$status" -eq "1" ]
     [[ "$output" =~ "UNIQUE" ]] || false
 }
+
+@test "index: Merge adds indexes from the merged branch and keeps indexes dropped on it" {
+    dolt sql <<SQL
+CREATE INDEX idx_v1 ON onepk(v1);
+INSERT INTO onepk VALUES (1, 11, 101), (2, 22, 202);
+SQL
+    dolt add -A
+    dolt commit -m "baseline commit"
+    dolt checkout -b other
+    dolt sql <<SQL
+DROP INDEX idx_v1 ON onepk;
+CREATE INDEX idx_v2 ON onepk(v2);
+INSERT INTO onepk VALUES (3, 33, 303);
+SQL
+    dolt add -A
+    dolt commit -m "other changes"
+    dolt checkout master
+    dolt sql -q "INSERT INTO onepk VALUES (4, 44, 404)"
+    dolt add -A
+    dolt commit -m "master changes"
+    run dolt merge other
+    [ "$status" -eq "0" ]
+    run dolt index ls onepk
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "idx_v1(v1)" ]] || false
+    [[ "$output" =~ "idx_v2(v2)" ]] || false
+    run dolt index cat onepk idx_v1 -r=csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "v1,pk1" ]] || false
+    [[ "$output" =~ "11,1" ]] || false
+    [[ "$output" =~ "33,3" ]] || false
+    [[ "$output" =~ "44,4" ]] || false
+    [[ "${#lines[@]}" = "5" ]] || false
+    run dolt index cat onepk idx_v2 -r=csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "v2,pk1" ]] || false
+    [[ "$output" =~ "202,2" ]] || false
+    [[ "$output" =~ "303,3" ]] || false
+    [[ "$output" =~ "404,4" ]] || false
+    [[ "${#lines[@]}" = "5" ]] || false
+}
+
+@test "index: Merge does not restore an index dropped on master" {
+    dolt sql <<SQL
+CREATE INDEX idx_v1 ON onepk(v1);
+INSERT INTO onepk VALUES (1, 11, 101), (2, 22, 202);
+SQL
+    dolt add -A
+    dolt commit -m "baseline commit"
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO onepk VALUES (3, 33, 303)"
+    dolt add -A
+    dolt commit -m "other changes"
+    dolt checkout master
+    dolt sql -q "DROP INDEX idx_v1 ON onepk"
+    dolt add -A
+    dolt commit -m "master changes"
+    run dolt merge other
+    [ "$status" -eq "0" ]
+    run dolt index ls onepk
+    [ "$status" -eq "0" ]
+    ! [[ "$output" =~ "idx_v1" ]] || false
+}
@@ -0,0 +1,136 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+    mkdir "dolt-repo-clones"
+
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1), (2), (3);
+SQL
+    dolt add test
+    dolt commit -m "added table"
+
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "lazy clone reads data from the remote" {
+    cd dolt-repo-clones
+    run dolt clone --lazy file://../remotedir test-repo
+    [ "$status" -eq 0 ]
+    cd test-repo
+    grep '"lazy_remote": "origin"' .dolt/repo_state.json
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added table" ]] || false
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+}
+
+@test "changes can be committed and pushed from a lazy clone" {
+    cd dolt-repo-clones
+    dolt clone --lazy file://../remotedir test-repo
+    cd test-repo
+    dolt sql -q "INSERT INTO test VALUES (4)"
+    dolt add test
+    dolt commit -m "added a row"
+    run dolt push origin master
+    [ "$status" -eq 0 ]
+    cd ../..
+    dolt pull
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [[ "$output" =~ "4" ]] || false
+}
+
+@test "fetch --all-chunks completes a lazy clone" {
+    cd dolt-repo-clones
+    dolt clone --lazy file://../remotedir test-repo
+    cd test-repo
+    run dolt fetch --all-chunks
+    [ "$status" -eq 0 ]
+    run grep lazy_remote .dolt/repo_state.json
+    [ "$status" -eq 1 ]
+    mv ../../remotedir ../../remotedir-moved
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+    mv ../../remotedir-moved ../../remotedir
+}
+
+@test "fetch --all-chunks fails outside of a lazy clone" {
+    run dolt fetch --all-chunks
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "can only be used in a lazy clone" ]] || false
+}
+
+@test "the remote of a lazy clone can't be removed" {
+    cd dolt-repo-clones
+    dolt clone --lazy file://../remotedir test-repo
+    cd test-repo
+    run dolt remote remove origin
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "this lazy clone reads its data from 'origin'" ]] || false
+}
+
+@test "clone --lazy can't be used with --depth" {
+    cd dolt-repo-clones
+    run dolt clone --lazy --depth 1 file://../remotedir test-repo
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "cannot be used together" ]] || false
+}
+
+@test "chunks read by a lazy clone are stored locally" {
+    cd dolt-repo-clones
+    dolt clone --lazy file://../remotedir test-repo
+    cd test-repo
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+    run dolt log
+    [ "$status" -eq 0 ]
+    mv ../../remotedir ../../remotedir-moved
+    mkdir ../../remotedir
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    [[ "$output" =~ "2" ]] || false
+    [[ "$output" =~ "3" ]] || false
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added table" ]] || false
+    rm -rf ../../remotedir
+    mv ../../remotedir-moved ../../remotedir
+}
+
+@test "fsck checks only the local data of a lazy clone" {
+    cd dolt-repo-clones
+    dolt clone --lazy file://../remotedir test-repo
+    cd test-repo
+    mv ../../remotedir ../../remotedir-moved
+    mkdir ../../remotedir
+    run dolt fsck
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"ok": true' ]] || false
+    [[ ! "$output" =~ '"remote_chunks": 0,' ]] || false
+    rm -rf ../../remotedir
+    mv ../../remotedir-moved ../../remotedir
+    run dolt fetch --all-chunks
+    [ "$status" -eq 0 ]
+    run dolt fsck
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"remote_chunks": 0,' ]] || false
+    [[ "$output" =~ '"unverified": 0,' ]] || false
+}
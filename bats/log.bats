@@ -89,3 +89,126 @@ teardown() {
     regex='Merge:.*MergeCommit.*'
     [[ "$output" =~ $regex ]] || false
 }
+
+@test "dolt log --oneline" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt add test
+    dolt commit -m "first commit
+
+with a longer description"
+    run dolt log --oneline
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[0]}" =~ "first commit" ]] || false
+    [[ ! "$output" =~ "longer description" ]] || false
+    [[ "${lines[1]}" =~ "Initialize data repository" ]] || false
+}
+
+@test "dolt log with a table only shows commits which changed the table" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt sql -q "create table other (pk int, c1 int, primary key(pk))"
+    dolt add .
+    dolt commit -m "created tables"
+    dolt sql -q "insert into test values (0,0)"
+    dolt add test
+    dolt commit -m "changed test"
+    dolt sql -q "insert into other values (0,0)"
+    dolt add other
+    dolt commit -m "changed other"
+
+    run dolt log --oneline test
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[0]}" =~ "changed test" ]] || false
+    [[ "${lines[1]}" =~ "created tables" ]] || false
+
+    run dolt log --oneline HEAD~1 other
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "${lines[0]}" =~ "created tables" ]] || false
+
+    dolt table rm other
+    dolt add other
+    dolt commit -m "removed other"
+    run dolt log --oneline other
+    [ $status -ne 0 ]
+    [[ "$output" =~ "neither a commit nor a table" ]] || false
+
+    run dolt log --oneline -- other
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[0]}" =~ "removed other" ]] || false
+}
+
+@test "dolt log --author, --grep, --since and --until" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt add test
+    dolt commit -m "added test table" --date 2020-01-01
+    dolt sql -q "insert into test values (0,0)"
+    dolt add test
+    dolt commit -m "added a row" --date 2020-02-01
+
+    run dolt log --oneline --grep "test table"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "${lines[0]}" =~ "added test table" ]] || false
+
+    run dolt log --oneline --author "Bats Tests"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "added a row" ]] || false
+
+    run dolt log --oneline --author "nobody"
+    [ $status -eq 0 ]
+    [ "$output" = "" ]
+
+    run dolt log --oneline --since 2020-01-15 --until 2020-03-01
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "${lines[0]}" =~ "added a row" ]] || false
+
+    run dolt log --oneline --until 2020-01-15
+    [ $status -eq 0 ]
+    [[ "${lines[0]}" =~ "added test table" ]] || false
+
+    run dolt log --since "not a date"
+    [ $status -ne 0 ]
+}
+
+@test "dolt log --merges, --no-merges and --graph" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt add test
+    dolt commit -m "Commit1"
+    dolt checkout -b test-branch
+    dolt sql -q "insert into test values (0,0)"
+    dolt add test
+    dolt commit -m "Commit2"
+    dolt checkout master
+    dolt sql -q "insert into test values (1,1)"
+    dolt add test
+    dolt commit -m "Commit3"
+    dolt merge -m "MergeCommit" test-branch
+
+    run dolt log --oneline --merges
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "${lines[0]}" =~ "MergeCommit" ]] || false
+
+    run dolt log --oneline --no-merges
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "MergeCommit" ]] || false
+    [[ "$output" =~ "Commit2" ]] || false
+
+    run dolt log --graph --oneline
+    [ $status -eq 0 ]
+    [[ "${lines[0]}" =~ "*   " ]] || false
+    [[ "${lines[0]}" =~ "MergeCommit" ]] || false
+    [[ "${lines[1]}" =~ '|\' ]] || false
+    [[ "$output" =~ "|/" ]] || false
+    [[ "${lines[-1]}" =~ "* " ]] || false
+    [[ "${lines[-1]}" =~ "Initialize data repository" ]] || false
+
+    run dolt log --graph
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Merge:" ]] || false
+    [[ "$output" =~ "| | Author:" ]] || false
+}
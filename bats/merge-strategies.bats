@@ -0,0 +1,92 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  hits BIGINT UNSIGNED COMMENT 'merge:sum-of-deltas',
+  high BIGINT COMMENT 'merge:max',
+  updated BIGINT COMMENT 'merge:max',
+  v TEXT COMMENT 'merge:latest-by-column updated',
+  other TEXT,
+  PRIMARY KEY (pk)
+);
+SQL
+    dolt sql -q "INSERT INTO test VALUES (1, 10, 1, 100, 'base', 'base')"
+    dolt add test
+    dolt commit -m "added table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "merge strategies are shown in the schema" {
+    run dolt schema show test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "merge:sum-of-deltas" ]] || false
+    [[ "$output" =~ "merge:latest-by-column updated" ]] || false
+}
+
+@test "merge resolves cells changed on both sides with the column merge strategies" {
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET hits = 13, high = 5, updated = 200, v = 'theirs' WHERE pk = 1"
+    dolt add test
+    dolt commit -m "changed on other"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test SET hits = 15, high = 3, updated = 150, v = 'ours' WHERE pk = 1"
+    dolt add test
+    dolt commit -m "changed on master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Auto-resolved 4 cells in test" ]] || false
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -r csv -q "SELECT * FROM test"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,18,5,200,theirs,base" ]] || false
+}
+
+@test "cells in columns without a merge strategy are still conflicts" {
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET hits = 13, other = 'theirs' WHERE pk = 1"
+    dolt add test
+    dolt commit -m "changed on other"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test SET hits = 15, other = 'ours' WHERE pk = 1"
+    dolt add test
+    dolt commit -m "changed on master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ ! "$output" =~ "Auto-resolved" ]] || false
+}
+
+@test "invalid merge strategies are rejected" {
+    run dolt sql -q "CREATE TABLE bad (pk BIGINT NOT NULL, v TEXT COMMENT 'merge:sum-of-deltas', PRIMARY KEY (pk))"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "can't be used with" ]] || false
+
+    run dolt sql -q "CREATE TABLE bad (pk BIGINT NOT NULL, v TEXT COMMENT 'merge:newest', PRIMARY KEY (pk))"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown merge strategy" ]] || false
+
+    run dolt sql -q "ALTER TABLE test DROP COLUMN updated"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "does not exist" ]] || false
+}
+
+@test "alter table sets the merge strategy of a column" {
+    dolt sql -q "ALTER TABLE test ADD COLUMN low BIGINT COMMENT 'merge:min'"
+    dolt sql -q "ALTER TABLE test MODIFY COLUMN other TEXT COMMENT 'merge:ours'"
+    run dolt schema show test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "merge:min" ]] || false
+    [[ "$output" =~ "merge:ours" ]] || false
+}
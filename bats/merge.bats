@@ -117,3 +117,152 @@ teardown() {
     run dolt merge merge_branch
     [ "$status" -eq 1 ]
 }
+
+@test "merge --no-ff creates a merge commit instead of fast-forwarding" {
+    dolt checkout -b merge_branch
+    dolt SQL -q "INSERT INTO test1 values (0,1,2)"
+    dolt add test1
+    dolt commit -m "add pk 0 to test1"
+    dolt checkout master
+
+    run dolt merge --no-ff -m "merge merge_branch" merge_branch
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "Fast-forward" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Merge:" ]] || false
+    [[ "$output" =~ "merge merge_branch" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "merge --no-ff without a message leaves the merge to be committed" {
+    dolt checkout -b merge_branch
+    dolt SQL -q "INSERT INTO test1 values (0,1,2)"
+    dolt add test1
+    dolt commit -m "add pk 0 to test1"
+    dolt checkout master
+
+    run dolt merge --no-ff merge_branch
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "Fast-forward" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "still merging" ]] || false
+
+    dolt commit -m "merge commit"
+    run dolt log -n 1
+    [[ "$output" =~ "Merge:" ]] || false
+}
+
+@test "merge --ff-only fails when a fast-forward isn't possible" {
+    dolt checkout -b merge_branch
+    dolt SQL -q "INSERT INTO test1 values (0,1,2)"
+    dolt add test1
+    dolt commit -m "add pk 0 to test1"
+    dolt checkout master
+    dolt SQL -q "INSERT INTO test2 values (0,1,2)"
+    dolt add test2
+    dolt commit -m "add pk 0 to test2"
+
+    run dolt merge --ff-only merge_branch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Not possible to fast-forward" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+    [[ ! "$output" =~ "merging" ]] || false
+
+    run dolt merge --no-ff --ff-only merge_branch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "cannot combine" ]] || false
+}
+
+@test "merge --ff-only fast-forwards when it can" {
+    dolt checkout -b merge_branch
+    dolt SQL -q "INSERT INTO test1 values (0,1,2)"
+    dolt add test1
+    dolt commit -m "add pk 0 to test1"
+    dolt checkout master
+
+    run dolt merge --ff-only merge_branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Fast-forward" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "add pk 0 to test1" ]] || false
+}
+
+@test "merge --squash collapses a branch into a single commit" {
+    dolt checkout -b merge_branch
+    dolt SQL -q "INSERT INTO test1 values (0,1,2)"
+    dolt add test1
+    dolt commit -m "add pk 0 to test1"
+    dolt SQL -q "INSERT INTO test1 values (1,2,3)"
+    dolt add test1
+    dolt commit -m "add pk 1 to test1"
+    dolt checkout master
+
+    run dolt merge --squash merge_branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Squash commit -- not updating HEAD" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "added tables" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "Changes to be committed" ]] || false
+    [[ ! "$output" =~ "merging" ]] || false
+
+    dolt commit -m "squashed merge_branch"
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "squashed merge_branch" ]] || false
+    [[ ! "$output" =~ "Merge:" ]] || false
+    [[ ! "$output" =~ "add pk 0 to test1" ]] || false
+
+    run dolt sql -q "SELECT COUNT(*) FROM test1" -r csv
+    [[ "$output" =~ "2" ]] || false
+}
+
+@test "merge --squash with a message commits in one step" {
+    dolt checkout -b merge_branch
+    dolt SQL -q "INSERT INTO test1 values (0,1,2)"
+    dolt add test1
+    dolt commit -m "add pk 0 to test1"
+    dolt checkout master
+    dolt SQL -q "INSERT INTO test2 values (0,1,2)"
+    dolt add test2
+    dolt commit -m "add pk 0 to test2"
+
+    run dolt merge --squash -m "squashed merge_branch" merge_branch
+    [ "$status" -eq 0 ]
+
+    run dolt log -n 1
+    [[ "$output" =~ "squashed merge_branch" ]] || false
+    [[ ! "$output" =~ "Merge:" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "merge with a message and conflicts leaves the merge to be resolved" {
+    dolt checkout -b merge_branch
+    dolt SQL -q "INSERT INTO test1 values (0,1,2)"
+    dolt add test1
+    dolt commit -m "add pk 0 to test1"
+    dolt checkout master
+    dolt SQL -q "INSERT INTO test1 values (0,2,3)"
+    dolt add test1
+    dolt commit -m "add conflicting pk 0 to test1"
+
+    run dolt merge -m "merge merge_branch" merge_branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "add conflicting pk 0 to test1" ]] || false
+}
@@ -0,0 +1,154 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1, 1), (2, 2);
+SQL
+
+    dolt add .
+    dolt commit -m "added table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "rebase replays local commits onto upstream" {
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (10, 10)"
+    dolt add test
+    dolt commit -m "feature commit 1" --date 2020-01-01
+    dolt sql -q "INSERT INTO test VALUES (11, 11)"
+    dolt add test
+    dolt commit -m "feature commit 2"
+
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt add test
+    dolt commit -m "master commit"
+
+    dolt checkout feature
+    run dolt rebase master
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased" ]] || false
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "${lines[3]}" =~ "feature commit 2" ]] || false
+    [[ "$output" =~ "2020" ]] || false
+    [[ ! "$output" =~ "Merge" ]] || false
+
+    run dolt sql -q "SELECT * FROM test"
+    [[ "$output" =~ "3" ]] || false
+    [[ "$output" =~ "10" ]] || false
+    [[ "$output" =~ "11" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "working tree clean" ]] || false
+
+    run dolt rebase master
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "up to date" ]] || false
+}
+
+@test "rebase fast-forwards when there are no local commits" {
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (10, 10)"
+    dolt add test
+    dolt commit -m "feature commit"
+
+    dolt checkout master
+    run dolt rebase feature
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Fast-forwarded" ]] || false
+    run dolt sql -q "SELECT * FROM test WHERE pk = 10"
+    [[ "$output" =~ "10" ]] || false
+}
+
+@test "rebase stops on conflicts and continues" {
+    dolt checkout -b feature
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "feature update pk 1"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test SET c1 = 100 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "master update pk 1"
+
+    dolt checkout feature
+    run dolt rebase master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "could not apply" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "currently rebasing branch 'feature'" ]] || false
+
+    run dolt rebase --continue
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "unmerged tables" ]] || false
+
+    run dolt merge master
+    [ "$status" -ne 0 ]
+
+    dolt conflicts resolve --theirs test
+    dolt add test
+    run dolt rebase --continue
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased" ]] || false
+
+    run dolt sql -q "SELECT * FROM test WHERE pk = 1"
+    [[ "$output" =~ "10" ]] || false
+    [[ ! "$output" =~ "100" ]] || false
+
+    run dolt log
+    [[ "$output" =~ "feature update pk 1" ]] || false
+    [[ "$output" =~ "master update pk 1" ]] || false
+}
+
+@test "rebase --abort restores the original branch" {
+    dolt checkout -b feature
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "feature update pk 1"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test SET c1 = 100 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "master update pk 1"
+
+    dolt checkout feature
+    run dolt rebase master
+    [ "$status" -eq 1 ]
+
+    run dolt rebase --abort
+    [ "$status" -eq 0 ]
+
+    run dolt status
+    [[ "$output" =~ "working tree clean" ]] || false
+    run dolt log
+    [[ ! "$output" =~ "master update pk 1" ]] || false
+    run dolt sql -q "SELECT * FROM test WHERE pk = 1"
+    [[ "$output" =~ "10" ]] || false
+    [[ ! "$output" =~ "100" ]] || false
+
+    run dolt rebase --abort
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no rebase in progress" ]] || false
+}
+
+@test "rebase requires a clean working set" {
+    dolt branch other
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    run dolt rebase other
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "local changes would be overwritten by rebase" ]] || false
+}
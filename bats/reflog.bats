@@ -0,0 +1,86 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1, 1), (2, 2);
+SQL
+
+    dolt add .
+    dolt commit -m "added table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "reflog records commits to the current branch" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt add test
+    dolt commit -m "added pk 3"
+
+    run dolt reflog
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" =~ "master@{0}: commit: added pk 3" ]] || false
+    [[ "${lines[1]}" =~ "master@{1}: commit: added table" ]] || false
+
+    run dolt reflog HEAD
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" =~ "master@{0}: commit: added pk 3" ]] || false
+}
+
+@test "reflog entries can be referenced with ref@{n}" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt add test
+    dolt commit -m "added pk 3"
+
+    run dolt log -n 1 master@{1}
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added table" ]] || false
+    [[ ! "$output" =~ "added pk 3" ]] || false
+
+    run dolt log -n 1 HEAD@{0}
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added pk 3" ]] || false
+
+    run dolt log -n 1 master@{100}
+    [ "$status" -ne 0 ]
+}
+
+@test "reflog keeps the history of deleted branches" {
+    dolt branch other
+    dolt branch -d other
+
+    run dolt reflog other
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" =~ "other@{0}: branch: Deleted" ]] || false
+    [[ "${lines[1]}" =~ "other@{1}: branch: Created" ]] || false
+
+    dolt checkout -b restored other@{1}
+    run dolt sql -q "SELECT * FROM test"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+}
+
+@test "reflog records changes to the working and staged tables" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    run dolt reflog WORKING
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" =~ "WORKING@{0}: working set updated on master" ]] || false
+
+    dolt add test
+    run dolt reflog staged
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" =~ "STAGED@{0}: staged tables updated on master" ]] || false
+}
+
+@test "reflog with an unknown ref" {
+    run dolt reflog bad..name
+    [ "$status" -ne 0 ]
+}
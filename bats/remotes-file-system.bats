@@ -284,3 +284,151 @@ SQL
     [ ! -f LICENSE.md ]
     [ ! -f README.md ]
 }
+
+@test "status and branch -vv show how a branch compares to its upstream" {
+    dolt sql -q "CREATE TABLE test (pk BIGINT NOT NULL, PRIMARY KEY (pk))"
+    dolt add test
+    dolt commit -m "test commit"
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push --set-upstream origin master
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Your branch is up to date with 'origin/master'." ]] || false
+
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt add test
+    dolt commit -m "local commit"
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Your branch is ahead of 'origin/master' by 1 commit." ]] || false
+    run dolt branch -vv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "[origin/master: ahead 1]" ]] || false
+
+    cd dolt-repo-clones
+    dolt clone file://../remotedir test-repo
+    cd test-repo
+    dolt sql -q "INSERT INTO test VALUES (2)"
+    dolt add test
+    dolt commit -m "remote commit 1"
+    dolt sql -q "INSERT INTO test VALUES (3)"
+    dolt add test
+    dolt commit -m "remote commit 2"
+    dolt push origin master
+    cd ../..
+
+    dolt fetch
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Your branch and 'origin/master' have diverged," ]] || false
+    [[ "$output" =~ "and have 1 and 2 different commits each, respectively." ]] || false
+    run dolt branch -vv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "[origin/master: ahead 1, behind 2]" ]] || false
+}
+
+@test "status and branch -vv work after the remote of the upstream is removed" {
+    dolt sql -q "CREATE TABLE test (pk BIGINT NOT NULL, PRIMARY KEY (pk))"
+    dolt add test
+    dolt commit -m "test commit"
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push --set-upstream origin master
+    dolt remote remove origin
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "Your branch" ]] || false
+    run dolt branch -vv
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "origin/master" ]] || false
+
+    dolt remote add origin file://remotedir
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "Your branch" ]] || false
+}
+
+@test "status does not compare branches without an upstream" {
+    dolt sql -q "CREATE TABLE test (pk BIGINT NOT NULL, PRIMARY KEY (pk))"
+    dolt add test
+    dolt commit -m "test commit"
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "Your branch" ]] || false
+    run dolt branch -vv
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "origin/master" ]] || false
+}
+
+@test "fetch --prune deletes remote tracking branches which were deleted on the remote" {
+    dolt sql -q "CREATE TABLE test (pk BIGINT NOT NULL, PRIMARY KEY (pk))"
+    dolt add test
+    dolt commit -m "test commit"
+    dolt branch feature
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+    dolt push origin feature
+    dolt fetch
+    run dolt branch -a
+    [[ "$output" =~ "remotes/origin/feature" ]] || false
+
+    cd dolt-repo-clones
+    dolt clone file://../remotedir test-repo
+    cd test-repo
+    dolt fetch
+    dolt push origin :feature
+    cd ../..
+
+    run dolt fetch
+    [ "$status" -eq 0 ]
+    run dolt branch -a
+    [[ "$output" =~ "remotes/origin/feature" ]] || false
+
+    run dolt fetch --prune
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "[pruned] origin/feature" ]] || false
+    run dolt branch -a
+    [[ ! "$output" =~ "remotes/origin/feature" ]] || false
+    [[ "$output" =~ "remotes/origin/master" ]] || false
+    [[ "$output" =~ "feature" ]] || false
+}
+
+@test "remote prune deletes remote tracking branches which were deleted on the remote" {
+    dolt sql -q "CREATE TABLE test (pk BIGINT NOT NULL, PRIMARY KEY (pk))"
+    dolt add test
+    dolt commit -m "test commit"
+    dolt branch feature
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+    dolt push origin feature
+    dolt fetch
+
+    cd dolt-repo-clones
+    dolt clone file://../remotedir test-repo
+    cd test-repo
+    dolt fetch
+    dolt push origin :feature
+    cd ../..
+
+    run dolt branch -a
+    [[ "$output" =~ "remotes/origin/feature" ]] || false
+
+    run dolt remote prune origin
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "[pruned] origin/feature" ]] || false
+    run dolt branch -a
+    [[ ! "$output" =~ "remotes/origin/feature" ]] || false
+    [[ "$output" =~ "remotes/origin/master" ]] || false
+
+    run dolt remote prune notaremote
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown remote" ]] || false
+}
@@ -712,3 +712,36 @@ SQL
     dolt pull
     [ "$status" -eq 0 ]
 }
+
+@test "pull --rebase replays local commits on top of the remote branch" {
+    dolt remote add test-remote http://localhost:50051/test-org/test-repo
+    dolt sql -q "CREATE TABLE test (pk BIGINT NOT NULL, c1 BIGINT, PRIMARY KEY (pk))"
+    dolt add test
+    dolt commit -m "test commit"
+    dolt push test-remote master
+    cd "dolt-repo-clones"
+    dolt clone http://localhost:50051/test-org/test-repo
+    cd test-repo
+    dolt sql -q "INSERT INTO test VALUES (1, 1)"
+    dolt add test
+    dolt commit -m "local commit"
+
+    cd ../../
+    dolt sql -q "INSERT INTO test VALUES (2, 2)"
+    dolt add test
+    dolt commit -m "remote commit"
+    dolt push test-remote master
+
+    cd dolt-repo-clones/test-repo
+    run dolt pull --rebase test-remote
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased" ]] || false
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "${lines[3]}" =~ "local commit" ]] || false
+    [[ "$output" =~ "remote commit" ]] || false
+    [[ ! "$output" =~ "Merge" ]] || false
+    run dolt sql -q "SELECT * FROM test"
+    [[ "$output" =~ "1" ]] || false
+    [[ "$output" =~ "2" ]] || false
+}
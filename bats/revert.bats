@@ -0,0 +1,151 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1, 1), (2, 2);
+SQL
+
+    dolt add .
+    dolt commit -m "added table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "revert undoes only the changes made by the commit" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt sql -q "UPDATE test SET c1 = 20 WHERE pk = 2"
+    dolt add test
+    dolt commit -m "bad load"
+    dolt sql -q "INSERT INTO test VALUES (4, 4)"
+    dolt add test
+    dolt commit -m "add pk 4"
+
+    run dolt revert HEAD~1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'Revert "bad load"' ]] || false
+    [[ "$output" =~ "This reverts commit" ]] || false
+
+    run dolt sql -q "SELECT * FROM test WHERE pk = 2"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "20" ]] || false
+    run dolt sql -q "SELECT * FROM test WHERE pk = 3"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "3" ]] || false
+    run dolt sql -q "SELECT * FROM test WHERE pk = 4"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "working tree clean" ]] || false
+
+    run dolt revert HEAD~2
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "already been undone" ]] || false
+}
+
+@test "revert with conflicts" {
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "update pk 1"
+    dolt sql -q "UPDATE test SET c1 = 100 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "update pk 1 again"
+
+    run dolt revert HEAD~1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "could not revert" ]] || false
+
+    run dolt conflicts cat test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "100" ]] || false
+
+    dolt conflicts resolve --theirs test
+    dolt add test
+    dolt commit -m "revert update pk 1"
+    run dolt sql -q "SELECT * FROM test WHERE pk = 1"
+    [[ ! "$output" =~ "100" ]] || false
+}
+
+@test "revert with conflicts can be committed with the generated message" {
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "update pk 1"
+    dolt sql -q "UPDATE test SET c1 = 100 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "update pk 1 again"
+
+    run dolt revert HEAD~1
+    [ "$status" -eq 1 ]
+
+    run dolt status
+    [[ "$output" =~ "You are currently reverting commit" ]] || false
+    [[ "$output" =~ "dolt revert --abort" ]] || false
+
+    run dolt revert HEAD
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "unmerged tables" ]] || false
+
+    dolt conflicts resolve --theirs test
+    dolt add test
+    run dolt status
+    [[ "$output" =~ "all conflicts fixed" ]] || false
+
+    EDITOR=true run dolt commit
+    [ "$status" -eq 0 ]
+    run dolt log -n 1
+    [[ "$output" =~ 'Revert "update pk 1"' ]] || false
+    run dolt status
+    [[ ! "$output" =~ "reverting" ]] || false
+}
+
+@test "revert --abort restores the state before the revert" {
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "update pk 1"
+    dolt sql -q "UPDATE test SET c1 = 100 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "update pk 1 again"
+
+    run dolt revert --abort
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no revert to abort" ]] || false
+
+    run dolt revert HEAD~1
+    [ "$status" -eq 1 ]
+
+    run dolt revert --abort
+    [ "$status" -eq 0 ]
+    run dolt status
+    [[ "$output" =~ "working tree clean" ]] || false
+    run dolt conflicts cat test
+    [[ ! "$output" =~ "100" ]] || false
+    run dolt sql -q "SELECT * FROM test WHERE pk = 1"
+    [[ "$output" =~ "100" ]] || false
+}
+
+@test "revert requires a clean working set" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt add test
+    dolt commit -m "add pk 3"
+    dolt sql -q "INSERT INTO test VALUES (4, 4)"
+
+    run dolt revert HEAD
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "local changes would be overwritten by revert" ]] || false
+}
+
+@test "revert of the initial commit is rejected" {
+    run dolt revert HEAD~1
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no parents" ]] || false
+}
@@ -0,0 +1,170 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  a INT,
+  b VARCHAR(10),
+  c TEXT,
+  PRIMARY KEY (pk)
+);
+SQL
+    dolt sql -q "INSERT INTO test VALUES (1, 1, 'one', 'x'), (2, 2, 'two', 'y')"
+    dolt add test
+    dolt commit -m "added table"
+    dolt branch other
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "merge keeps a rename on one side and type changes on the other" {
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test RENAME COLUMN a TO aa"
+    dolt sql -q "UPDATE test SET aa = 10 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "renamed a"
+
+    dolt checkout master
+    dolt sql -q "ALTER TABLE test MODIFY COLUMN a BIGINT"
+    dolt sql -q "ALTER TABLE test MODIFY COLUMN b VARCHAR(20)"
+    dolt sql -q "UPDATE test SET a = 3000000000, b = 'twenty-chars-long' WHERE pk = 2"
+    dolt add test
+    dolt commit -m "widened a and b"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt schema show test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`aa\` BIGINT" ]] || false
+    [[ "$output" =~ "\`b\` VARCHAR(20)" ]] || false
+
+    run dolt sql -r csv -q "SELECT * FROM test ORDER BY pk"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,10,one,x" ]] || false
+    [[ "$output" =~ "2,3000000000,twenty-chars-long,y" ]] || false
+}
+
+@test "merge combines columns and indexes added on both sides" {
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test ADD COLUMN d BIGINT"
+    dolt sql -q "CREATE INDEX idx_c ON test (c)"
+    dolt sql -q "UPDATE test SET d = 5 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "added d and idx_c"
+
+    dolt checkout master
+    dolt sql -q "ALTER TABLE test ADD COLUMN d BIGINT"
+    dolt sql -q "CREATE INDEX idx_b ON test (b)"
+    dolt sql -q "UPDATE test SET d = 7 WHERE pk = 2"
+    dolt add test
+    dolt commit -m "added d and idx_b"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt schema show test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "INDEX \`idx_b\`" ]] || false
+    [[ "$output" =~ "INDEX \`idx_c\`" ]] || false
+
+    run dolt sql -r csv -q "SELECT pk, d FROM test WHERE c = 'x'"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,5" ]] || false
+    run dolt sql -r csv -q "SELECT pk, d FROM test WHERE b = 'two'"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2,7" ]] || false
+}
+
+@test "clashing schema changes are schema conflicts" {
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test RENAME COLUMN a TO theirs_a"
+    dolt add test
+    dolt commit -m "renamed a to theirs_a"
+
+    dolt checkout master
+    dolt sql -q "ALTER TABLE test RENAME COLUMN a TO ours_a"
+    dolt add test
+    dolt commit -m "renamed a to ours_a"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT (schema): Merge conflict in test" ]] || false
+
+    run dolt conflicts cat test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "renamed to 'ours_a' on our side and 'theirs_a' on their side" ]] || false
+
+    run dolt add test
+    [ "$status" -eq 1 ]
+
+    run dolt conflicts resolve test 1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "schema conflict" ]] || false
+}
+
+@test "resolving a schema conflict merges the rows with the picked schema" {
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test RENAME COLUMN a TO theirs_a"
+    dolt sql -q "UPDATE test SET c = 'theirs' WHERE pk = 1"
+    dolt sql -q "INSERT INTO test VALUES (3, 3, 'three', 'z')"
+    dolt add test
+    dolt commit -m "renamed a to theirs_a"
+
+    dolt checkout master
+    dolt sql -q "ALTER TABLE test RENAME COLUMN a TO ours_a"
+    dolt sql -q "UPDATE test SET c = 'ours' WHERE pk = 2"
+    dolt add test
+    dolt commit -m "renamed a to ours_a"
+
+    dolt merge other
+    run dolt conflicts resolve --theirs test
+    [ "$status" -eq 0 ]
+
+    run dolt schema show test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "theirs_a" ]] || false
+    [[ ! "$output" =~ "ours_a" ]] || false
+
+    run dolt sql -r csv -q "SELECT * FROM test ORDER BY pk"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,1,one,theirs" ]] || false
+    [[ "$output" =~ "2,2,two,ours" ]] || false
+    [[ "$output" =~ "3,3,three,z" ]] || false
+
+    dolt add test
+    dolt commit -m "merged other"
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+}
+
+@test "a schema conflict can be fixed by altering our table before resolving" {
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test RENAME COLUMN a TO theirs_a"
+    dolt add test
+    dolt commit -m "renamed a to theirs_a"
+
+    dolt checkout master
+    dolt sql -q "ALTER TABLE test RENAME COLUMN a TO ours_a"
+    dolt add test
+    dolt commit -m "renamed a to ours_a"
+
+    dolt merge other
+    dolt sql -q "ALTER TABLE test RENAME COLUMN ours_a TO both_a"
+    run dolt conflicts resolve --ours test
+    [ "$status" -eq 0 ]
+
+    run dolt schema show test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "both_a" ]] || false
+
+    dolt add test
+    dolt commit -m "merged other"
+}
@@ -0,0 +1,96 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+    mkdir "dolt-repo-clones"
+
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  PRIMARY KEY (pk)
+);
+SQL
+    dolt add test
+    dolt commit -m "commit 0"
+
+    for i in 1 2 3 4 5; do
+        dolt sql -q "INSERT INTO test VALUES ($i)"
+        dolt add test
+        dolt commit -m "commit $i"
+    done
+
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "clone --depth only clones the last commits" {
+    cd dolt-repo-clones
+    run dolt clone --depth 2 file://../remotedir test-repo
+    [ "$status" -eq 0 ]
+    cd test-repo
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "commit 5" ]] || false
+    [[ "$output" =~ "commit 4" ]] || false
+    [[ ! "$output" =~ "commit 3" ]] || false
+    [[ ! "$output" =~ "Initialize data repository" ]] || false
+    [ -f .dolt/shallow ]
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [[ "$output" =~ "5" ]] || false
+}
+
+@test "commits made in a shallow clone can be pushed" {
+    cd dolt-repo-clones
+    dolt clone --depth 1 file://../remotedir test-repo
+    cd test-repo
+    dolt sql -q "INSERT INTO test VALUES (6)"
+    dolt add test
+    dolt commit -m "commit 6"
+    run dolt push origin master
+    [ "$status" -eq 0 ]
+    cd ../..
+    run dolt pull
+    [ "$status" -eq 0 ]
+    run dolt log -n 1
+    [[ "$output" =~ "commit 6" ]] || false
+}
+
+@test "fetch --deepen extends the history of a shallow clone" {
+    cd dolt-repo-clones
+    dolt clone --depth 1 file://../remotedir test-repo
+    cd test-repo
+    run dolt fetch --deepen 2
+    [ "$status" -eq 0 ]
+    run dolt log
+    [[ "$output" =~ "commit 3" ]] || false
+    [[ ! "$output" =~ "commit 2" ]] || false
+    run dolt fetch --deepen 10
+    [ "$status" -eq 0 ]
+    run dolt log
+    [[ "$output" =~ "Initialize data repository" ]] || false
+    [ ! -f .dolt/shallow ]
+}
+
+@test "fetch --deepen fails in a complete clone" {
+    cd dolt-repo-clones
+    dolt clone file://../remotedir test-repo
+    cd test-repo
+    run dolt fetch --deepen 1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "does not make sense" ]] || false
+}
+
+@test "clone --depth must be positive" {
+    cd dolt-repo-clones
+    run dolt clone --depth 0 file://../remotedir test-repo
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "is not a positive number" ]] || false
+}
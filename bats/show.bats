@@ -0,0 +1,91 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1, 1), (2, 2);
+SQL
+
+    dolt add .
+    dolt commit -m "added table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "show prints the metadata and row diff of HEAD" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt add test
+    dolt commit -m "added a row"
+    run dolt show
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added a row" ]] || false
+    [[ "$output" =~ "Author:" ]] || false
+    [[ "$output" =~ "diff --dolt a/test b/test" ]] || false
+    [[ "$output" =~ "|  +  | 3  | 3  |" ]] || false
+    [[ ! "$output" =~ "| 2  | 2  |" ]] || false
+}
+
+@test "show accepts ancestor specs" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt add test
+    dolt commit -m "added a row"
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "updated a row"
+    run dolt show HEAD~1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added a row" ]] || false
+    [[ ! "$output" =~ "updated a row" ]] || false
+    [[ "$output" =~ "|  +  | 3  | 3  |" ]] || false
+}
+
+@test "show prints schema changes" {
+    dolt sql -q "ALTER TABLE test ADD COLUMN c2 BIGINT"
+    dolt add test
+    dolt commit -m "added a column"
+    run dolt show
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CREATE TABLE test" ]] || false
+    [[ "$output" =~ "+   \`c2\` BIGINT" ]] || false
+}
+
+@test "show --stat prints a summary of the changes" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt add test
+    dolt commit -m "added a row"
+    run dolt show --stat
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1 Row Added" ]] || false
+    [[ ! "$output" =~ "|  +  |" ]] || false
+}
+
+@test "show lists all the parents of a merge commit" {
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt add test
+    dolt commit -m "commit on other"
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (4, 4)"
+    dolt add test
+    dolt commit -m "commit on master"
+    dolt merge other
+    dolt commit -m "merged other"
+    run dolt show
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Merge:" ]] || false
+    [[ "$output" =~ "merged other" ]] || false
+}
+
+@test "show fails for an unknown commit" {
+    run dolt show notacommit
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'notacommit' not found" ]] || false
+}
@@ -0,0 +1,75 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt creds new
+    dolt creds use `current_pub_key`
+    dolt sql -q "CREATE TABLE test (pk BIGINT NOT NULL, PRIMARY KEY (pk))"
+    dolt add test
+}
+
+teardown() {
+    teardown_common
+}
+
+current_pub_key() {
+    dolt creds ls -v | grep '^\*' | awk '{print $2}'
+}
+
+@test "commit -S signs the commit with the current credentials" {
+    run dolt commit -S -m "signed commit"
+    [ "$status" -eq 0 ]
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt add test
+    dolt commit -m "unsigned commit"
+
+    run dolt log --show-signature
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Good signature with untrusted key" ]] || false
+    run dolt log
+    [[ ! "$output" =~ "signature" ]] || false
+}
+
+@test "verify-commit checks commits against the trusted keys" {
+    dolt commit -S -m "signed commit"
+    echo "# trusted keys" > trusted_keys
+    echo "`current_pub_key` Bats Tests" >> trusted_keys
+
+    run dolt verify-commit --trusted-keys trusted_keys HEAD
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'Good signature from "Bats Tests"' ]] || false
+
+    run dolt verify-commit --trusted-keys trusted_keys HEAD~1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "No signature" ]] || false
+
+    run dolt verify-commit --trusted-keys trusted_keys HEAD HEAD~1
+    [ "$status" -eq 1 ]
+
+    echo "" > empty_keys
+    run dolt verify-commit --trusted-keys empty_keys HEAD
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Good signature with untrusted key" ]] || false
+}
+
+@test "verify-commit fails for an invalid trusted keys file" {
+    dolt commit -S -m "signed commit"
+    echo "notakey Bats Tests" > trusted_keys
+    run dolt verify-commit --trusted-keys trusted_keys HEAD
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'notakey' is not a valid public key" ]] || false
+}
+
+@test "signatures are kept when commits are pushed and cloned" {
+    dolt commit -S -m "signed commit"
+    echo "`current_pub_key`" > trusted_keys
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+    dolt clone file://remotedir clone
+    cd clone
+    run dolt verify-commit --trusted-keys ../trusted_keys HEAD
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Good signature with key" ]] || false
+}
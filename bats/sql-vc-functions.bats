@@ -0,0 +1,206 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (0, 0), (1, 1);
+SQL
+    dolt add test
+    dolt commit -m "added test"
+}
+
+teardown() {
+    rm -rf "$BATS_TMPDIR/worktree-$$"
+    teardown_common
+}
+
+@test "DOLT_BRANCH creates and deletes branches" {
+    run dolt sql -q "SELECT DOLT_BRANCH('feature')"
+    [ "$status" -eq 0 ]
+    run dolt branch
+    [[ "$output" =~ "feature" ]] || false
+
+    run dolt sql -q "SELECT DOLT_BRANCH('feature')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "already exists" ]] || false
+
+    run dolt sql -q "SELECT DOLT_BRANCH('-x', 'other')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown argument" ]] || false
+
+    run dolt sql -q "SELECT DOLT_BRANCH('-d', 'feature')"
+    [ "$status" -eq 0 ]
+    run dolt branch
+    [[ ! "$output" =~ "feature" ]] || false
+}
+
+@test "DOLT_BRANCH refuses to delete or reset a checked out branch" {
+    dolt branch feature
+    run dolt sql -q "SELECT DOLT_BRANCH('-d', '-f', 'master')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "attempted to delete checked out branch" ]] || false
+    run dolt sql -q "SELECT DOLT_BRANCH('-f', 'master', 'feature')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "attempted to force update checked out branch" ]] || false
+
+    dolt branch other
+    run dolt sql <<SQL
+SELECT DOLT_CHECKOUT('feature');
+SELECT DOLT_BRANCH('-d', '-f', 'feature');
+SQL
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "attempted to delete checked out branch" ]] || false
+    run dolt sql <<SQL
+SELECT DOLT_CHECKOUT('feature');
+SELECT DOLT_BRANCH('-f', 'feature', 'other');
+SQL
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "attempted to force update checked out branch" ]] || false
+    run dolt branch
+    [[ "$output" =~ "feature" ]] || false
+}
+
+@test "DOLT_BRANCH refuses to delete or reset a branch checked out in a worktree" {
+    dolt branch feature
+    dolt worktree add "$BATS_TMPDIR/worktree-$$" feature
+    run dolt sql -q "SELECT DOLT_BRANCH('-d', '-f', 'feature')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'feature' is already checked out at" ]] || false
+    run dolt sql -q "SELECT DOLT_BRANCH('-f', 'feature', 'HEAD')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'feature' is already checked out at" ]] || false
+    run dolt branch
+    [[ "$output" =~ "feature" ]] || false
+}
+
+@test "DOLT_CHECKOUT switches branches and discards table changes" {
+    dolt branch feature
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 0"
+    run dolt sql -q "SELECT DOLT_CHECKOUT('test')"
+    [ "$status" -eq 0 ]
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+
+    run dolt sql -r csv <<SQL
+SELECT DOLT_CHECKOUT('feature');
+INSERT INTO test VALUES (2, 2);
+SELECT DOLT_ADD('test');
+SQL
+    [ "$status" -eq 0 ]
+    run dolt status
+    [[ "$output" =~ "On branch feature" ]] || false
+    [[ "$output" =~ "Changes to be committed" ]] || false
+
+    run dolt sql -q "SELECT DOLT_CHECKOUT('-b', 'feature2')"
+    [ "$status" -eq 0 ]
+    run dolt status
+    [[ "$output" =~ "On branch feature2" ]] || false
+    [[ "$output" =~ "Changes to be committed" ]] || false
+
+    dolt checkout master
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 0"
+    dolt add test
+    dolt commit -m "changed master"
+    dolt sql -q "UPDATE test SET c1 = 11 WHERE pk = 0"
+    run dolt sql -q "SELECT DOLT_CHECKOUT('feature')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "would be overwritten by checkout: test" ]] || false
+
+    run dolt sql -q "SELECT DOLT_CHECKOUT('not_a_table')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "did not match any table" ]] || false
+}
+
+@test "DOLT_MERGE reports conflicts, which are resolved and committed as a merge" {
+    dolt checkout -b feature
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 0"
+    dolt add test
+    dolt commit -m "changed feature"
+    dolt checkout master
+    dolt sql -q "UPDATE test SET c1 = 20 WHERE pk = 0"
+    dolt add test
+    dolt commit -m "changed master"
+
+    run dolt sql -r csv <<SQL
+SELECT DOLT_MERGE('feature');
+SELECT DOLT_ADD('test');
+DELETE FROM dolt_conflicts_test;
+SELECT DOLT_ADD('test');
+SQL
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "1" ]] || false
+    [[ "${lines[3]}" = "1" ]] || false
+    [[ "${lines[5]}" = "0" ]] || false
+
+    dolt commit -m "merged feature"
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+    run dolt log
+    [[ "$output" =~ "Merge:" ]] || false
+    run dolt sql -r csv -q "SELECT c1 FROM test WHERE pk = 0"
+    [[ "$output" =~ "20" ]] || false
+}
+
+@test "DOLT_MERGE stages a fast-forward merge" {
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (2, 2)"
+    dolt add test
+    dolt commit -m "added a row on feature"
+    dolt checkout master
+
+    run dolt sql -r csv <<SQL
+SELECT DOLT_MERGE('feature');
+SELECT COUNT(*) FROM test;
+SQL
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "0" ]] || false
+    [[ "${lines[3]}" = "3" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "Changes to be committed" ]] || false
+    [[ ! "$output" =~ "Changes not staged" ]] || false
+}
+
+@test "DOLT_MERGE refuses to overwrite local changes" {
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (2, 2)"
+    dolt add test
+    dolt commit -m "added a row on feature"
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+
+    run dolt sql -q "SELECT DOLT_MERGE('feature')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "would be overwritten by merge: test" ]] || false
+}
+
+@test "DOLT_ADD and DOLT_RESET stage and unstage tables" {
+    dolt sql -q "INSERT INTO test VALUES (2, 2)"
+    run dolt sql -q "SELECT DOLT_ADD('.')"
+    [ "$status" -eq 0 ]
+    run dolt status
+    [[ "$output" =~ "Changes to be committed" ]] || false
+    [[ ! "$output" =~ "Changes not staged" ]] || false
+
+    run dolt sql -q "SELECT DOLT_RESET('test')"
+    [ "$status" -eq 0 ]
+    run dolt status
+    [[ ! "$output" =~ "Changes to be committed" ]] || false
+    [[ "$output" =~ "Changes not staged" ]] || false
+
+    dolt sql -q "CREATE TABLE untracked (pk BIGINT NOT NULL PRIMARY KEY)"
+    run dolt sql -q "SELECT DOLT_RESET('--hard')"
+    [ "$status" -eq 0 ]
+    run dolt status
+    [[ ! "$output" =~ "modified" ]] || false
+    [[ "$output" =~ "untracked" ]] || false
+
+    run dolt sql -q "SELECT DOLT_ADD('not_a_table')"
+    [ "$status" -eq 1 ]
+}
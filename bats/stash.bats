@@ -0,0 +1,158 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1, 1), (2, 2);
+SQL
+
+    dolt add .
+    dolt commit -m "added table"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "stash with no changes" {
+    run dolt stash
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No local changes to save" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+}
+
+@test "stash push and pop restores working and staged tables" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt add test
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 1"
+
+    run dolt stash push -m "my work"
+    [ "$status" -eq 0 ]
+
+    run dolt status
+    [[ "$output" =~ "working tree clean" ]] || false
+    run dolt sql -q "SELECT * FROM test WHERE pk = 3"
+    [[ ! "$output" =~ "3" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "stash@{0}: On master: my work" ]] || false
+
+    run dolt stash pop
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Dropped stash@{0}" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "Changes to be committed" ]] || false
+    [[ "$output" =~ "Changes not staged for commit" ]] || false
+    run dolt sql -q "SELECT * FROM test WHERE pk = 1"
+    [[ "$output" =~ "10" ]] || false
+    run dolt sql -q "SELECT * FROM test WHERE pk = 3"
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt stash list
+    [ "$output" = "" ]
+}
+
+@test "stash apply keeps the entry and drop removes it" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt stash
+    dolt sql -q "INSERT INTO test VALUES (4, 4)"
+    dolt stash
+
+    run dolt stash list
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[0]}" =~ "stash@{0}: WIP on master" ]] || false
+
+    run dolt stash apply stash@{1}
+    [ "$status" -eq 0 ]
+    run dolt sql -q "SELECT * FROM test WHERE pk = 3"
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt stash list
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt stash drop 1
+    [ "$status" -eq 0 ]
+    run dolt stash list
+    [ "${#lines[@]}" -eq 1 ]
+
+    run dolt stash drop stash@{5}
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "does not exist" ]] || false
+}
+
+@test "stash entries are not listed as branches" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt stash
+
+    run dolt branch -a
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "master" ]] || false
+    [[ ! "$output" =~ "  0  " ]] || false
+}
+
+@test "stash pop onto a new commit" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+    dolt stash
+    dolt sql -q "INSERT INTO test VALUES (4, 4)"
+    dolt add test
+    dolt commit -m "add pk 4"
+
+    run dolt stash pop
+    [ "$status" -eq 0 ]
+    run dolt sql -q "SELECT * FROM test"
+    [[ "$output" =~ "3" ]] || false
+    [[ "$output" =~ "4" ]] || false
+}
+
+@test "stash pop with conflicts keeps the entry" {
+    dolt sql -q "UPDATE test SET c1 = 100 WHERE pk = 1"
+    dolt stash
+    dolt sql -q "UPDATE test SET c1 = 200 WHERE pk = 1"
+    dolt add test
+    dolt commit -m "update pk 1"
+
+    run dolt stash pop
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "stash entry is kept" ]] || false
+
+    run dolt stash list
+    [ "${#lines[@]}" -eq 1 ]
+
+    run dolt conflicts cat test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "100" ]] || false
+    [[ "$output" =~ "200" ]] || false
+}
+
+@test "stash pop with conflicting staged changes keeps the entry and the working set" {
+    dolt sql -q "UPDATE test SET c1 = 2 WHERE pk = 1"
+    dolt add test
+    dolt sql -q "UPDATE test SET c1 = 5 WHERE pk = 1"
+    dolt stash
+    dolt sql -q "UPDATE test SET c1 = 3 WHERE pk = 1"
+    dolt add test
+    dolt sql -q "UPDATE test SET c1 = 5 WHERE pk = 1"
+
+    run dolt stash pop
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "staged changes in stash@{0} conflict" ]] || false
+
+    run dolt stash list
+    [ "${#lines[@]}" -eq 1 ]
+
+    run dolt diff
+    [[ "$output" =~ "<  | 1  | 3" ]] || false
+    [[ "$output" =~ ">  | 1  | 5" ]] || false
+}
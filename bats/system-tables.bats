@@ -17,6 +17,7 @@ teardown() {
     [ $status -eq 0 ]
     [[ "$output" =~ "dolt_log" ]] || false
     [[ "$output" =~ "dolt_branches" ]] || false
+    [[ "$output" =~ "dolt_status" ]] || false
     [[ "$output" =~ "dolt_query_catalog" ]] || false
     [[ ! "$output" =~ " test" ]] || false  # spaces are impt!
     run dolt ls --all
@@ -109,6 +110,55 @@ teardown() {
     [[ "$output" =~ "create-table-branch" ]] || false
 }
 
+@test "query dolt_status system table" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt sql -q "create table dropped (pk int, primary key(pk))"
+    dolt add .
+    dolt commit -m "Added tables"
+    run dolt sql -r csv -q "select * from dolt_status"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+
+    dolt sql -q "insert into test values (0, 0)"
+    dolt add test
+    dolt sql -q "insert into test values (1, 1)"
+    dolt sql -q "drop table dropped"
+    dolt sql -q "create table added (pk int, primary key(pk))"
+    run dolt sql -r csv -q "select * from dolt_status order by table_name, staged"
+    [ $status -eq 0 ]
+    [ "${lines[0]}" = "table_name,staged,status" ]
+    [ "${lines[1]}" = "added,false,new table" ]
+    [ "${lines[2]}" = "dropped,false,deleted" ]
+    [ "${lines[3]}" = "test,false,modified" ]
+    [ "${lines[4]}" = "test,true,modified" ]
+    [ "${#lines[@]}" -eq 5 ]
+
+    run dolt sql -r csv -q "select table_name from dolt_status where staged = true"
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "test" ]
+    [ "${#lines[@]}" -eq 2 ]
+}
+
+@test "dolt_status system table shows tables in conflict" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt sql -q "insert into test values (0, 0)"
+    dolt add test
+    dolt commit -m "Added test table"
+    dolt checkout -b other
+    dolt sql -q "update test set c1 = 1 where pk = 0"
+    dolt add test
+    dolt commit -m "changed c1 on other"
+    dolt checkout master
+    dolt sql -q "update test set c1 = 2 where pk = 0"
+    dolt add test
+    dolt commit -m "changed c1 on master"
+    dolt merge other
+
+    run dolt sql -r csv -q "select * from dolt_status"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "test,false,conflict" ]] || false
+}
+
 @test "query dolt_diff_ system table" {
     dolt sql -q "CREATE TABLE test (pk INT, c1 INT, PRIMARY KEY(pk))"
     dolt add test
@@ -174,3 +224,84 @@ teardown() {
     [ $status -eq 0 ]
     [ "${#lines[@]}" -eq 6 ]
 }
+
+@test "query dolt_conflicts and dolt_conflicts_ system tables" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt sql -q "insert into test values (0,0), (1,1)"
+    dolt add test
+    dolt commit -m "Added test table"
+    dolt branch other
+    dolt sql -q "update test set c1 = 10 where pk = 0"
+    dolt sql -q "delete from test where pk = 1"
+    dolt add test
+    dolt commit -m "changed on master"
+    dolt checkout other
+    dolt sql -q "update test set c1 = 11 where pk = 0"
+    dolt sql -q "update test set c1 = 21 where pk = 1"
+    dolt add test
+    dolt commit -m "changed on other"
+    dolt checkout master
+    run dolt sql -r csv -q "select * from dolt_conflicts_test"
+    [ $status -eq 1 ]
+    [[ "$output" =~ "table not found" ]] || false
+    dolt merge other
+    run dolt ls --system
+    [ $status -eq 0 ]
+    [[ "$output" =~ "dolt_conflicts_test" ]] || false
+    run dolt sql -r csv -q "select * from dolt_conflicts"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "test,2,false" ]] || false
+    run dolt sql -r csv -q "select base_pk, base_c1, our_pk, our_c1, their_pk, their_c1 from dolt_conflicts_test order by base_pk"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "0,0,0,10,0,11" ]] || false
+    [[ "$output" =~ "1,1,,,1,21" ]] || false
+}
+
+@test "resolve conflicts using the dolt_conflicts_ system table" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk), index idx_c1 (c1))"
+    dolt sql -q "insert into test values (0,0), (1,1), (2,2)"
+    dolt add test
+    dolt commit -m "Added test table"
+    dolt branch other
+    dolt sql -q "update test set c1 = 10 where pk = 0"
+    dolt sql -q "delete from test where pk = 1"
+    dolt sql -q "update test set c1 = 20 where pk = 2"
+    dolt add test
+    dolt commit -m "changed on master"
+    dolt checkout other
+    dolt sql -q "update test set c1 = 11 where pk = 0"
+    dolt sql -q "update test set c1 = 21 where pk = 1"
+    dolt sql -q "update test set c1 = 22 where pk = 2"
+    dolt add test
+    dolt commit -m "changed on other"
+    dolt checkout master
+    dolt merge other
+
+    dolt sql -q "update dolt_conflicts_test set our_pk = their_pk, our_c1 = their_c1 where base_pk < 2"
+    run dolt sql -r csv -q "select pk, c1 from test where c1 > 10 order by pk"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "0,11" ]] || false
+    [[ "$output" =~ "1,21" ]] || false
+    run dolt sql -r csv -q "select * from dolt_conflicts"
+    [[ "$output" =~ "test,3,false" ]] || false
+
+    run dolt sql -q "update dolt_conflicts_test set our_pk = 5 where base_pk = 2"
+    [ $status -eq 1 ]
+    [[ "$output" =~ "primary key of a conflict cannot be modified" ]] || false
+
+    dolt sql -q "delete from dolt_conflicts_test where base_pk < 2"
+    run dolt sql -r csv -q "select * from dolt_conflicts"
+    [[ "$output" =~ "test,1,false" ]] || false
+    run dolt add test
+    [ $status -eq 1 ]
+
+    dolt sql -q "delete from dolt_conflicts_test"
+    run dolt sql -r csv -q "select * from dolt_conflicts"
+    [[ ! "$output" =~ "test" ]] || false
+    dolt add test
+    dolt commit -m "merged other"
+    run dolt sql -r csv -q "select * from test order by pk"
+    [[ "$output" =~ "0,11" ]] || false
+    [[ "$output" =~ "1,21" ]] || false
+    [[ "$output" =~ "2,20" ]] || false
+}
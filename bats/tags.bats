@@ -250,7 +250,7 @@ SQL
     dolt merge branch2
 }
 
-@test "Merging branches that use the same tag referring to different schema fails" {
+@test "Merging branches that use the same tag referring to different schema is a schema conflict" {
     dolt sql <<SQL
 CREATE TABLE test (
   pk BIGINT NOT NULL COMMENT 'tag:1234',
@@ -272,10 +272,14 @@ SQL
     dolt checkout master
     dolt merge branch1
     run dolt merge branch2
-    [ $status -ne 0 ]
+    [ $status -eq 0 ]
+    [[ "$output" =~ "CONFLICT (schema)" ]] || false
+    run dolt conflicts cat test
+    [ $status -eq 0 ]
+    [[ "$output" =~ "added with type BIGINT on our side and LONGTEXT on their side" ]] || false
 }
 
-@test "Merging branches that use the same tag referring to different column names fails" {
+@test "Merging branches that use the same tag referring to different column names is a schema conflict" {
     dolt sql <<SQL
 CREATE TABLE test (
   pk BIGINT NOT NULL COMMENT 'tag:1234',
@@ -297,7 +301,8 @@ SQL
     dolt checkout master
     dolt merge branch1
     run dolt merge branch2
-    [ $status -eq 1 ]
+    [ $status -eq 0 ]
+    [[ "$output" =~ "CONFLICT (schema)" ]] || false
 }
 
 @test "Merging branches that both created the same column succeeds" {
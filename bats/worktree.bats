@@ -0,0 +1,177 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE test (pk BIGINT NOT NULL, v BIGINT, PRIMARY KEY (pk))"
+    dolt sql -q "INSERT INTO test VALUES (1, 1)"
+    dolt add test
+    dolt commit -m "added table"
+    dolt branch feature
+    rm -rf "$BATS_TMPDIR/worktree-$$"
+}
+
+teardown() {
+    rm -rf "$BATS_TMPDIR/worktree-$$"
+    teardown_common
+}
+
+@test "worktree add creates a worktree sharing the repository's database" {
+    run dolt worktree add "$BATS_TMPDIR/worktree-$$" feature
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "with branch 'feature' checked out" ]] || false
+    [ ! -d "$BATS_TMPDIR/worktree-$$/.dolt/noms" ]
+
+    repo=`pwd`
+    cd "$BATS_TMPDIR/worktree-$$"
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "On branch feature" ]] || false
+
+    dolt sql -q "INSERT INTO test VALUES (2, 2)"
+    dolt add test
+    dolt commit -m "commit in worktree"
+
+    cd "$repo"
+    run dolt log feature
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "commit in worktree" ]] || false
+    run dolt sql -q "SELECT COUNT(*) FROM test"
+    [[ "$output" =~ "| 1 " ]] || false
+}
+
+@test "worktree list shows every worktree and its branch" {
+    dolt worktree add "$BATS_TMPDIR/worktree-$$" feature
+    run dolt worktree list
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "${lines[0]}" =~ "[master]" ]] || false
+    [[ "${lines[1]}" =~ "worktree-$$" ]] || false
+    [[ "${lines[1]}" =~ "[feature]" ]] || false
+
+    cd "$BATS_TMPDIR/worktree-$$"
+    run dolt worktree list
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+}
+
+@test "a branch can't be checked out in two worktrees" {
+    run dolt worktree add "$BATS_TMPDIR/worktree-$$" master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'master' is already checked out" ]] || false
+
+    dolt worktree add "$BATS_TMPDIR/worktree-$$" feature
+    run dolt checkout feature
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'feature' is already checked out at" ]] || false
+    run dolt branch -d -f feature
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'feature' is already checked out at" ]] || false
+    run dolt branch -m feature other
+    [ "$status" -eq 1 ]
+    run dolt branch -f feature master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'feature' is already checked out at" ]] || false
+    run dolt branch -c -f master feature
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'feature' is already checked out at" ]] || false
+
+    cd "$BATS_TMPDIR/worktree-$$"
+    run dolt checkout master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "'master' is already checked out at" ]] || false
+}
+
+@test "a branch can't be checked out by two worktrees at once" {
+    dolt branch other
+    dolt branch third
+    dolt worktree add "$BATS_TMPDIR/worktree-$$" feature
+    dolt worktree add "$BATS_TMPDIR/worktree-$$/other" other
+    repo=`pwd`
+
+    cd "$BATS_TMPDIR/worktree-$$"
+    dolt checkout third > checkout1.out 2>&1 &
+    pid1=$!
+    cd "$BATS_TMPDIR/worktree-$$/other"
+    dolt checkout third > ../checkout2.out 2>&1 &
+    pid2=$!
+
+    succeeded=0
+    wait $pid1 && succeeded=$((succeeded+1))
+    wait $pid2 && succeeded=$((succeeded+1))
+    [ "$succeeded" -eq 1 ]
+    cd "$repo"
+    run dolt worktree list
+    [ `echo "$output" | grep -c "\[third\]"` -eq 1 ]
+}
+
+@test "worktrees share the remotes of the repository" {
+    dolt worktree add "$BATS_TMPDIR/worktree-$$" feature
+    dolt remote add origin http://localhost:50051/test-org/origin
+    repo=`pwd`
+
+    cd "$BATS_TMPDIR/worktree-$$"
+    run dolt remote -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "origin" ]] || false
+    dolt remote add upstream http://localhost:50051/test-org/upstream
+    dolt remote remove origin
+
+    cd "$repo"
+    run dolt remote -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "upstream" ]] || false
+    [[ ! "$output" =~ "origin" ]] || false
+}
+
+@test "worktree add fails for a missing branch or a directory which isn't empty" {
+    run dolt worktree add "$BATS_TMPDIR/worktree-$$" missing
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not found" ]] || false
+
+    mkdir "$BATS_TMPDIR/worktree-$$"
+    touch "$BATS_TMPDIR/worktree-$$/file"
+    run dolt worktree add "$BATS_TMPDIR/worktree-$$" feature
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not empty" ]] || false
+}
+
+@test "gc keeps the uncommitted changes of other worktrees" {
+    dolt worktree add "$BATS_TMPDIR/worktree-$$" feature
+    repo=`pwd`
+    cd "$BATS_TMPDIR/worktree-$$"
+    dolt sql -q "INSERT INTO test VALUES (2, 2)"
+
+    cd "$repo"
+    dolt gc
+
+    cd "$BATS_TMPDIR/worktree-$$"
+    run dolt sql -q "SELECT * FROM test WHERE pk = 2"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "| 2 " ]] || false
+}
+
+@test "worktree remove and prune" {
+    dolt worktree add "$BATS_TMPDIR/worktree-$$" feature
+    cd "$BATS_TMPDIR/worktree-$$"
+    dolt sql -q "INSERT INTO test VALUES (2, 2)"
+    cd -
+
+    run dolt worktree remove "$BATS_TMPDIR/worktree-$$"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "uncommitted changes" ]] || false
+    run dolt worktree remove -f "$BATS_TMPDIR/worktree-$$"
+    [ "$status" -eq 0 ]
+    [ ! -d "$BATS_TMPDIR/worktree-$$" ]
+    dolt checkout feature
+
+    dolt checkout master
+    dolt worktree add "$BATS_TMPDIR/worktree-$$" feature
+    rm -rf "$BATS_TMPDIR/worktree-$$"
+    run dolt worktree list
+    [[ "$output" =~ "(missing)" ]] || false
+    run dolt worktree prune
+    [ "$status" -eq 0 ]
+    run dolt worktree list
+    [ "${#lines[@]}" -eq 1 ]
+}
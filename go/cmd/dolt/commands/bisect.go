@@ -0,0 +1,479 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"io"
+	"math/bits"
+	"strings"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	dsqle "github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+var bisectDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Use binary search to find the commit that introduced a bug",
+	LongDesc: `Finds the first commit in the history of the current branch which is bad, given a bad commit and one or more good commits which came before it. Only the first-parent history of the bad commit is searched. At each step the tables of the commit to be tested are checked out into the working set, without moving the current branch, and the commit is marked as good or bad until the first bad commit is found.
+
+{{.EmphasisLeft}}start{{.EmphasisRight}}
+Start a bisect. The working set must be clean. The bad commit and any number of good commits may be given, or marked afterwards.
+
+{{.EmphasisLeft}}bad{{.EmphasisRight}}
+Mark {{.LessThan}}commit{{.GreaterThan}} as bad. Defaults to the commit currently being tested.
+
+{{.EmphasisLeft}}good{{.EmphasisRight}}
+Mark each {{.LessThan}}commit{{.GreaterThan}} as good. Defaults to the commit currently being tested.
+
+{{.EmphasisLeft}}skip{{.EmphasisRight}}
+Skip testing each {{.LessThan}}commit{{.GreaterThan}}, as it can't be determined whether it is good or bad. Defaults to the commit currently being tested.
+
+{{.EmphasisLeft}}run{{.EmphasisRight}}
+Test commits automatically until the first bad commit is found. {{.LessThan}}query{{.GreaterThan}} is run against the tables of each commit, and the commit is bad if the query returns any rows and good otherwise.
+
+{{.EmphasisLeft}}reset{{.EmphasisRight}}
+End the bisect and restore the working set to what it was before the bisect began.`,
+	Synopsis: []string{
+		"start [{{.LessThan}}bad{{.GreaterThan}} [{{.LessThan}}good{{.GreaterThan}}...]]",
+		"(bad|good|skip) [{{.LessThan}}commit{{.GreaterThan}}...]",
+		"run {{.LessThan}}query{{.GreaterThan}}",
+		"reset",
+	},
+}
+
+const (
+	startBisectId = "start"
+	badBisectId   = "bad"
+	goodBisectId  = "good"
+	skipBisectId  = "skip"
+	runBisectId   = "run"
+	resetBisectId = "reset"
+)
+
+type BisectCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BisectCmd) Name() string {
+	return "bisect"
+}
+
+// Description returns a description of the command
+func (cmd BisectCmd) Description() string {
+	return "Use binary search to find the commit that introduced a bug."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd BisectCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, bisectDocs, ap))
+}
+
+func (cmd BisectCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "A commit, branch, or ancestor spec such as HEAD~2."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"query", "A SQL query which returns rows when run against the tables of a bad commit."})
+	return ap
+}
+
+// Exec executes the command
+func (cmd BisectCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, bisectDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	var verr errhand.VerboseError
+	if apr.Arg(0) != startBisectId && apr.Arg(0) != resetBisectId && !dEnv.IsBisectActive() {
+		bdr := errhand.BuildDError("error: there is no bisect in progress.")
+		bdr.AddDetails("hint: use 'dolt bisect start' to start one.")
+		return HandleVErrAndExitCode(bdr.Build(), usage)
+	}
+
+	switch apr.Arg(0) {
+	case startBisectId:
+		verr = bisectStart(ctx, dEnv, apr.Args()[1:])
+	case badBisectId:
+		if apr.NArg() > 2 {
+			verr = errhand.BuildDError("error: only one commit can be marked as bad.").Build()
+		} else {
+			verr = bisectMark(ctx, dEnv, badBisectId, apr.Args()[1:])
+		}
+	case goodBisectId, skipBisectId:
+		verr = bisectMark(ctx, dEnv, apr.Arg(0), apr.Args()[1:])
+	case runBisectId:
+		if apr.NArg() != 2 {
+			verr = errhand.BuildDError("").SetPrintUsage().Build()
+		} else {
+			verr = bisectRun(ctx, dEnv, apr.Arg(1))
+		}
+	case resetBisectId:
+		if apr.NArg() != 1 {
+			verr = errhand.BuildDError("").SetPrintUsage().Build()
+		} else {
+			verr = bisectReset(ctx, dEnv)
+		}
+	default:
+		verr = errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func bisectStart(ctx context.Context, dEnv *env.DoltEnv, specs []string) errhand.VerboseError {
+	if dEnv.IsBisectActive() {
+		bdr := errhand.BuildDError("error: a bisect is already in progress.")
+		bdr.AddDetails("hint: use 'dolt bisect reset' to end it first.")
+		return bdr.Build()
+	}
+
+	if verr := checkWorkingSetClean(ctx, dEnv, "bisect"); verr != nil {
+		return verr
+	}
+
+	hashes, verr := resolveBisectCommits(dEnv, dEnv.RepoState.CWBHeadRef().String(), specs)
+
+	if verr != nil {
+		return verr
+	}
+
+	err := dEnv.RepoState.StartBisect(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to start the bisect").AddCause(err).Build()
+	}
+
+	if len(hashes) > 0 {
+		dEnv.RepoState.Bisect.Bad = hashes[0]
+		dEnv.RepoState.Bisect.Good = hashes[1:]
+	}
+
+	_, verr = bisectNext(ctx, dEnv)
+	return verr
+}
+
+// bisectMark marks the commits given, or the commit being tested if none are given, as bad, good, or skipped and checks
+// out the next commit to test.
+func bisectMark(ctx context.Context, dEnv *env.DoltEnv, mark string, specs []string) errhand.VerboseError {
+	state := dEnv.RepoState.Bisect
+
+	var hashes []string
+	if len(specs) == 0 {
+		h, verr := currentBisectCommit(dEnv)
+
+		if verr != nil {
+			return verr
+		}
+
+		hashes = []string{h}
+	} else {
+		var verr errhand.VerboseError
+		hashes, verr = resolveBisectCommits(dEnv, state.Branch.Ref.String(), specs)
+
+		if verr != nil {
+			return verr
+		}
+	}
+
+	switch mark {
+	case badBisectId:
+		state.Bad = hashes[0]
+	case goodBisectId:
+		state.Good = append(state.Good, hashes...)
+	case skipBisectId:
+		state.Skipped = append(state.Skipped, hashes...)
+	}
+
+	_, verr := bisectNext(ctx, dEnv)
+	return verr
+}
+
+// bisectRun tests commits using query until the first bad commit is found, or there are no commits left to test.
+func bisectRun(ctx context.Context, dEnv *env.DoltEnv, query string) errhand.VerboseError {
+	state := dEnv.RepoState.Bisect
+
+	if state.Bad == "" || len(state.Good) == 0 {
+		bdr := errhand.BuildDError("error: a bad commit and at least one good commit must be marked before using 'dolt bisect run'.")
+		return bdr.Build()
+	}
+
+	for {
+		current, verr := currentBisectCommit(dEnv)
+
+		if verr != nil {
+			return verr
+		}
+
+		cli.Println("running", query)
+		hasRows, verr := bisectQueryHasRows(ctx, dEnv, query)
+
+		if verr != nil {
+			return verr
+		}
+
+		if hasRows {
+			state.Bad = current
+		} else {
+			state.Good = append(state.Good, current)
+		}
+
+		done, verr := bisectNext(ctx, dEnv)
+
+		if verr != nil || done {
+			return verr
+		}
+	}
+}
+
+func bisectReset(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	if !dEnv.IsBisectActive() {
+		cli.Println("We are not bisecting.")
+		return nil
+	}
+
+	branch := dEnv.RepoState.Bisect.Branch.Ref.GetPath()
+	err := dEnv.RepoState.ResetBisect(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to reset the bisect").AddCause(err).Build()
+	}
+
+	err = actions.SaveDocsFromWorking(ctx, dEnv)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to update docs to the working root").AddCause(err).Build()
+	}
+
+	cli.Printf("Switched back to branch '%s'\n", branch)
+	return nil
+}
+
+// bisectNext saves the bisect state and checks out the next commit which should be tested.  It returns true once there
+// is nothing left to test, either because the first bad commit has been found or because only skipped commits remain.
+func bisectNext(ctx context.Context, dEnv *env.DoltEnv) (bool, errhand.VerboseError) {
+	state := dEnv.RepoState.Bisect
+	err := dEnv.RepoState.Save(dEnv.FS)
+
+	if err != nil {
+		return false, errhand.BuildDError("error: failed to save the bisect state").AddCause(err).Build()
+	}
+
+	if state.Bad == "" {
+		if len(state.Good) == 0 {
+			cli.Println("status: waiting for both good and bad commits")
+		} else {
+			cli.Println("status: waiting for bad commit, good commit known")
+		}
+
+		return false, nil
+	} else if len(state.Good) == 0 {
+		cli.Println("status: waiting for good commit(s), bad commit known")
+		return false, nil
+	}
+
+	bad := hash.Parse(state.Bad)
+	good := parseBisectHashes(state.Good)
+	skipped := parseBisectHashes(state.Skipped)
+	step, err := actions.NextBisectStep(ctx, dEnv.DoltDB, bad, good, skipped)
+
+	if err == actions.ErrBisectBadIsGood {
+		bdr := errhand.BuildDError("error: the bad commit %s is an ancestor of a good commit.", state.Bad)
+		bdr.AddDetails("hint: mark the commits again, or use 'dolt bisect reset' to end the bisect.")
+		return false, bdr.Build()
+	} else if err != nil {
+		return false, errhand.BuildDError("error: failed to find the next commit to test").AddCause(err).Build()
+	}
+
+	if step.FirstBad != nil {
+		lc, err := newLogCommit(ctx, step.FirstBad)
+
+		if err != nil {
+			return false, errhand.BuildDError("error: failed to read commit %s", state.Bad).AddCause(err).Build()
+		}
+
+		cli.Printf("%s is the first bad commit\n", lc.hash.String())
+		cli.Println(strings.Join(formatCommit(lc, false), "\n"))
+		return true, nil
+	}
+
+	if step.Next == nil {
+		cli.Println("There are only 'skip'ped commits left to test.")
+		cli.Println("The first bad commit could be any of:")
+		for _, cm := range step.Candidates {
+			h, err := cm.HashOf()
+
+			if err != nil {
+				return false, errhand.BuildDError("error: failed to get commit hash").AddCause(err).Build()
+			}
+
+			cli.Println(h.String())
+		}
+
+		cli.Println("We cannot bisect more!")
+		return true, nil
+	}
+
+	lc, err := newLogCommit(ctx, step.Next)
+
+	if err != nil {
+		return false, errhand.BuildDError("error: failed to read the next commit to test").AddCause(err).Build()
+	}
+
+	if verr := checkoutBisectCommit(ctx, dEnv, step.Next, lc.hash); verr != nil {
+		return false, verr
+	}
+
+	left := step.Untested / 2
+	cli.Printf("Bisecting: %d revisions left to test after this (roughly %d steps)\n", left, bits.Len(uint(left)))
+	cli.Println(formatCommit(lc, true)[0])
+
+	return false, nil
+}
+
+// checkoutBisectCommit sets the working and staged tables to the tables of the commit given without moving the current
+// branch, and records it as the commit being tested.
+func checkoutBisectCommit(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit, h hash.Hash) errhand.VerboseError {
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get root value").AddCause(err).Build()
+	}
+
+	if verr := UpdateWorkingWithVErr(dEnv, root); verr != nil {
+		return verr
+	}
+
+	if verr := UpdateStagedWithVErr(dEnv, root); verr != nil {
+		return verr
+	}
+
+	dEnv.RepoState.Bisect.Current = h.String()
+	err = dEnv.RepoState.Save(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to save the bisect state").AddCause(err).Build()
+	}
+
+	err = actions.SaveDocsFromWorking(ctx, dEnv)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// currentBisectCommit returns the hash of the commit being tested, which is the head of the bisected branch until the
+// first commit to test has been checked out.
+func currentBisectCommit(dEnv *env.DoltEnv) (string, errhand.VerboseError) {
+	state := dEnv.RepoState.Bisect
+
+	if state.Current != "" {
+		return state.Current, nil
+	}
+
+	hashes, verr := resolveBisectCommits(dEnv, state.Branch.Ref.String(), []string{"HEAD"})
+
+	if verr != nil {
+		return "", verr
+	}
+
+	return hashes[0], nil
+}
+
+func resolveBisectCommits(dEnv *env.DoltEnv, cwb string, specs []string) ([]string, errhand.VerboseError) {
+	hashes := make([]string, len(specs))
+	for i, spec := range specs {
+		cm, verr := ResolveCommitWithVErr(dEnv, spec, cwb)
+
+		if verr != nil {
+			return nil, verr
+		}
+
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to get commit hash").AddCause(err).Build()
+		}
+
+		hashes[i] = h.String()
+	}
+
+	return hashes, nil
+}
+
+func parseBisectHashes(hashStrs []string) []hash.Hash {
+	hashes := make([]hash.Hash, len(hashStrs))
+	for i, hashStr := range hashStrs {
+		hashes[i] = hash.Parse(hashStr)
+	}
+
+	return hashes
+}
+
+// bisectQueryHasRows runs query against the working tables and returns whether it returned any rows.
+func bisectQueryHasRows(ctx context.Context, dEnv *env.DoltEnv, query string) (bool, errhand.VerboseError) {
+	mrEnv := env.DoltEnvAsMultiEnv(dEnv)
+	roots, err := mrEnv.GetWorkingRoots(ctx)
+
+	if err != nil {
+		return false, errhand.BuildDError("error: failed to get the working root").AddCause(err).Build()
+	}
+
+	sqlCtx := sql.NewContext(ctx,
+		sql.WithSession(dsqle.DefaultDoltSession()),
+		sql.WithIndexRegistry(sql.NewIndexRegistry()),
+		sql.WithViewRegistry(sql.NewViewRegistry()))
+
+	for name := range roots {
+		sqlCtx.SetCurrentDatabase(name)
+	}
+
+	se, err := newSqlEngine(sqlCtx, mrEnv, roots, formatTabular, CollectDBs(mrEnv, newDatabase)...)
+
+	if err != nil {
+		return false, errhand.VerboseErrorFromError(err)
+	}
+
+	_, rowIter, err := se.query(sqlCtx, query)
+
+	if err != nil {
+		return false, errhand.BuildDError("error: failed to run query").AddCause(err).Build()
+	}
+
+	defer rowIter.Close()
+
+	_, err = rowIter.Next()
+
+	if err == io.EOF {
+		return false, nil
+	} else if err != nil {
+		return false, errhand.BuildDError("error: failed to run query").AddCause(err).Build()
+	}
+
+	return true, nil
+}
@@ -50,7 +50,7 @@ The {{.EmphasisLeft}}-c{{.EmphasisRight}} options have the exact same semantics
 
 With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}branchname{{.GreaterThan}} will be deleted. You may specify more than one branch for deletion.`,
 	Synopsis: []string{
-		`[--list] [-v] [-a]`,
+		`[--list] [-v | -vv] [-a]`,
 		`[-f] {{.LessThan}}branchname{{.GreaterThan}} [{{.LessThan}}start-point{{.GreaterThan}}]`,
 		`-m [-f] [{{.LessThan}}oldbranch{{.GreaterThan}}] {{.LessThan}}newbranch{{.GreaterThan}}`,
 		`-c [-f] [{{.LessThan}}oldbranch{{.GreaterThan}}] {{.LessThan}}newbranch{{.GreaterThan}}`,
@@ -66,6 +66,7 @@ const (
 	deleteFlag      = "delete"
 	deleteForceFlag = "D"
 	verboseFlag     = "verbose"
+	veryVerboseFlag = "very-verbose"
 	allFlag         = "all"
 )
 
@@ -97,6 +98,7 @@ func (cmd BranchCmd) createArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(deleteFlag, "d", "Delete a branch. The branch must be fully merged in its upstream branch.")
 	ap.SupportsFlag(deleteForceFlag, "", "Shortcut for {{.EmphasisLeft}}--delete --force{{.EmphasisRight}}.")
 	ap.SupportsFlag(verboseFlag, "v", "When in list mode, show the hash and commit subject line for each head")
+	ap.SupportsFlag(veryVerboseFlag, "vv", "When in list mode, also show the upstream of each branch which has one, and how many commits the branch is ahead of and behind it")
 	ap.SupportsFlag(allFlag, "a", "When in list mode, shows remote tracked branches")
 	return ap
 }
@@ -133,7 +135,8 @@ func (cmd BranchCmd) Exec(ctx context.Context, commandStr string, args []string,
 func printBranches(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, _ cli.UsagePrinter) int {
 	branchSet := set.NewStrSet(apr.Args())
 
-	verbose := apr.Contains(verboseFlag)
+	veryVerbose := apr.Contains(veryVerboseFlag)
+	verbose := apr.Contains(verboseFlag) || veryVerbose
 	printAll := apr.Contains(allParam)
 
 	branches, err := dEnv.DoltDB.GetRefs(ctx)
@@ -154,7 +157,7 @@ func printBranches(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 
 		cs, _ := doltdb.NewCommitSpec("HEAD", branch.String())
 
-		if branch.GetType() != ref.BranchRefType && !printAll {
+		if branch.GetType() == ref.TagRefType || branch.GetType() == ref.StashRefType || branch.GetType() != ref.BranchRefType && !printAll {
 			continue
 		}
 
@@ -183,6 +186,18 @@ func printBranches(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 			}
 		}
 
+		if veryVerbose && branch.GetType() == ref.BranchRefType {
+			us, err := actions.GetUpstreamStatus(ctx, dEnv, branch)
+
+			if err != nil {
+				return HandleVErrAndExitCode(errhand.BuildDError("error: failed to compare '%s' to its upstream", branch.GetPath()).AddCause(err).Build(), nil)
+			}
+
+			if us != nil {
+				commitStr += " [" + formatUpstreamStatus(us) + "]"
+			}
+		}
+
 		fmtStr := fmt.Sprintf("%%s%%%ds\t%%s", 48-branchLen)
 		line := fmt.Sprintf(fmtStr, branchName, "", commitStr)
 
@@ -192,6 +207,28 @@ func printBranches(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 	return 0
 }
 
+// formatUpstreamStatus formats the name of the upstream and the ahead and behind counts like "origin/master: ahead 2".
+func formatUpstreamStatus(us *actions.UpstreamStatus) string {
+	var counts []string
+	if us.Gone {
+		counts = append(counts, "gone")
+	}
+
+	if us.Ahead > 0 {
+		counts = append(counts, fmt.Sprintf("ahead %d", us.Ahead))
+	}
+
+	if us.Behind > 0 {
+		counts = append(counts, fmt.Sprintf("behind %d", us.Behind))
+	}
+
+	if len(counts) == 0 {
+		return us.Upstream.GetPath()
+	}
+
+	return us.Upstream.GetPath() + ": " + strings.Join(counts, ", ")
+}
+
 func moveBranch(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
 	if apr.NArg() != 2 {
 		usage()
@@ -213,6 +250,8 @@ func moveBranch(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseR
 			verr = errhand.BuildDError("fatal: '%s' is not a valid branch name.", dest).Build()
 		} else if err == actions.ErrCOBranchDelete {
 			verr = errhand.BuildDError("error: Cannot delete checked out branch '%s'", src).Build()
+		} else if env.IsBranchCheckedOut(err) {
+			verr = errhand.BuildDError("error: %s", err.Error()).Build()
 		} else {
 			bdr := errhand.BuildDError("fatal: Unexpected error moving branch from '%s' to '%s'", src, dest)
 			verr = bdr.AddCause(err).Build()
@@ -241,6 +280,8 @@ func copyBranch(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseR
 			verr = errhand.BuildDError("fatal: A branch named '%s' already exists.", dest).Build()
 		} else if err == doltdb.ErrInvBranchName {
 			verr = errhand.BuildDError("fatal: '%s' is not a valid branch name.", dest).Build()
+		} else if env.IsBranchCheckedOut(err) {
+			verr = errhand.BuildDError("error: %s", err.Error()).Build()
 		} else {
 			bdr := errhand.BuildDError("fatal: Unexpected error copying branch from '%s' to '%s'", src, dest)
 			verr = bdr.AddCause(err).Build()
@@ -274,6 +315,8 @@ func handleDeleteBranches(ctx context.Context, dEnv *env.DoltEnv, apr *argparser
 			verr = errhand.BuildDError("fatal: branch '%s' not found", brName).Build()
 		} else if err == actions.ErrCOBranchDelete {
 			verr = errhand.BuildDError("error: Cannot delete checked out branch '%s'", brName).Build()
+		} else if env.IsBranchCheckedOut(err) {
+			verr = errhand.BuildDError("error: %s", err.Error()).Build()
 		} else {
 			bdr := errhand.BuildDError("fatal: Unexpected error deleting '%s'", brName)
 			verr = bdr.AddCause(err).Build()
@@ -312,6 +355,8 @@ func createBranchWithStartPt(ctx context.Context, dEnv *env.DoltEnv, newBranch,
 		} else if err == doltdb.ErrInvHash || doltdb.IsNotACommit(err) {
 			bdr := errhand.BuildDError("fatal: '%s' is not a commit and a branch '%s' cannot be created from it", startPt, newBranch)
 			return bdr.Build()
+		} else if env.IsBranchCheckedOut(err) {
+			return errhand.BuildDError("error: %s", err.Error()).Build()
 		} else {
 			bdr := errhand.BuildDError("fatal: Unexpected error creating branch '%s'", newBranch)
 			bdr.AddCause(err)
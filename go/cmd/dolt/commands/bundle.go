@@ -0,0 +1,471 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+var bundleDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Move commits between repositories without a network connection",
+	LongDesc: `Packs branches, along with the data needed to complete their histories, into a single file which can be carried to another repository and fetched from there.
+
+{{.EmphasisLeft}}create{{.EmphasisRight}}
+Write the branches given by each {{.LessThan}}rev{{.GreaterThan}} to {{.LessThan}}file{{.GreaterThan}}. A {{.LessThan}}rev{{.GreaterThan}} is either a branch, {{.EmphasisLeft}}^{{.EmphasisRight}}{{.LessThan}}commit{{.GreaterThan}} to leave out the history of {{.LessThan}}commit{{.GreaterThan}}, or {{.LessThan}}commit{{.GreaterThan}}..{{.LessThan}}branch{{.GreaterThan}} which is the same as {{.LessThan}}branch{{.GreaterThan}} ^{{.LessThan}}commit{{.GreaterThan}}. The commits whose history is left out are prerequisites of the bundle, and must be in any repository the bundle is fetched into. With {{.EmphasisLeft}}--all{{.EmphasisRight}} every branch is bundled.
+
+{{.EmphasisLeft}}verify{{.EmphasisRight}}
+Check that {{.LessThan}}file{{.GreaterThan}} is a bundle and that the current repository has all of its prerequisite commits.
+
+{{.EmphasisLeft}}list-heads{{.EmphasisRight}}
+List the branches in {{.LessThan}}file{{.GreaterThan}} and the commits they point to.
+
+{{.EmphasisLeft}}unbundle{{.EmphasisRight}}
+Import the commits in {{.LessThan}}file{{.GreaterThan}} into the current repository without updating any refs, and list the branches in it. Use {{.EmphasisLeft}}dolt fetch {{.LessThan}}file{{.GreaterThan}}{{.EmphasisRight}} to import the commits and update remote-tracking branches.
+
+A bundle starts with a header listing its prerequisites and branches, followed by the table files holding the data of its commits.`,
+	Synopsis: []string{
+		"create [--all] {{.LessThan}}file{{.GreaterThan}} [{{.LessThan}}rev{{.GreaterThan}}...]",
+		"verify {{.LessThan}}file{{.GreaterThan}}",
+		"list-heads {{.LessThan}}file{{.GreaterThan}}",
+		"unbundle {{.LessThan}}file{{.GreaterThan}}",
+	},
+}
+
+const (
+	createBundleId    = "create"
+	verifyBundleId    = "verify"
+	listHeadsBundleId = "list-heads"
+	unbundleBundleId  = "unbundle"
+
+	allBundleFlag = "all"
+
+	// bundleRemoteName is the name of the remote which branches fetched from a bundle are tracked under by default
+	bundleRemoteName = "bundle"
+)
+
+type BundleCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BundleCmd) Name() string {
+	return "bundle"
+}
+
+// Description returns a description of the command
+func (cmd BundleCmd) Description() string {
+	return "Move commits between repositories without a network connection."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd BundleCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, bundleDocs, ap))
+}
+
+func (cmd BundleCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "The path of the bundle."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"rev", "A branch, a commit whose history is left out prefixed with ^, or a range such as master..feature."})
+	ap.SupportsFlag(allBundleFlag, "", "Bundle every branch.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd BundleCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, bundleDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() < 2 || (apr.Arg(0) != createBundleId && apr.NArg() != 2) {
+		usage()
+		return 1
+	}
+
+	var verr errhand.VerboseError
+	switch apr.Arg(0) {
+	case createBundleId:
+		verr = createBundle(ctx, dEnv, apr.Arg(1), apr.Args()[2:], apr.Contains(allBundleFlag))
+	case verifyBundleId:
+		verr = verifyBundle(ctx, dEnv, apr.Arg(1))
+	case listHeadsBundleId:
+		verr = listBundleHeads(dEnv, apr.Arg(1))
+	case unbundleBundleId:
+		verr = unbundle(ctx, dEnv, apr.Arg(1))
+	default:
+		verr = errhand.BuildDError("error: unknown subcommand '%s'", apr.Arg(0)).SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func createBundle(ctx context.Context, dEnv *env.DoltEnv, path string, revs []string, all bool) errhand.VerboseError {
+	header, verr := parseBundleRevs(ctx, dEnv, revs, all)
+
+	if verr != nil {
+		return verr
+	}
+
+	wr, err := dEnv.FS.OpenForWrite(path, os.ModePerm)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to create '%s'", path).AddCause(err).Build()
+	}
+
+	wg, progChan, pullerEventCh := runProgFuncs()
+	err = dEnv.DoltDB.WriteBundle(ctx, wr, dEnv.TempTableFilesDir(), header, progChan)
+	stopProgFuncs(wg, progChan, pullerEventCh)
+
+	closeErr := wr.Close()
+
+	if err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = dEnv.FS.DeleteFile(path)
+		return errhand.BuildDError("error: failed to write the bundle").AddCause(err).Build()
+	}
+
+	cli.Printf("Created bundle '%s' with %d branches and %d prerequisites\n", path, len(header.Refs), len(header.Prerequisites))
+	return nil
+}
+
+// parseBundleRevs returns a header listing the branches and prerequisites given by revs.
+func parseBundleRevs(ctx context.Context, dEnv *env.DoltEnv, revs []string, all bool) (doltdb.BundleHeader, errhand.VerboseError) {
+	var header doltdb.BundleHeader
+	var branches []string
+	var excluded []string
+
+	if all {
+		branchRefs, err := dEnv.DoltDB.GetBranches(ctx)
+
+		if err != nil {
+			return header, errhand.BuildDError("error: failed to read the branches").AddCause(err).Build()
+		}
+
+		for _, br := range branchRefs {
+			branches = append(branches, br.String())
+		}
+	}
+
+	for _, rev := range revs {
+		if strings.HasPrefix(rev, "^") {
+			excluded = append(excluded, rev[1:])
+		} else if i := strings.Index(rev, ".."); i >= 0 {
+			excluded = append(excluded, rev[:i])
+			branches = append(branches, rev[i+2:])
+		} else {
+			branches = append(branches, rev)
+		}
+	}
+
+	if len(branches) == 0 {
+		return header, errhand.BuildDError("error: refusing to create an empty bundle").SetPrintUsage().Build()
+	}
+
+	cwb := dEnv.RepoState.CWBHeadRef()
+	seen := make(map[string]bool)
+	for _, branch := range branches {
+		var dref ref.DoltRef = ref.NewBranchRef(branch)
+		if branch == "HEAD" {
+			dref = cwb
+		} else if ref.IsRef(branch) {
+			var err error
+			dref, err = ref.Parse(branch)
+
+			if err != nil || dref.GetType() != ref.BranchRefType {
+				return header, errhand.BuildDError("error: '%s' is not a branch. Only branches can be bundled.", branch).Build()
+			}
+		}
+
+		if seen[dref.String()] {
+			continue
+		}
+
+		seen[dref.String()] = true
+
+		cs, _ := doltdb.NewCommitSpec("HEAD", dref.String())
+		cm, err := dEnv.DoltDB.Resolve(ctx, cs)
+
+		if err != nil {
+			return header, errhand.BuildDError("error: unknown branch '%s'", branch).Build()
+		}
+
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return header, errhand.BuildDError("error: failed to get commit hash").AddCause(err).Build()
+		}
+
+		header.Refs = append(header.Refs, doltdb.BundleRef{Ref: dref, Hash: h})
+	}
+
+	for _, cSpecStr := range excluded {
+		cm, verr := ResolveCommitWithVErr(dEnv, cSpecStr, cwb.String())
+
+		if verr != nil {
+			return header, verr
+		}
+
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return header, errhand.BuildDError("error: failed to get commit hash").AddCause(err).Build()
+		}
+
+		header.Prerequisites = append(header.Prerequisites, h)
+	}
+
+	return header, nil
+}
+
+func readBundleHeader(dEnv *env.DoltEnv, path string) (doltdb.BundleHeader, errhand.VerboseError) {
+	rd, err := dEnv.FS.OpenForRead(path)
+
+	if err != nil {
+		return doltdb.BundleHeader{}, errhand.BuildDError("error: failed to open '%s'", path).AddCause(err).Build()
+	}
+
+	defer rd.Close()
+
+	header, err := doltdb.ReadBundleHeader(bufio.NewReader(rd))
+
+	if err != nil {
+		return header, errhand.BuildDError("error: failed to read '%s'", path).AddCause(err).Build()
+	}
+
+	return header, nil
+}
+
+// checkBundlePrerequisites returns an error listing the prerequisites of the bundle which are missing from the
+// repository.
+func checkBundlePrerequisites(ctx context.Context, dEnv *env.DoltEnv, header doltdb.BundleHeader) errhand.VerboseError {
+	missing, err := dEnv.DoltDB.MissingPrerequisites(ctx, header)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to check the prerequisites of the bundle").AddCause(err).Build()
+	} else if len(missing) == 0 {
+		return nil
+	}
+
+	bdr := errhand.BuildDError("error: repository lacks these prerequisite commits:")
+	for _, h := range missing {
+		bdr.AddDetails(h.String())
+	}
+
+	return bdr.Build()
+}
+
+func verifyBundle(ctx context.Context, dEnv *env.DoltEnv, path string) errhand.VerboseError {
+	header, verr := readBundleHeader(dEnv, path)
+
+	if verr != nil {
+		return verr
+	}
+
+	verr = checkBundlePrerequisites(ctx, dEnv, header)
+
+	if verr != nil {
+		return verr
+	}
+
+	cli.Printf("The bundle contains %d branches:\n", len(header.Refs))
+	printBundleRefs(header)
+
+	if len(header.Prerequisites) == 0 {
+		cli.Println("The bundle records a complete history.")
+	} else {
+		cli.Printf("The bundle requires these %d commits:\n", len(header.Prerequisites))
+		for _, h := range header.Prerequisites {
+			cli.Println(h.String())
+		}
+	}
+
+	cli.Printf("%s is okay\n", path)
+	return nil
+}
+
+func listBundleHeads(dEnv *env.DoltEnv, path string) errhand.VerboseError {
+	header, verr := readBundleHeader(dEnv, path)
+
+	if verr == nil {
+		printBundleRefs(header)
+	}
+
+	return verr
+}
+
+func printBundleRefs(header doltdb.BundleHeader) {
+	for _, br := range header.Refs {
+		cli.Println(br.Hash.String() + " " + br.Ref.String())
+	}
+}
+
+// openBundle unpacks the bundle at path, after checking that the repository has its prerequisites.  The returned
+// bundle must be closed.
+func openBundle(ctx context.Context, dEnv *env.DoltEnv, path string) (*doltdb.Bundle, errhand.VerboseError) {
+	header, verr := readBundleHeader(dEnv, path)
+
+	if verr != nil {
+		return nil, verr
+	}
+
+	verr = checkBundlePrerequisites(ctx, dEnv, header)
+
+	if verr != nil {
+		return nil, verr
+	}
+
+	rd, err := dEnv.FS.OpenForRead(path)
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to open '%s'", path).AddCause(err).Build()
+	}
+
+	defer rd.Close()
+
+	bundle, err := doltdb.OpenBundle(ctx, dEnv.DoltDB.Format(), rd, dEnv.TempTableFilesDir())
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to read '%s'", path).AddCause(err).Build()
+	}
+
+	return bundle, nil
+}
+
+// pullFromBundle pulls the commit h, and the data needed to complete its history, from the bundle.
+func pullFromBundle(ctx context.Context, dEnv *env.DoltEnv, bundle *doltdb.Bundle, h hash.Hash) (*doltdb.Commit, errhand.VerboseError) {
+	cs, _ := doltdb.NewCommitSpec(h.String(), "")
+	cm, err := bundle.DB.Resolve(ctx, cs)
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: commit %s is missing from the bundle", h.String()).AddCause(err).Build()
+	}
+
+	wg, progChan, pullerEventCh := runProgFuncs()
+	err = dEnv.DoltDB.PullChunks(ctx, dEnv.TempTableFilesDir(), bundle.DB, cm, progChan, pullerEventCh)
+	stopProgFuncs(wg, progChan, pullerEventCh)
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to import commit %s", h.String()).AddCause(err).Build()
+	}
+
+	return cm, nil
+}
+
+func unbundle(ctx context.Context, dEnv *env.DoltEnv, path string) errhand.VerboseError {
+	bundle, verr := openBundle(ctx, dEnv, path)
+
+	if verr != nil {
+		return verr
+	}
+
+	defer bundle.Close()
+
+	for _, br := range bundle.Header.Refs {
+		_, verr = pullFromBundle(ctx, dEnv, bundle, br.Hash)
+
+		if verr != nil {
+			return verr
+		}
+	}
+
+	printBundleRefs(bundle.Header)
+	return nil
+}
+
+// isBundleFile returns whether path is a file which starts with the signature of a bundle.
+func isBundleFile(fs filesys.Filesys, path string) bool {
+	if exists, isDir := fs.Exists(path); !exists || isDir {
+		return false
+	}
+
+	rd, err := fs.OpenForRead(path)
+
+	if err != nil {
+		return false
+	}
+
+	defer rd.Close()
+
+	return doltdb.IsBundle(rd)
+}
+
+// fetchBundle imports the branches in the bundle at path which match one of the refspecs given in args, and updates
+// the refs they map to.  By default branches are fetched to remote-tracking branches of a remote named bundle.
+func fetchBundle(ctx context.Context, mode ref.RefUpdateMode, dEnv *env.DoltEnv, path string, args []string) errhand.VerboseError {
+	if len(args) == 0 {
+		args = env.NewRemote(bundleRemoteName, path, nil).FetchSpecs
+	}
+
+	var refSpecs []ref.RefSpec
+	for _, rsStr := range args {
+		rs, err := ref.ParseRefSpecForRemote(bundleRemoteName, rsStr)
+
+		if err != nil {
+			return errhand.BuildDError("error: '%s' is not a valid refspec.", rsStr).SetPrintUsage().Build()
+		}
+
+		refSpecs = append(refSpecs, rs)
+	}
+
+	bundle, verr := openBundle(ctx, dEnv, path)
+
+	if verr != nil {
+		return verr
+	}
+
+	defer bundle.Close()
+
+	cwb := dEnv.RepoState.CWBHeadRef()
+	for _, br := range bundle.Header.Refs {
+		for _, rs := range refSpecs {
+			destRef := rs.DestRef(br.Ref)
+
+			if destRef == nil {
+				continue
+			} else if ref.Equals(destRef, cwb) {
+				return errhand.BuildDError("error: refusing to fetch into the current branch '%s'", cwb.GetPath()).Build()
+			}
+
+			cm, verr := pullFromBundle(ctx, dEnv, bundle, br.Hash)
+
+			if verr != nil {
+				return verr
+			}
+
+			verr = updateFetchedRef(ctx, mode, dEnv, destRef, cm)
+
+			if verr != nil {
+				return verr
+			}
+
+			cli.Printf(" * %s -> %s\n", br.Ref.GetPath(), destRef.GetPath())
+		}
+	}
+
+	return nil
+}
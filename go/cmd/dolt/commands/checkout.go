@@ -98,7 +98,9 @@ func (cmd CheckoutCmd) Exec(ctx context.Context, commandStr string, args []strin
 
 	if newBranch, newBranchOk := apr.GetValue(coBranchArg); newBranchOk {
 		var verr errhand.VerboseError
-		if len(newBranch) == 0 {
+		if dEnv.IsBisectActive() {
+			verr = bisectCheckoutErr()
+		} else if len(newBranch) == 0 {
 			verr = errhand.BuildDError("error: cannot checkout empty string").Build()
 		} else {
 			verr = checkoutNewBranch(ctx, dEnv, newBranch, apr)
@@ -117,6 +119,10 @@ func (cmd CheckoutCmd) Exec(ctx context.Context, commandStr string, args []strin
 		verr := errhand.BuildDError("error: unable to determine type of checkout").AddCause(err).Build()
 		return HandleVErrAndExitCode(verr, usagePrt)
 	} else if isBranch {
+		if dEnv.IsBisectActive() {
+			return HandleVErrAndExitCode(bisectCheckoutErr(), usagePrt)
+		}
+
 		verr := checkoutBranch(ctx, dEnv, name)
 		return HandleVErrAndExitCode(verr, usagePrt)
 	}
@@ -137,10 +143,20 @@ func (cmd CheckoutCmd) Exec(ctx context.Context, commandStr string, args []strin
 
 }
 
+func bisectCheckoutErr() errhand.VerboseError {
+	bdr := errhand.BuildDError("error: cannot check out a branch while a bisect is in progress.")
+	bdr.AddDetails("hint: use 'dolt bisect reset' to end the bisect first.")
+	return bdr.Build()
+}
+
 func checkoutRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, name string) errhand.VerboseError {
 	if ref, refExists, err := getRemoteBranchRef(ctx, dEnv, name); err != nil {
 		return errhand.BuildDError("fatal: unable to read from data repository.").AddCause(err).Build()
 	} else if refExists {
+		if dEnv.IsBisectActive() {
+			return bisectCheckoutErr()
+		}
+
 		return checkoutNewBranchFromStartPt(ctx, dEnv, name, ref.String())
 	} else {
 		return errhand.BuildDError("error: could not find %s", name).Build()
@@ -231,6 +247,8 @@ func checkoutBranch(ctx context.Context, dEnv *env.DoltEnv, name string) errhand
 			return bdr.Build()
 		} else if err == doltdb.ErrAlreadyOnBranch {
 			return errhand.BuildDError("Already on branch '%s'", name).Build()
+		} else if env.IsBranchCheckedOut(err) {
+			return errhand.BuildDError("fatal: %s", err.Error()).Build()
 		} else {
 			bdr := errhand.BuildDError("fatal: Unexpected error checking out branch '%s'", name)
 			bdr.AddCause(err)
@@ -0,0 +1,234 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/diff"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var cherryPickDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Apply the changes introduced by an existing commit",
+	LongDesc: `Applies the changes introduced by {{.LessThan}}commit{{.GreaterThan}} to the current branch and records a new commit for them. The new commit has the same message, author, and date as the original commit.
+
+The changes are applied using a three-way merge where the parent of {{.LessThan}}commit{{.GreaterThan}} is the common ancestor, {{.EmphasisLeft}}HEAD{{.EmphasisRight}} is the current state, and {{.LessThan}}commit{{.GreaterThan}} is the state being merged in. If the merge results in conflicts, the cherry-pick stops so that the conflicts can be resolved using {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}. Once they are resolved, add the affected tables and run {{.EmphasisLeft}}dolt commit{{.EmphasisRight}} to record the cherry-picked commit.
+
+The working set must be clean before cherry-picking. Merge commits cannot be cherry-picked.`,
+	Synopsis: []string{
+		"{{.LessThan}}commit{{.GreaterThan}}",
+		"--abort",
+	},
+}
+
+var cherryPickAbortDetails = `Abort the current conflict resolution process, and reconstruct the state before the cherry-pick began.`
+
+type CherryPickCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd CherryPickCmd) Name() string {
+	return "cherry-pick"
+}
+
+// Description returns a description of the command
+func (cmd CherryPickCmd) Description() string {
+	return "Apply the changes introduced by an existing commit."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd CherryPickCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, cherryPickDocs, ap))
+}
+
+func (cmd CherryPickCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "The commit whose changes should be applied."})
+	ap.SupportsFlag(abortParam, "", cherryPickAbortDetails)
+	return ap
+}
+
+// Exec executes the command
+func (cmd CherryPickCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, cherryPickDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.Contains(abortParam) {
+		if !dEnv.IsCherryPickActive() {
+			cli.PrintErrln("fatal: There is no cherry-pick to abort")
+			return 1
+		}
+
+		return HandleVErrAndExitCode(abortCherryPick(ctx, dEnv), usage)
+	}
+
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	if verr := checkWorkingSetClean(ctx, dEnv, "cherry-pick"); verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	cm, verr := ResolveCommitWithVErr(dEnv, apr.Arg(0), dEnv.RepoState.CWBHeadRef().String())
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	err := cherryPick(ctx, dEnv, cm)
+
+	if verr, ok := err.(errhand.VerboseError); ok {
+		return HandleVErrAndExitCode(verr, usage)
+	} else if err != nil {
+		return handleCommitErr(ctx, dEnv, err, usage)
+	}
+
+	return LogCmd{}.Exec(ctx, "log", []string{"-n=1"}, dEnv)
+}
+
+func abortCherryPick(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	err := actions.CheckoutAllTables(ctx, dEnv)
+
+	if err == nil {
+		err = dEnv.RepoState.ClearCherryPick(dEnv.FS)
+
+		if err == nil {
+			return nil
+		}
+	}
+
+	return errhand.BuildDError("fatal: failed to revert changes").AddCause(err).Build()
+}
+
+// checkWorkingSetClean returns an error if there are conflicts, an operation in progress which needs to be committed,
+// or uncommitted changes to tables in the working set.  opName is the name of the operation used in the error.
+func checkWorkingSetClean(ctx context.Context, dEnv *env.DoltEnv, opName string) errhand.VerboseError {
+	root, verr := GetWorkingWithVErr(dEnv)
+
+	if verr != nil {
+		return verr
+	}
+
+	if has, err := root.HasConflicts(ctx); err != nil {
+		return errhand.BuildDError("error: failed to get conflicts").AddCause(err).Build()
+	} else if has {
+		bdr := errhand.BuildDError("error: %s is not possible because you have unmerged tables.", opName)
+		bdr.AddDetails("hint: Fix them up in the working set, and then use 'dolt add <table>'")
+		bdr.AddDetails("hint: as appropriate to mark resolution and make a commit.")
+		return bdr.Build()
+	} else if dEnv.IsMergeActive() {
+		bdr := errhand.BuildDError("error: %s is not possible because you have not committed an active merge.", opName)
+		bdr.AddDetails("hint: add affected tables using 'dolt add <table>' and commit using 'dolt commit -m <msg>'")
+		return bdr.Build()
+	} else if dEnv.IsCherryPickActive() {
+		bdr := errhand.BuildDError("error: %s is not possible because a cherry-pick is in progress.", opName)
+		bdr.AddDetails("hint: commit the cherry-pick using 'dolt commit' or abort it using 'dolt cherry-pick --abort'")
+		return bdr.Build()
+	} else if dEnv.IsRevertActive() {
+		bdr := errhand.BuildDError("error: %s is not possible because a revert is in progress.", opName)
+		bdr.AddDetails("hint: commit the revert using 'dolt commit' or abort it using 'dolt revert --abort'")
+		return bdr.Build()
+	} else if dEnv.IsRebaseActive() {
+		bdr := errhand.BuildDError("error: %s is not possible because a rebase is in progress.", opName)
+		bdr.AddDetails("hint: continue the rebase using 'dolt rebase --continue' or abort it using 'dolt rebase --abort'")
+		return bdr.Build()
+	} else if dEnv.IsBisectActive() {
+		bdr := errhand.BuildDError("error: %s is not possible because a bisect is in progress.", opName)
+		bdr.AddDetails("hint: end the bisect using 'dolt bisect reset'")
+		return bdr.Build()
+	}
+
+	stagedTbls, notStagedTbls, err := diff.GetTableDiffs(ctx, dEnv)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get table diffs").AddCause(err).Build()
+	}
+
+	if len(stagedTbls.Tables) > 0 || len(notStagedTbls.Tables) > 0 {
+		bdr := errhand.BuildDError("error: your local changes would be overwritten by %s.", opName)
+		bdr.AddDetails("hint: commit your changes before you %s.", opName)
+		return bdr.Build()
+	}
+
+	return nil
+}
+
+// cherryPick applies the changes made by cm on top of the current HEAD, and commits them if there are no conflicts.
+// Errors which occur while committing are returned as is so that they can be handled like errors from dolt commit.
+func cherryPick(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit) error {
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	numParents, err := cm.NumParents()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read the parents of commit %s", h.String()).AddCause(err).Build()
+	} else if numParents == 0 {
+		return errhand.BuildDError("error: commit %s has no parents and cannot be cherry-picked", h.String()).Build()
+	} else if numParents > 1 {
+		return errhand.BuildDError("error: commit %s is a merge and cannot be cherry-picked", h.String()).Build()
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get commit metadata").AddCause(err).Build()
+	}
+
+	err = dEnv.RepoState.StartCherryPick(h.String(), dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+	}
+
+	tblToStats, err := actions.ReplayCommit(ctx, dEnv, cm, false, meta.Description, meta.Time())
+
+	if tblToStats == nil {
+		dEnv.RepoState.ClearCherryPick(dEnv.FS)
+		return errhand.BuildDError("error: could not apply %s", h.String()).AddCause(err).Build()
+	}
+
+	if hasConflicts := printSuccessStats(tblToStats); hasConflicts {
+		cli.Printf("error: could not apply %s... %s\n", h.String(), meta.Description)
+		cli.Println("hint: after resolving the conflicts, mark the corrected tables")
+		cli.Println("hint: with 'dolt add <table>' and run 'dolt commit'")
+		return errhand.BuildDError("").Build()
+	}
+
+	if actions.IsNothingStaged(err) {
+		err = dEnv.RepoState.ClearCherryPick(dEnv.FS)
+
+		if err != nil {
+			return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+		}
+
+		return errhand.BuildDError("The changes made by commit %s have already been applied.", h.String()).Build()
+	}
+
+	return err
+}
@@ -43,6 +43,8 @@ import (
 const (
 	remoteParam = "remote"
 	branchParam = "branch"
+	depthParam  = "depth"
+	lazyFlag    = "lazy"
 )
 
 var cloneDocs = cli.CommandDocumentationContent{
@@ -52,9 +54,13 @@ var cloneDocs = cli.CommandDocumentationContent{
 After the clone, a plain {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} without arguments will update all the remote-tracking branches, and a {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} without arguments will in addition merge the remote branch into the current branch.
 
 This default configuration is achieved by creating references to the remote branch heads under {{.LessThan}}refs/remotes/origin{{.GreaterThan}}  and by creating a remote named 'origin'.
+
+When {{.EmphasisLeft}}--depth{{.EmphasisRight}} is given, only the last {{.LessThan}}depth{{.GreaterThan}} commits of each branch, and the data they reference, are cloned. The history of the resulting shallow clone ends at the oldest commits cloned, and can be extended later using {{.EmphasisLeft}}dolt fetch --deepen{{.EmphasisRight}}. Tags are not cloned by a shallow clone.
+
+When {{.EmphasisLeft}}--lazy{{.EmphasisRight}} is given, no data is downloaded by the clone. Data is read from the remote as it is needed, and saved locally so that it is only downloaded once. The remote must stay available until the clone is completed using {{.EmphasisLeft}}dolt fetch --all-chunks{{.EmphasisRight}}.
 `,
 	Synopsis: []string{
-		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}]  [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
+		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}] [--depth {{.LessThan}}depth{{.GreaterThan}} | --lazy] [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
 	},
 }
 
@@ -86,6 +92,8 @@ func (cmd CloneCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.SupportsString(remoteParam, "", "name", "Name of the remote to be added. Default will be 'origin'.")
 	ap.SupportsString(branchParam, "b", "branch", "The branch to be cloned.  If not specified all branches will be cloned.")
+	ap.SupportsInt(depthParam, "", "depth", "Create a shallow clone with a history truncated to the given number of commits.")
+	ap.SupportsFlag(lazyFlag, "", "Create a lazy clone which downloads data from the remote as it is needed.")
 	ap.SupportsString(dbfactory.AWSRegionParam, "", "region", "")
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, credTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file.")
@@ -108,6 +116,18 @@ func (cmd CloneCmd) Exec(ctx context.Context, commandStr string, args []string,
 	branch := apr.GetValueOrDefault(branchParam, "")
 	dir, urlStr, verr := parseArgs(apr)
 
+	depth, hasDepth := apr.GetInt(depthParam)
+
+	if verr == nil && hasDepth && depth < 1 {
+		verr = errhand.BuildDError("fatal: depth %d is not a positive number", depth).Build()
+	}
+
+	lazy := apr.Contains(lazyFlag)
+
+	if verr == nil && hasDepth && lazy {
+		verr = errhand.BuildDError("fatal: --depth and --lazy cannot be used together").Build()
+	}
+
 	scheme, remoteUrl, err := getAbsRemoteUrl(dEnv.FS, dEnv.Config, urlStr)
 
 	if err != nil {
@@ -127,7 +147,7 @@ func (cmd CloneCmd) Exec(ctx context.Context, commandStr string, args []string,
 				dEnv, verr = envForClone(ctx, srcDB.ValueReadWriter().Format(), r, dir, dEnv.FS, dEnv.Version)
 
 				if verr == nil {
-					verr = cloneRemote(ctx, srcDB, remoteName, branch, dEnv)
+					verr = cloneRemote(ctx, srcDB, remoteName, branch, depth, lazy, dEnv)
 
 					if verr == nil {
 						evt := events.GetEventFromContext(ctx)
@@ -272,20 +292,31 @@ func cloneProg(eventCh <-chan datas.TableFileEvent) {
 	cli.Println()
 }
 
-func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, dEnv *env.DoltEnv) errhand.VerboseError {
-	eventCh := make(chan datas.TableFileEvent, 128)
+// cloneRemote clones srcDB into the database of dEnv.  If depth is greater than 0 a shallow clone is made, and if lazy is
+// true a lazy clone is made.
+func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, depth int, lazy bool, dEnv *env.DoltEnv) errhand.VerboseError {
+	var err error
+	if lazy {
+		err = actions.LazyClone(ctx, srcDB, dEnv, remoteName)
+	} else if depth > 0 {
+		wg, progChan, pullerEventCh := runProgFuncs()
+		err = actions.ShallowClone(ctx, srcDB, dEnv, depth, progChan)
+		stopProgFuncs(wg, progChan, pullerEventCh)
+	} else {
+		eventCh := make(chan datas.TableFileEvent, 128)
 
-	wg := &sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		cloneProg(eventCh)
-	}()
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cloneProg(eventCh)
+		}()
 
-	err := actions.Clone(ctx, srcDB, dEnv.DoltDB, eventCh)
-	close(eventCh)
+		err = actions.Clone(ctx, srcDB, dEnv.DoltDB, eventCh)
+		close(eventCh)
 
-	wg.Wait()
+		wg.Wait()
+	}
 
 	if err != nil {
 		return errhand.BuildDError("error: clone failed").AddCause(err).Build()
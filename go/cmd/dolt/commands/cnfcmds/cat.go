@@ -28,6 +28,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle/sqlfmt"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/pipeline"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/untyped/fwt"
@@ -155,6 +156,10 @@ func printConflicts(ctx context.Context, root *doltdb.RootValue, tblNames []stri
 				return errhand.BuildDError("error: unable to read database").AddCause(err).Build()
 			}
 
+			if verr := printSchemaConflict(ctx, tblName, tbl); verr != nil {
+				return verr
+			}
+
 			cnfRd, err := merge.NewConflictReader(ctx, tbl)
 
 			if err == doltdb.ErrNoConflicts {
@@ -212,3 +217,47 @@ func printConflicts(ctx context.Context, root *doltdb.RootValue, tblNames []stri
 
 	return nil
 }
+
+func printSchemaConflict(ctx context.Context, tblName string, tbl *doltdb.Table) errhand.VerboseError {
+	schCnfs, err := merge.GetSchemaConflicts(ctx, tbl)
+
+	if err == doltdb.ErrNoConflicts {
+		return nil
+	} else if err != nil {
+		return errhand.BuildDError("failed to read schema conflicts").AddCause(err).Build()
+	}
+
+	_, ourTbl, theirTbl, err := tbl.GetSchemaConflict(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("failed to read schema conflicts").AddCause(err).Build()
+	}
+
+	ourSch, err := ourTbl.GetSchema(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get schema").AddCause(err).Build()
+	}
+
+	theirSch, err := theirTbl.GetSchema(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get schema").AddCause(err).Build()
+	}
+
+	cli.Printf("Schema conflicts in %s:\n", tblName)
+	for _, schCnf := range schCnfs {
+		cli.Println("\t" + schCnf.String())
+	}
+
+	cli.Println()
+	cli.Println("ours:")
+	cli.Println(sqlfmt.SchemaAsCreateStmt(tblName, ourSch))
+	cli.Println()
+	cli.Println("theirs:")
+	cli.Println(sqlfmt.SchemaAsCreateStmt(tblName, theirSch))
+	cli.Println()
+	cli.Printf("use \"dolt conflicts resolve --ours|--theirs %s\" to pick a schema\n", tblName)
+
+	return nil
+}
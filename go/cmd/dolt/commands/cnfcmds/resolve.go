@@ -38,6 +38,8 @@ When a merge operation finds conflicting changes, the rows with the conflicts ar
 In it's first form {{.EmphasisLeft}}dolt conflicts resolve <table> <key>...{{.EmphasisRight}}, resolve runs in manual merge mode resolving the conflicts whose keys are provided.
 
 In it's second form {{.EmphasisLeft}}dolt conflicts resolve --ours|--theirs <table>...{{.EmphasisRight}}, resolve runs in auto resolve mode. Where conflicts are resolved using a rule to determine which version of a row should be used.
+
+When a merge finds changes to the schema of a table which can't be merged, the table has a schema conflict and its rows are not merged.  A schema conflict can only be resolved in auto resolve mode, which picks our or their schema and then finishes merging the rows of the table.  Our version of the table is the one in the working set, so the table can be altered before resolving with {{.EmphasisLeft}}--ours{{.EmphasisRight}}.
 `,
 	Synopsis: []string{
 		`{{.LessThan}}table{{.GreaterThan}} [{{.LessThan}}key_definition{{.GreaterThan}}] {{.LessThan}}key{{.GreaterThan}}...`,
@@ -172,6 +174,12 @@ func manualResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *en
 		return errhand.BuildDError("error: failed to get table '%s'", tblName).AddCause(err).Build()
 	}
 
+	if has, err := tbl.HasSchemaConflict(); err != nil {
+		return errhand.BuildDError("error: failed to get table '%s'", tblName).AddCause(err).Build()
+	} else if has {
+		return errhand.BuildDError("error: table '%s' has a schema conflict which must be resolved with --ours or --theirs", tblName).Build()
+	}
+
 	sch, err := tbl.GetSchema(ctx)
 
 	if err != nil {
@@ -23,13 +23,17 @@ import (
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/liquidata-inc/go-mysql-server/sql"
 
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/creds"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/diff"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	dsqle "github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/editor"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
@@ -39,6 +43,8 @@ const (
 	allowEmptyFlag   = "allow-empty"
 	dateParam        = "date"
 	commitMessageArg = "message"
+	signFlag         = "sign"
+	noVerifyFlag     = "no-verify"
 )
 
 var commitDocs = cli.CommandDocumentationContent{
@@ -51,6 +57,10 @@ var commitDocs = cli.CommandDocumentationContent{
 	The log message can be added with the parameter {{.EmphasisLeft}}-m <msg>{{.EmphasisRight}}.  If the {{.LessThan}}-m{{.GreaterThan}} parameter is not provided an editor will be opened where you can review the commit and provide a log message.
 	
 	The commit timestamp can be modified using the --date parameter.  Dates can be specified in the formats {{.LessThan}}YYYY-MM-DD{{.GreaterThan}}, {{.LessThan}}YYYY-MM-DDTHH:MM:SS{{.GreaterThan}}, or {{.LessThan}}YYYY-MM-DDTHH:MM:SSZ07:00{{.GreaterThan}} (where {{.LessThan}}07:00{{.GreaterThan}} is the time zone offset)."
+	
+	With {{.EmphasisLeft}}-S{{.EmphasisRight}} the commit is signed with the private key of the credentials set by {{.EmphasisLeft}}dolt creds use{{.EmphasisRight}}. The signature covers the root value, the parents and the metadata of the commit, and can be checked with {{.EmphasisLeft}}dolt verify-commit{{.EmphasisRight}}.
+	
+	Before committing, each query in the {{.EmphasisLeft}}dolt_commit_checks{{.EmphasisRight}} table is run against the staged tables. The table has a {{.EmphasisLeft}}name{{.EmphasisRight}} and a {{.EmphasisLeft}}query{{.EmphasisRight}} column, and is created by inserting a row into it. If any of the queries return rows the commit fails and the rows are printed. Use {{.EmphasisLeft}}--no-verify{{.EmphasisRight}} to commit without running the checks.
 	`,
 	Synopsis: []string{
 		"[options]",
@@ -80,6 +90,8 @@ func (cmd CommitCmd) createArgParser() *argparser.ArgParser {
 	ap.SupportsString(commitMessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the commit message.")
 	ap.SupportsFlag(allowEmptyFlag, "", "Allow recording a commit that has the exact same data as its sole parent. This is usually a mistake, so it is disabled by default. This option bypasses that safety.")
 	ap.SupportsString(dateParam, "", "date", "Specify the date used in the commit. If not specified the current system time is used.")
+	ap.SupportsFlag(signFlag, "S", "Sign the commit with the private key of the current user's credentials.")
+	ap.SupportsFlag(noVerifyFlag, "", "Commit without running the queries in the dolt_commit_checks table.")
 	return ap
 }
 
@@ -104,7 +116,30 @@ func (cmd CommitCmd) Exec(ctx context.Context, commandStr string, args []string,
 		}
 	}
 
-	err := actions.CommitStaged(ctx, dEnv, msg, t, apr.Contains(allowEmptyFlag))
+	var signer *creds.DoltCreds
+	if apr.Contains(signFlag) {
+		dc, valid, err := dEnv.UserRPCCreds()
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read credentials").AddCause(err).Build(), usage)
+		} else if !valid {
+			bdr := errhand.BuildDError("error: no credentials to sign the commit with.")
+			bdr.AddDetails("to create credentials run: dolt creds new")
+			return HandleVErrAndExitCode(bdr.Build(), usage)
+		}
+
+		signer = &dc
+	}
+
+	if !apr.Contains(noVerifyFlag) {
+		verr := runCommitChecks(ctx, dEnv)
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+	}
+
+	err := actions.CommitStaged(ctx, dEnv, msg, t, apr.Contains(allowEmptyFlag), signer)
 	if err == nil {
 		// if the commit was successful, print it out using the log command
 		return LogCmd{}.Exec(ctx, "log", []string{"-n=1"}, dEnv)
@@ -113,6 +148,44 @@ func (cmd CommitCmd) Exec(ctx context.Context, commandStr string, args []string,
 	return handleCommitErr(ctx, dEnv, err, usage)
 }
 
+// runCommitChecks runs the queries in the commit checks table against the staged root, and prints the rows returned by
+// any checks which fail.
+func runCommitChecks(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	root, err := dEnv.StagedRoot(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get the staged root").AddCause(err).Build()
+	}
+
+	var db dsqle.Database
+	_ = env.DoltEnvAsMultiEnv(dEnv).Iter(func(name string, dEnv *env.DoltEnv) (stop bool, err error) {
+		db = newDatabase(name, dEnv)
+		return true, nil
+	})
+
+	failures, err := dsqle.RunCommitChecks(ctx, db, root)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to run the commit checks").AddCause(err).Build()
+	} else if len(failures) == 0 {
+		return nil
+	}
+
+	se := &sqlEngine{resultFormat: formatTabular}
+	for _, failure := range failures {
+		cli.PrintErrln(color.RedString("commit check '%s' failed:", failure.Check.Name))
+		err = se.prettyPrintResults(ctx, failure.Schema, sql.RowsToRowIter(failure.Rows...))
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to print the rows of commit check '%s'", failure.Check.Name).AddCause(err).Build()
+		}
+	}
+
+	bdr := errhand.BuildDError("error: commit checks failed, aborting commit.")
+	bdr.AddDetails("hint: use --no-verify to commit without running the checks.")
+	return bdr.Build()
+}
+
 // we are more permissive than what is documented.
 var supportedLayouts = []string{
 	"2006/01/02",
@@ -164,6 +237,12 @@ func handleCommitErr(ctx context.Context, dEnv *env.DoltEnv, err error, usage cl
 		return HandleVErrAndExitCode(bdr.Build(), usage)
 	}
 
+	if err == actions.ErrBisectActive {
+		bdr := errhand.BuildDError("error: cannot commit while a bisect is in progress.")
+		bdr.AddDetails("hint: use 'dolt bisect reset' to end the bisect first.")
+		return HandleVErrAndExitCode(bdr.Build(), usage)
+	}
+
 	if actions.IsNothingStaged(err) {
 		notStagedTbls := actions.NothingStagedTblDiffs(err)
 		notStagedDocs := actions.NothingStagedDocsDiffs(err)
@@ -213,7 +292,7 @@ func buildInitalCommitMsg(ctx context.Context, dEnv *env.DoltEnv) string {
 	n := printStagedDiffs(buf, stagedTblDiffs, stagedDocDiffs, true)
 	n = printDiffsNotStaged(ctx, dEnv, buf, notStagedTblDiffs, notStagedDocDiffs, true, n, workingTblsInConflict)
 
-	initialCommitMessage := "\n" + "# Please enter the commit message for your changes. Lines starting" + "\n" +
+	initialCommitMessage := getInProgressCommitMessage(ctx, dEnv) + "\n" + "# Please enter the commit message for your changes. Lines starting" + "\n" +
 		"# with '#' will be ignored, and an empty message aborts the commit." + "\n# On branch " + currBranch.GetPath() + "\n#" + "\n"
 
 	msgLines := strings.Split(buf.String(), "\n")
@@ -226,6 +305,43 @@ func buildInitalCommitMsg(ctx context.Context, dEnv *env.DoltEnv) string {
 	return initialCommitMessage + statusMsg
 }
 
+// getInProgressCommitMessage returns the message for the commit concluding a cherry-pick or revert in progress, or ""
+// if there is neither.  A cherry-pick keeps the message of the original commit, and a revert generates one from it.
+func getInProgressCommitMessage(ctx context.Context, dEnv *env.DoltEnv) string {
+	var cmHashStr string
+	if dEnv.IsCherryPickActive() {
+		cmHashStr = dEnv.RepoState.CherryPick.Commit
+	} else if dEnv.IsRevertActive() {
+		cmHashStr = dEnv.RepoState.Revert.Commit
+	} else {
+		return ""
+	}
+
+	cs, err := doltdb.NewCommitSpec(cmHashStr, dEnv.RepoState.CWBHeadRef().String())
+
+	if err != nil {
+		return ""
+	}
+
+	cm, err := dEnv.DoltDB.Resolve(ctx, cs)
+
+	if err != nil {
+		return ""
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return ""
+	}
+
+	if dEnv.IsRevertActive() {
+		return revertCommitMessage(cmHashStr, meta)
+	}
+
+	return meta.Description
+}
+
 func parseCommitMessage(cm string) string {
 	lines := strings.Split(cm, "\n")
 	filtered := make([]string, 0, len(lines))
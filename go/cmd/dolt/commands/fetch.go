@@ -32,6 +32,9 @@ import (
 
 const (
 	ForceFetchFlag = "force"
+	deepenParam    = "deepen"
+	allChunksFlag  = "all-chunks"
+	pruneFlag      = "prune"
 )
 
 var fetchDocs = cli.CommandDocumentationContent{
@@ -41,10 +44,19 @@ var fetchDocs = cli.CommandDocumentationContent{
 By default dolt will attempt to fetch from a remote named {{.EmphasisLeft}}origin{{.EmphasisRight}}.  The {{.LessThan}}remote{{.GreaterThan}} parameter allows you to specify the name of a different remote you wish to pull from by the remote's name.
 
 When no refspec(s) are specified on the command line, the fetch_specs for the default remote are used.
+
+With {{.EmphasisLeft}}--prune{{.EmphasisRight}}, remote-tracking branches of the remote whose branches no longer exist on the remote are deleted after fetching.
+
+{{.LessThan}}remote{{.GreaterThan}} may also be the path of a bundle created with {{.EmphasisLeft}}dolt bundle create{{.EmphasisRight}}. The branches in the bundle are fetched to {{.LessThan}}refs/remotes/bundle{{.GreaterThan}} unless refspecs are given, and the repository must have the prerequisite commits of the bundle.
+
+In a shallow clone, {{.EmphasisLeft}}--deepen{{.EmphasisRight}} fetches the given number of additional commits from behind the oldest commits of the clone before the refs are fetched.
+
+In a lazy clone, {{.EmphasisLeft}}--all-chunks{{.EmphasisRight}} downloads all of the data which has not yet been read from the remote the repository was cloned from, after which the repository no longer needs the remote.
 `,
 
 	Synopsis: []string{
-		"[{{.LessThan}}remote{{.GreaterThan}}] [{{.LessThan}}refspec{{.GreaterThan}} ...]",
+		"[-p | --prune] [--deepen {{.LessThan}}depth{{.GreaterThan}}] [--all-chunks] [{{.LessThan}}remote{{.GreaterThan}}] [{{.LessThan}}refspec{{.GreaterThan}} ...]",
+		"[-f] {{.LessThan}}bundle-file{{.GreaterThan}} [{{.LessThan}}refspec{{.GreaterThan}} ...]",
 	},
 }
 
@@ -74,6 +86,9 @@ func (cmd FetchCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string)
 func (cmd FetchCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.SupportsFlag(ForceFetchFlag, "f", "Update refs to remote branches with the current state of the remote, overwriting any conflicting history.")
+	ap.SupportsInt(deepenParam, "", "depth", "Extend the history of a shallow clone by the given number of commits.")
+	ap.SupportsFlag(pruneFlag, "p", "After fetching, remove any remote-tracking branches which no longer exist on the remote.")
+	ap.SupportsFlag(allChunksFlag, "", "Download all of the data missing from a lazy clone.")
 	return ap
 }
 
@@ -84,17 +99,88 @@ func (cmd FetchCmd) Exec(ctx context.Context, commandStr string, args []string,
 	apr := cli.ParseArgs(ap, args, help)
 
 	remotes, _ := dEnv.GetRemotes()
+	updateMode := ref.RefUpdateMode{Force: apr.Contains(ForceFetchFlag)}
+
+	if apr.NArg() > 0 && !isRemoteName(remotes, apr.Arg(0)) && isBundleFile(dEnv.FS, apr.Arg(0)) {
+		var verr errhand.VerboseError
+		if apr.Contains(deepenParam) || apr.Contains(pruneFlag) || apr.Contains(allChunksFlag) {
+			verr = errhand.BuildDError("error: only --force can be used when fetching from a bundle").Build()
+		} else {
+			verr = fetchBundle(ctx, updateMode, dEnv, apr.Arg(0), apr.Args()[1:])
+		}
+
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
 	r, refSpecs, verr := getRefSpecs(apr.Args(), dEnv, remotes)
 
-	updateMode := ref.RefUpdateMode{Force: apr.Contains(ForceFetchFlag)}
+	if depth, ok := apr.GetInt(deepenParam); ok && verr == nil {
+		verr = deepenShallowClone(ctx, dEnv, r, depth)
+	}
 
 	if verr == nil {
 		verr = fetchRefSpecs(ctx, updateMode, dEnv, r, refSpecs)
 	}
 
+	if verr == nil && apr.Contains(pruneFlag) {
+		verr = pruneRemote(ctx, dEnv, r)
+	}
+
+	if verr == nil && apr.Contains(allChunksFlag) {
+		verr = completeLazyClone(ctx, dEnv)
+	}
+
 	return HandleVErrAndExitCode(verr, usage)
 }
 
+// deepenShallowClone fetches depth more generations of commits from behind the shallow boundary of the repository.
+func deepenShallowClone(ctx context.Context, dEnv *env.DoltEnv, rem env.Remote, depth int) errhand.VerboseError {
+	if depth < 1 {
+		return errhand.BuildDError("fatal: depth %d is not a positive number", depth).Build()
+	}
+
+	if !dEnv.DoltDB.IsShallow() {
+		return errhand.BuildDError("fatal: --deepen on a complete repository does not make sense").Build()
+	}
+
+	srcDB, err := rem.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get remote db").AddCause(err).Build()
+	}
+
+	wg, progChan, pullerEventCh := runProgFuncs()
+	err = actions.Deepen(ctx, dEnv, srcDB, depth, progChan)
+	stopProgFuncs(wg, progChan, pullerEventCh)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to deepen the shallow clone").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// completeLazyClone downloads all of the chunks which are missing from a lazy clone.
+func completeLazyClone(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	if !dEnv.DoltDB.IsLazy() {
+		return errhand.BuildDError("fatal: --all-chunks can only be used in a lazy clone").Build()
+	}
+
+	cli.Println("Downloading all of the missing data from the remote")
+	err := actions.CompleteLazyClone(ctx, dEnv)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to download the missing data").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+func isRemoteName(remotes map[string]env.Remote, name string) bool {
+	_, ok := remotes[name]
+	return ok
+}
+
 func getRefSpecs(args []string, dEnv *env.DoltEnv, remotes map[string]env.Remote) (env.Remote, []ref.RemoteRefSpec, errhand.VerboseError) {
 	if len(remotes) == 0 {
 		return env.NoRemote, nil, errhand.BuildDError("error: no remotes set").AddDetails("to add a remote run: dolt remote add <remote> <url>").Build()
@@ -185,28 +271,23 @@ func fetchRefSpecs(ctx context.Context, mode ref.RefUpdateMode, dEnv *env.DoltEn
 		for _, branchRef := range branchRefs {
 			remoteTrackRef := rs.DestRef(branchRef)
 
-			if remoteTrackRef != nil {
-				srcDBCommit, verr := fetchRemoteBranch(ctx, dEnv, rem, srcDB, dEnv.DoltDB, branchRef, remoteTrackRef)
+			if remoteTrackRef != nil && remoteTrackRef.GetType() == ref.TagRefType {
+				verr := fetchRemoteTag(ctx, mode, dEnv, rem, srcDB, dEnv.DoltDB, branchRef, remoteTrackRef.(ref.TagRef))
 
 				if verr != nil {
 					return verr
 				}
+			} else if remoteTrackRef != nil {
+				srcDBCommit, verr := fetchRemoteBranch(ctx, dEnv, rem, srcDB, dEnv.DoltDB, branchRef, remoteTrackRef)
 
-				switch mode {
-				case ref.ForceUpdate:
-					err = dEnv.DoltDB.SetHead(ctx, remoteTrackRef, srcDBCommit)
-				case ref.FastForwardOnly:
-					ok, err := dEnv.DoltDB.CanFastForward(ctx, remoteTrackRef, srcDBCommit)
-					if !ok {
-						return errhand.BuildDError("error: fetch failed, can't fast forward remote tracking ref").Build()
-					}
-					if err == nil {
-						err = dEnv.DoltDB.FastForward(ctx, remoteTrackRef, srcDBCommit)
-					}
+				if verr != nil {
+					return verr
 				}
 
-				if err != nil {
-					return errhand.BuildDError("error: fetch failed").AddCause(err).Build()
+				verr = updateFetchedRef(ctx, mode, dEnv, remoteTrackRef, srcDBCommit)
+
+				if verr != nil {
+					return verr
 				}
 			}
 		}
@@ -215,6 +296,30 @@ func fetchRefSpecs(ctx context.Context, mode ref.RefUpdateMode, dEnv *env.DoltEn
 	return nil
 }
 
+// updateFetchedRef points destRef at the fetched commit cm.  Unless mode is a forced update, destRef must be able to
+// be fast-forwarded to cm.
+func updateFetchedRef(ctx context.Context, mode ref.RefUpdateMode, dEnv *env.DoltEnv, destRef ref.DoltRef, cm *doltdb.Commit) errhand.VerboseError {
+	var err error
+	switch mode {
+	case ref.ForceUpdate:
+		err = dEnv.DoltDB.SetHead(ctx, destRef, cm)
+	case ref.FastForwardOnly:
+		ok, err := dEnv.DoltDB.CanFastForward(ctx, destRef, cm)
+		if !ok {
+			return errhand.BuildDError("error: fetch failed, can't fast forward remote tracking ref").Build()
+		}
+		if err == nil {
+			err = dEnv.DoltDB.FastForward(ctx, destRef, cm)
+		}
+	}
+
+	if err != nil {
+		return errhand.BuildDError("error: fetch failed").AddCause(err).Build()
+	}
+
+	return nil
+}
+
 func fetchRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, rem env.Remote, srcDB, destDB *doltdb.DoltDB, srcRef, destRef ref.DoltRef) (*doltdb.Commit, errhand.VerboseError) {
 	evt := events.GetEventFromContext(ctx)
 
@@ -243,3 +348,23 @@ func fetchRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, rem env.Remote, s
 
 	return srcDBCommit, nil
 }
+
+func fetchRemoteTag(ctx context.Context, mode ref.RefUpdateMode, dEnv *env.DoltEnv, rem env.Remote, srcDB, destDB *doltdb.DoltDB, srcRef ref.DoltRef, destRef ref.TagRef) errhand.VerboseError {
+	tag, err := srcDB.ResolveTag(ctx, srcRef)
+
+	if err != nil {
+		return errhand.BuildDError("error: unable to find tag '%s' on '%s'", srcRef.GetPath(), rem.Name).Build()
+	}
+
+	wg, progChan, pullerEventCh := runProgFuncs()
+	err = actions.FetchTag(ctx, dEnv, mode, destRef, srcDB, destDB, tag, progChan, pullerEventCh)
+	stopProgFuncs(wg, progChan, pullerEventCh)
+
+	if err == doltdb.ErrTagExists {
+		cli.Printf("! [rejected]          %s -> %s (would clobber existing tag)\n", srcRef.GetPath(), destRef.GetPath())
+	} else if err != nil && err != doltdb.ErrUpToDate {
+		return errhand.BuildDError("error: fetch failed").AddCause(err).Build()
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/datas"
+)
+
+const connectivityOnlyFlag = "connectivity-only"
+
+var fsckDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Verify the integrity of the repository",
+	LongDesc: `Checks that the data of the repository is complete and intact:
+
+  - the index of every table file can be read, and every chunk in the table file passes its checksum and has the hash the index gives it
+  - every branch, tag, remote tracking branch and stash resolves to a commit, and the working and staged tables can be read
+  - every chunk reachable from a ref, from the working and staged tables, or from anything else kept by {{.EmphasisLeft}}dolt gc{{.EmphasisRight}} is present and can be decoded
+  - the rows of every table in the working and staged tables and in every commit match the schema of the table, and every index of the table has exactly one entry for each row
+
+In a lazy clone only the data which is stored locally is checked, and nothing is fetched from the remote. The chunks which have not been fetched yet are counted in {{.EmphasisLeft}}remote_chunks{{.EmphasisRight}}, and the refs, commits and tables which can't be read without them are counted in {{.EmphasisLeft}}unverified{{.EmphasisRight}}.
+
+The result is written as a JSON report with the counts of what was checked, and a list of problems. Each problem has a {{.EmphasisLeft}}kind{{.EmphasisRight}} of {{.EmphasisLeft}}table_file{{.EmphasisRight}}, {{.EmphasisLeft}}chunk{{.EmphasisRight}}, {{.EmphasisLeft}}ref{{.EmphasisRight}}, {{.EmphasisLeft}}root{{.EmphasisRight}}, {{.EmphasisLeft}}commit{{.EmphasisRight}} or {{.EmphasisLeft}}table{{.EmphasisRight}}, the {{.EmphasisLeft}}object{{.EmphasisRight}} it was found in, a {{.EmphasisLeft}}location{{.EmphasisRight}} giving the commit or root a table problem was found in, and a {{.EmphasisLeft}}message{{.EmphasisRight}}. The exit code is 1 if any problems are found.`,
+	Synopsis: []string{
+		"[--connectivity-only]",
+	},
+}
+
+type FsckCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd FsckCmd) Name() string {
+	return "fsck"
+}
+
+// Description returns a description of the command
+func (cmd FsckCmd) Description() string {
+	return "Verify the integrity of the repository."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd FsckCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, fsckDocs, ap))
+}
+
+func (cmd FsckCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.SupportsFlag(connectivityOnlyFlag, "", "Only check that the data is present and can be decoded, without checking the rows of tables.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd FsckCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, fsckDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() != 0 {
+		usage()
+		return 1
+	}
+
+	report, err := actions.Fsck(ctx, dEnv, apr.Contains(connectivityOnlyFlag))
+
+	if err == datas.ErrVerifyNotSupported {
+		verr := errhand.BuildDError("error: verification is not supported for this repository's storage").Build()
+		return HandleVErrAndExitCode(verr, usage)
+	} else if err != nil {
+		verr := errhand.BuildDError("error: failed to check the repository").AddCause(err).Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+
+	if err != nil {
+		verr := errhand.BuildDError("error: failed to write the report").AddCause(err).Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	cli.Println(string(data))
+
+	if !report.OK {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/datas"
+)
+
+const dryRunFlag = "dry-run"
+
+var gcDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Remove unreachable data from the repository",
+	LongDesc: `Data which is written to a repository is never removed by other commands, even once nothing refers to it. For example, tables which are imported and then discarded using {{.EmphasisLeft}}dolt reset --hard{{.EmphasisRight}} continue to use space on disk.
+
+{{.EmphasisLeft}}dolt gc{{.EmphasisRight}} finds all the data which is reachable from a branch, tag, remote tracking branch or stash, from the working and staged tables, from a merge, cherry-pick or rebase in progress, or from a commit or root recorded in a reflog. The reachable data is rewritten into new table files, the repository is updated to use them, and the old table files are deleted.
+
+No other dolt commands, including {{.EmphasisLeft}}dolt sql-server{{.EmphasisRight}}, should be run against the repository while garbage is being collected.`,
+	Synopsis: []string{
+		"[--dry-run]",
+	},
+}
+
+type GarbageCollectionCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd GarbageCollectionCmd) Name() string {
+	return "gc"
+}
+
+// Description returns a description of the command
+func (cmd GarbageCollectionCmd) Description() string {
+	return "Remove unreachable data from the repository."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd GarbageCollectionCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, gcDocs, ap))
+}
+
+func (cmd GarbageCollectionCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.SupportsFlag(dryRunFlag, "", "Report how much space would be reclaimed without removing anything.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd GarbageCollectionCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, gcDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() != 0 {
+		usage()
+		return 1
+	}
+
+	dryRun := apr.Contains(dryRunFlag)
+	stats, err := actions.GarbageCollect(ctx, dEnv, dryRun)
+
+	if err == datas.ErrGCNotSupported {
+		verr := errhand.BuildDError("error: garbage collection is not supported for this repository's storage").Build()
+		return HandleVErrAndExitCode(verr, usage)
+	} else if err != nil {
+		verr := errhand.BuildDError("error: failed to collect garbage").AddCause(err).Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	unreachable := stats.ChunksBefore - stats.ChunksAfter
+	reclaimed := humanize.Bytes(stats.ReclaimedBytes())
+
+	if dryRun {
+		cli.Printf("%d of %d chunks are unreachable. Collecting garbage would reclaim %s (%d bytes).\n", unreachable, stats.ChunksBefore, reclaimed, stats.ReclaimedBytes())
+	} else {
+		cli.Printf("Removed %d of %d chunks. Reclaimed %s (%d bytes).\n", unreachable, stats.ChunksBefore, reclaimed, stats.ReclaimedBytes())
+		cli.Printf("Rewrote %d table files into %d.\n", stats.TableFilesBefore, stats.TableFilesAfter)
+	}
+
+	return 0
+}
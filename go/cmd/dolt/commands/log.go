@@ -16,12 +16,18 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
 	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
@@ -33,52 +39,95 @@ import (
 
 const (
 	numLinesParam = "number"
+	authorParam   = "author"
+	sinceParam    = "since"
+	untilParam    = "until"
+	grepParam     = "grep"
+	mergesParam   = "merges"
+	noMergesParam = "no-merges"
+	onelineParam  = "oneline"
+	graphParam    = "graph"
+	showSigParam  = "show-signature"
 )
 
 var logDocs = cli.CommandDocumentationContent{
 	ShortDesc: `Show commit logs`,
 	LongDesc: `Shows the commit logs
 
-The command takes options to control what is shown and how.`,
+The command takes options to control what is shown and how.
+
+If one or more tables are given, only commits which changed one of those tables are shown. When a table has the same name as a branch or another commit, separate the tables from the commit using {{.EmphasisLeft}}--{{.EmphasisRight}}.
+
+The dates given to {{.EmphasisLeft}}--since{{.EmphasisRight}} and {{.EmphasisLeft}}--until{{.EmphasisRight}} can be in the formats {{.LessThan}}YYYY-MM-DD{{.GreaterThan}}, {{.LessThan}}YYYY-MM-DDTHH:MM:SS{{.GreaterThan}}, or {{.LessThan}}YYYY-MM-DDTHH:MM:SSZ07:00{{.GreaterThan}}, or relative to the current time, such as {{.LessThan}}2 weeks ago{{.GreaterThan}}.
+
+With {{.EmphasisLeft}}--show-signature{{.EmphasisRight}}, the signature of each signed commit is checked against the keys in {{.EmphasisLeft}}~/.dolt/trusted_keys{{.EmphasisRight}}, as done by {{.EmphasisLeft}}dolt verify-commit{{.EmphasisRight}}.`,
 	Synopsis: []string{
-		`[-n {{.LessThan}}num_commits{{.GreaterThan}}] [{{.LessThan}}commit{{.GreaterThan}}]`,
+		`[-n {{.LessThan}}num_commits{{.GreaterThan}}] [{{.LessThan}}options{{.GreaterThan}}] [{{.LessThan}}commit{{.GreaterThan}}] [[--] {{.LessThan}}table{{.GreaterThan}}...]`,
 	},
 }
 
-type commitLoggerFunc func(*doltdb.CommitMeta, []hash.Hash, hash.Hash)
+// logOpts are the options which control which commits are shown by dolt log, and how
+type logOpts struct {
+	numLines int
+	author   *regexp.Regexp
+	grep     *regexp.Regexp
+	since    *time.Time
+	until    *time.Time
+	tables   []string
+	merges   bool
+	noMerges bool
+	oneline  bool
+	graph    bool
+	showSig  bool
+}
 
-func logToStdOutFunc(cm *doltdb.CommitMeta, parentHashes []hash.Hash, ch hash.Hash) {
-	cli.Println(color.YellowString("commit %s", ch.String()))
+// hasFilters returns true if any options were given which exclude commits from the log
+func (opts *logOpts) hasFilters() bool {
+	return opts.author != nil || opts.grep != nil || opts.since != nil || opts.until != nil || len(opts.tables) > 0 || opts.merges || opts.noMerges
+}
 
-	if len(parentHashes) > 1 {
-		printMerge(parentHashes)
-	}
+// logCommit is a commit which is being shown by dolt log
+type logCommit struct {
+	commit  *doltdb.Commit
+	hash    hash.Hash
+	meta    *doltdb.CommitMeta
+	parents []hash.Hash
 
-	printAuthor(cm)
-	printDate(cm)
-	printDesc(cm)
+	// signature describes the signature of the commit, and is only set when signatures are shown
+	signature string
 }
 
-func printMerge(hashes []hash.Hash) {
-	cli.Print("Merge:")
-	for _, h := range hashes {
-		cli.Print(" " + h.String())
+// formatCommit returns the lines used to show a commit in the log.
+func formatCommit(lc *logCommit, oneline bool) []string {
+	if oneline {
+		summary := strings.SplitN(lc.meta.Description, "\n", 2)[0]
+		return []string{color.YellowString(lc.hash.String()) + " " + summary}
 	}
-	cli.Println()
-}
 
-func printAuthor(cm *doltdb.CommitMeta) {
-	cli.Printf("Author: %s <%s>\n", cm.Name, cm.Email)
-}
+	lines := []string{color.YellowString("commit %s", lc.hash.String())}
 
-func printDate(cm *doltdb.CommitMeta) {
-	timeStr := cm.FormatTS()
-	cli.Println("Date:  ", timeStr)
-}
+	if lc.signature != "" {
+		lines = append(lines, lc.signature)
+	}
+
+	if len(lc.parents) > 1 {
+		merge := "Merge:"
+		for _, h := range lc.parents {
+			merge += " " + h.String()
+		}
+
+		lines = append(lines, merge)
+	}
+
+	lines = append(lines, fmt.Sprintf("Author: %s <%s>", lc.meta.Name, lc.meta.Email))
+	lines = append(lines, "Date:   "+lc.meta.FormatTS())
+	lines = append(lines, "")
+
+	for _, descLine := range strings.Split(lc.meta.Description, "\n") {
+		lines = append(lines, "\t"+descLine)
+	}
 
-func printDesc(cm *doltdb.CommitMeta) {
-	formattedDesc := "\n\t" + strings.Replace(cm.Description, "\n", "\n\t", -1) + "\n"
-	cli.Println(formattedDesc)
+	return append(lines, "")
 }
 
 type LogCmd struct{}
@@ -107,57 +156,207 @@ func (cmd LogCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) er
 func createLogArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.SupportsInt(numLinesParam, "n", "num_commits", "Limit the number of commits to output")
+	ap.SupportsString(authorParam, "", "pattern", "Limit the commits output to ones with an author name or email matching the regular expression {{.LessThan}}pattern{{.GreaterThan}}.")
+	ap.SupportsString(sinceParam, "", "date", "Show commits more recent than {{.LessThan}}date{{.GreaterThan}}.")
+	ap.SupportsString(untilParam, "", "date", "Show commits older than {{.LessThan}}date{{.GreaterThan}}.")
+	ap.SupportsString(grepParam, "", "pattern", "Limit the commits output to ones with a commit message matching the regular expression {{.LessThan}}pattern{{.GreaterThan}}.")
+	ap.SupportsFlag(mergesParam, "", "Print only merge commits.")
+	ap.SupportsFlag(noMergesParam, "", "Do not print merge commits.")
+	ap.SupportsFlag(onelineParam, "", "Show each commit on a single line, as its hash followed by the first line of its message.")
+	ap.SupportsFlag(graphParam, "", "Draw a text-based graph of the commit history to the left of the log.")
+	ap.SupportsFlag(showSigParam, "", "Check the signature of each signed commit, and show whether it is good and was made with a trusted key.")
 	return ap
 }
 
 // Exec executes the command
 func (cmd LogCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
-	return logWithLoggerFunc(ctx, commandStr, args, dEnv, logToStdOutFunc)
-}
-
-func logWithLoggerFunc(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, loggerFunc commitLoggerFunc) int {
 	ap := createLogArgParser()
-	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, logDocs, ap))
+	help, _ := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, logDocs, ap))
 	apr := cli.ParseArgs(ap, args, help)
 
-	if apr.NArg() > 1 {
-		usage()
+	opts, err := parseLogOpts(apr)
+
+	if err != nil {
+		cli.PrintErrln(err.Error())
 		return 1
 	}
 
-	cs, err := parseCommitSpec(dEnv, apr)
+	commit, tables, err := parseLogArgs(ctx, dEnv, apr.Args())
+
 	if err != nil {
-		cli.PrintErr(err)
+		cli.PrintErrln(err.Error())
 		return 1
 	}
 
-	numLines := apr.GetIntOrDefault(numLinesParam, -1)
-	return logCommits(ctx, dEnv, cs, loggerFunc, numLines)
+	opts.tables = tables
+	return logCommits(ctx, dEnv, commit, opts)
+}
+
+func parseLogOpts(apr *argparser.ArgParseResults) (*logOpts, error) {
+	opts := &logOpts{
+		numLines: apr.GetIntOrDefault(numLinesParam, -1),
+		merges:   apr.Contains(mergesParam),
+		noMerges: apr.Contains(noMergesParam),
+		oneline:  apr.Contains(onelineParam),
+		graph:    apr.Contains(graphParam),
+		showSig:  apr.Contains(showSigParam),
+	}
+
+	if opts.merges && opts.noMerges {
+		return nil, errors.New("error: --merges and --no-merges cannot be used together")
+	}
+
+	var err error
+	if pattern, ok := apr.GetValue(authorParam); ok {
+		if opts.author, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("error: invalid --author pattern '%s': %s", pattern, err.Error())
+		}
+	}
+
+	if pattern, ok := apr.GetValue(grepParam); ok {
+		if opts.grep, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("error: invalid --grep pattern '%s': %s", pattern, err.Error())
+		}
+	}
+
+	if dateStr, ok := apr.GetValue(sinceParam); ok {
+		t, err := parseLogDate(dateStr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		opts.since = &t
+	}
+
+	if dateStr, ok := apr.GetValue(untilParam); ok {
+		t, err := parseLogDate(dateStr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		opts.until = &t
+	}
+
+	return opts, nil
+}
+
+var relativeDateRegex = regexp.MustCompile(`^(\d+)[ .](second|minute|hour|day|week|month|year)s?[ .]ago$`)
+
+// parseLogDate parses a date in any of the formats supported by dolt commit --date, or a date relative to the current
+// time such as "2 weeks ago".
+func parseLogDate(dateStr string) (time.Time, error) {
+	if matches := relativeDateRegex.FindStringSubmatch(strings.TrimSpace(dateStr)); matches != nil {
+		n, _ := strconv.Atoi(matches[1])
+		now := time.Now()
+
+		switch matches[2] {
+		case "second":
+			return now.Add(-time.Duration(n) * time.Second), nil
+		case "minute":
+			return now.Add(-time.Duration(n) * time.Minute), nil
+		case "hour":
+			return now.Add(-time.Duration(n) * time.Hour), nil
+		case "day":
+			return now.AddDate(0, 0, -n), nil
+		case "week":
+			return now.AddDate(0, 0, -7*n), nil
+		case "month":
+			return now.AddDate(0, -n, 0), nil
+		case "year":
+			return now.AddDate(-n, 0, 0), nil
+		}
+	}
+
+	return parseDate(dateStr)
 }
 
-func parseCommitSpec(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (*doltdb.CommitSpec, error) {
-	if apr.NArg() == 0 || apr.Arg(0) == "--" {
-		return dEnv.RepoState.CWBHeadSpec(), nil
+// parseLogArgs returns the commit the log starts at and the tables the log is limited to. Arguments before a "--" are
+// a commit, and arguments after it are tables. Without a "--", the first argument is a commit if it resolves to one,
+// and every other argument must be a table.
+func parseLogArgs(ctx context.Context, dEnv *env.DoltEnv, args []string) (*doltdb.Commit, []string, error) {
+	sepIdx := -1
+	for i, arg := range args {
+		if arg == "--" {
+			sepIdx = i
+			break
+		}
+	}
+
+	var commitArgs, tables []string
+	if sepIdx >= 0 {
+		commitArgs, tables = args[:sepIdx], args[sepIdx+1:]
+
+		if len(commitArgs) > 1 {
+			return nil, nil, fmt.Errorf("error: only one commit may be given, found '%s'", strings.Join(commitArgs, " "))
+		}
+	} else if len(args) > 0 {
+		if cm, err := resolveLogCommit(ctx, dEnv, args[0]); err == nil {
+			return cm, args[1:], checkLogTablesExist(ctx, cm, args[1:])
+		}
+
+		tables = args
+	}
+
+	if len(commitArgs) == 1 {
+		cm, err := resolveLogCommit(ctx, dEnv, commitArgs[0])
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid commit %s", commitArgs[0])
+		}
+
+		return cm, tables, nil
 	}
 
-	comSpecStr := apr.Arg(0)
-	cs, err := doltdb.NewCommitSpec(comSpecStr, dEnv.RepoState.CWBHeadRef().String())
+	cm, err := dEnv.DoltDB.Resolve(ctx, dEnv.RepoState.CWBHeadSpec())
 
 	if err != nil {
-		return nil, fmt.Errorf("invalid commit %s\n", comSpecStr)
+		return nil, nil, errors.New("Fatal error: cannot get HEAD commit for current branch.")
 	}
 
-	return cs, nil
+	if sepIdx < 0 {
+		err = checkLogTablesExist(ctx, cm, tables)
+	}
+
+	return cm, tables, err
 }
 
-func logCommits(ctx context.Context, dEnv *env.DoltEnv, cs *doltdb.CommitSpec, loggerFunc commitLoggerFunc, numLines int) int {
-	commit, err := dEnv.DoltDB.Resolve(ctx, cs)
+func resolveLogCommit(ctx context.Context, dEnv *env.DoltEnv, cSpecStr string) (*doltdb.Commit, error) {
+	cs, err := doltdb.NewCommitSpec(cSpecStr, dEnv.RepoState.CWBHeadRef().String())
 
 	if err != nil {
-		cli.PrintErrln(color.HiRedString("Fatal error: cannot get HEAD commit for current branch."))
-		return 1
+		return nil, err
+	}
+
+	return dEnv.DoltDB.Resolve(ctx, cs)
+}
+
+// checkLogTablesExist returns an error if any of the tables given aren't in the commit given. Tables which no longer
+// exist can still be given after a "--".
+func checkLogTablesExist(ctx context.Context, cm *doltdb.Commit, tables []string) error {
+	if len(tables) == 0 {
+		return nil
 	}
 
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return err
+	}
+
+	for _, tbl := range tables {
+		if has, err := root.HasTable(ctx, tbl); err != nil {
+			return err
+		} else if !has {
+			return fmt.Errorf("error: '%s' is neither a commit nor a table. Use '--' to separate a commit from tables which no longer exist.", tbl)
+		}
+	}
+
+	return nil
+}
+
+func logCommits(ctx context.Context, dEnv *env.DoltEnv, commit *doltdb.Commit, opts *logOpts) int {
 	h, err := commit.HashOf()
 
 	if err != nil {
@@ -165,36 +364,316 @@ func logCommits(ctx context.Context, dEnv *env.DoltEnv, cs *doltdb.CommitSpec, l
 		return 1
 	}
 
-	commits, err := commitwalk.GetTopNTopoOrderedCommits(ctx, dEnv.DoltDB, h, numLines)
+	var commits []*logCommit
+	if opts.hasFilters() {
+		commits, err = getFilteredLogCommits(ctx, dEnv.DoltDB, h, opts)
+	} else {
+		commits, err = getLogCommits(ctx, dEnv.DoltDB, h, opts.numLines)
+	}
 
 	if err != nil {
-		cli.PrintErrln("Error retrieving commit.")
+		cli.PrintErrln("error: failed to retrieve commits")
+		cli.PrintErrln(err.Error())
 		return 1
 	}
 
-	for _, comm := range commits {
-		meta, err := comm.GetCommitMeta()
+	if opts.showSig {
+		verr := addLogSignatures(dEnv, commits)
 
-		if err != nil {
-			cli.PrintErrln("error: failed to get commit metadata")
+		if verr != nil {
+			cli.PrintErrln(verr.Verbose())
 			return 1
 		}
+	}
 
-		pHashes, err := comm.ParentHashes(ctx)
+	if opts.graph {
+		printLogGraph(commits, opts.oneline)
+		return 0
+	}
+
+	for _, lc := range commits {
+		for _, line := range formatCommit(lc, opts.oneline) {
+			cli.Println(line)
+		}
+	}
+
+	return 0
+}
+
+// addLogSignatures sets the signature status of each of the signed commits.
+func addLogSignatures(dEnv *env.DoltEnv, commits []*logCommit) errhand.VerboseError {
+	tk, verr := loadTrustedKeys(dEnv, "")
+
+	if verr != nil {
+		return verr
+	}
+
+	for _, lc := range commits {
+		// unsigned commits are shown without a signature line
+		if _, err := lc.commit.GetSignature(); err == doltdb.ErrUnsignedCommit {
+			continue
+		}
+
+		status, _, err := signatureStatus(lc.commit, tk)
 
 		if err != nil {
-			cli.PrintErrln("error: failed to get parent hashes")
-			return 1
+			return errhand.BuildDError("error: failed to read the signature of %s", lc.hash.String()).AddCause(err).Build()
+		}
+
+		lc.signature = status
+	}
+
+	return nil
+}
+
+func newLogCommit(ctx context.Context, cm *doltdb.Commit) (*logCommit, error) {
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return nil, err
+	}
+
+	parents, err := cm.ParentHashes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &logCommit{cm, h, meta, parents, ""}, nil
+}
+
+// getLogCommits returns the first numLines commits in the history of the commit with hash h.
+func getLogCommits(ctx context.Context, ddb *doltdb.DoltDB, h hash.Hash, numLines int) ([]*logCommit, error) {
+	commits, err := commitwalk.GetTopNTopoOrderedCommits(ctx, ddb, h, numLines)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logCommits := make([]*logCommit, len(commits))
+	for i, cm := range commits {
+		logCommits[i], err = newLogCommit(ctx, cm)
+
+		if err != nil {
+			return nil, err
 		}
+	}
+
+	return logCommits, nil
+}
 
-		cmHash, err := comm.HashOf()
+// getFilteredLogCommits returns the first opts.numLines commits in the history of the commit with hash h which match
+// the filters in opts. When drawing a graph, the parents of each returned commit are rewritten to be its closest
+// ancestors which match the filters, so that the graph only connects commits that are shown.
+func getFilteredLogCommits(ctx context.Context, ddb *doltdb.DoltDB, h hash.Hash, opts *logOpts) ([]*logCommit, error) {
+	itr, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, h)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var all, matched []*logCommit
+	isMatch := make(map[hash.Hash]bool)
+	for {
+		_, cm, err := itr.Next(ctx)
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		lc, err := newLogCommit(ctx, cm)
 
 		if err != nil {
-			cli.PrintErrln("error: failed to get commit hash")
-			return 1
+			return nil, err
+		}
+
+		ok, err := opts.matches(ctx, ddb, lc)
+
+		if err != nil {
+			return nil, err
+		}
+
+		isMatch[lc.hash] = ok
+		all = append(all, lc)
+
+		if ok {
+			matched = append(matched, lc)
+
+			// the whole history is needed to rewrite parents for the graph
+			if !opts.graph && len(matched) == opts.numLines {
+				break
+			}
 		}
-		loggerFunc(meta, pHashes, cmHash)
 	}
 
-	return 0
+	if opts.graph {
+		rewriteLogParents(all, isMatch)
+	}
+
+	if opts.numLines >= 0 && len(matched) > opts.numLines {
+		matched = matched[:opts.numLines]
+	}
+
+	return matched, nil
+}
+
+// rewriteLogParents replaces the parents of each commit in |commits| with the closest ancestors along each of its
+// parents for which |isMatch| is true. |commits| must be in reverse topological order.
+func rewriteLogParents(commits []*logCommit, isMatch map[hash.Hash]bool) {
+	closest := make(map[hash.Hash][]hash.Hash, len(commits))
+	for i := len(commits) - 1; i >= 0; i-- {
+		lc := commits[i]
+
+		var parents []hash.Hash
+		seen := make(map[hash.Hash]bool)
+		for _, parent := range lc.parents {
+			for _, anc := range closest[parent] {
+				if !seen[anc] {
+					seen[anc] = true
+					parents = append(parents, anc)
+				}
+			}
+		}
+
+		if isMatch[lc.hash] {
+			lc.parents = parents
+			closest[lc.hash] = []hash.Hash{lc.hash}
+		} else {
+			closest[lc.hash] = parents
+		}
+	}
+}
+
+// matches returns true if the commit given passes all the filters in opts.
+func (opts *logOpts) matches(ctx context.Context, ddb *doltdb.DoltDB, lc *logCommit) (bool, error) {
+	isMerge := len(lc.parents) > 1
+
+	if (opts.merges && !isMerge) || (opts.noMerges && isMerge) {
+		return false, nil
+	}
+
+	if opts.author != nil && !opts.author.MatchString(fmt.Sprintf("%s <%s>", lc.meta.Name, lc.meta.Email)) {
+		return false, nil
+	}
+
+	if opts.grep != nil && !opts.grep.MatchString(lc.meta.Description) {
+		return false, nil
+	}
+
+	if opts.since != nil && lc.meta.Time().Before(*opts.since) {
+		return false, nil
+	}
+
+	if opts.until != nil && lc.meta.Time().After(*opts.until) {
+		return false, nil
+	}
+
+	if len(opts.tables) > 0 {
+		return commitChangesTables(ctx, ddb, lc.commit, opts.tables)
+	}
+
+	return true, nil
+}
+
+// commitChangesTables returns true if the commit given changed any of the tables given. A merge commit only changes a
+// table if the table differs from the table in every parent, so that changes which were made on a merged branch are
+// attributed to the commit on that branch rather than the merge.
+func commitChangesTables(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit, tables []string) (bool, error) {
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return false, err
+	}
+
+	parents, err := ddb.ResolveAllParents(ctx, cm)
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, tbl := range tables {
+		h, _, err := root.GetTableHash(ctx, tbl)
+
+		if err != nil {
+			return false, err
+		}
+
+		if len(parents) == 0 {
+			if !h.IsEmpty() {
+				return true, nil
+			}
+
+			continue
+		}
+
+		changed := true
+		for _, parent := range parents {
+			parentRoot, err := parent.GetRootValue()
+
+			if err != nil {
+				return false, err
+			}
+
+			parentH, _, err := parentRoot.GetTableHash(ctx, tbl)
+
+			if err != nil {
+				return false, err
+			}
+
+			if parentH == h {
+				changed = false
+				break
+			}
+		}
+
+		if changed {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// printLogGraph prints the commits given alongside a graph of the commit history.
+func printLogGraph(commits []*logCommit, oneline bool) {
+	graph := &logGraph{}
+	for _, lc := range commits {
+		graphLines, continuation := graph.next(lc.hash, lc.parents)
+		textLines := formatCommit(lc, oneline)
+
+		width := len(continuation)
+		for _, line := range graphLines {
+			if len(line) > width {
+				width = len(line)
+			}
+		}
+
+		numLines := len(textLines)
+		if len(graphLines) > numLines {
+			numLines = len(graphLines)
+		}
+
+		for i := 0; i < numLines; i++ {
+			graphLine := continuation
+			if i < len(graphLines) {
+				graphLine = graphLines[i]
+			}
+
+			var text string
+			if i < len(textLines) {
+				text = textLines[i]
+			}
+
+			line := fmt.Sprintf("%-*s %s", width, graphLine, text)
+			cli.Println(strings.TrimRight(line, " "))
+		}
+	}
 }
@@ -0,0 +1,142 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// logGraph draws an ASCII rendering of a commit graph, one commit at a time. Commits must be added in reverse
+// topological order. Each column of the graph is a lane which holds the hash of the next commit expected in that lane.
+type logGraph struct {
+	lanes []hash.Hash
+}
+
+// laneEdge is a line in the graph from a lane before a commit to a lane after it
+type laneEdge struct {
+	from int
+	to   int
+}
+
+// next adds the commit with hash h and the given parents to the graph, and returns the graph lines drawn for it. The
+// first line is the one containing the commit. The second line, if there is one, shows lanes being added, moved or
+// removed. Lines after these should be prefixed with the returned continuation line.
+func (g *logGraph) next(h hash.Hash, parents []hash.Hash) (lines []string, continuation string) {
+	idx := -1
+	for i, lane := range g.lanes {
+		if lane == h {
+			idx = i
+			break
+		}
+	}
+
+	if idx == -1 {
+		g.lanes = append(g.lanes, h)
+		idx = len(g.lanes) - 1
+	}
+
+	commitRow := make([]string, len(g.lanes))
+	for i := range g.lanes {
+		commitRow[i] = "|"
+	}
+	commitRow[idx] = "*"
+	lines = append(lines, strings.Join(commitRow, " "))
+
+	var newLanes []hash.Hash
+	addLane := func(h hash.Hash) int {
+		for i, lane := range newLanes {
+			if lane == h {
+				return i
+			}
+		}
+
+		newLanes = append(newLanes, h)
+		return len(newLanes) - 1
+	}
+
+	var edges []laneEdge
+	for i, lane := range g.lanes {
+		if i == idx {
+			for _, parent := range parents {
+				edges = append(edges, laneEdge{i, addLane(parent)})
+			}
+		} else {
+			edges = append(edges, laneEdge{i, addLane(lane)})
+		}
+	}
+
+	if transition, ok := drawLaneTransition(edges, len(g.lanes), len(newLanes)); ok {
+		lines = append(lines, transition)
+	}
+
+	g.lanes = newLanes
+
+	bars := make([]string, len(newLanes))
+	for i := range newLanes {
+		bars[i] = "|"
+	}
+
+	return lines, strings.Join(bars, " ")
+}
+
+// drawLaneTransition draws the edges between the lanes before and after a commit. If every lane which continues stays
+// where it is no line is needed, and false is returned.
+func drawLaneTransition(edges []laneEdge, numBefore, numAfter int) (string, bool) {
+	straight := true
+	for _, edge := range edges {
+		if edge.from != edge.to {
+			straight = false
+			break
+		}
+	}
+
+	if straight {
+		return "", false
+	}
+
+	width := numBefore
+	if numAfter > width {
+		width = numAfter
+	}
+
+	cells := []rune(strings.Repeat(" ", 2*width))
+	for _, edge := range edges {
+		if edge.from == edge.to {
+			cells[2*edge.from] = '|'
+		}
+	}
+
+	for _, edge := range edges {
+		if edge.to < edge.from {
+			for i := 2*edge.to + 1; i < 2*edge.from-1; i++ {
+				if cells[i] == ' ' {
+					cells[i] = '_'
+				}
+			}
+			cells[2*edge.from-1] = '/'
+		} else if edge.to > edge.from {
+			cells[2*edge.from+1] = '\\'
+			for i := 2*edge.from + 2; i < 2*edge.to; i++ {
+				if cells[i] == ' ' {
+					cells[i] = '_'
+				}
+			}
+		}
+	}
+
+	return strings.TrimRight(string(cells), " "), true
+}
@@ -0,0 +1,119 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+type graphTestCommit struct {
+	name    string
+	parents []string
+}
+
+func drawTestGraph(commits []graphTestCommit) string {
+	toHash := func(name string) hash.Hash {
+		return hash.Of([]byte(name))
+	}
+
+	var lines []string
+	g := &logGraph{}
+	for _, c := range commits {
+		var parents []hash.Hash
+		for _, p := range c.parents {
+			parents = append(parents, toHash(p))
+		}
+
+		graphLines, _ := g.next(toHash(c.name), parents)
+		graphLines[0] += " " + c.name
+		lines = append(lines, graphLines...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func TestLogGraph(t *testing.T) {
+	tests := []struct {
+		name     string
+		commits  []graphTestCommit
+		expected string
+	}{
+		{
+			"linear",
+			[]graphTestCommit{
+				{"c", []string{"b"}},
+				{"b", []string{"a"}},
+				{"a", nil},
+			},
+			"* c\n" +
+				"* b\n" +
+				"* a",
+		},
+		{
+			"merge",
+			[]graphTestCommit{
+				{"m", []string{"b", "d"}},
+				{"d", []string{"c"}},
+				{"b", []string{"a"}},
+				{"c", []string{"a"}},
+				{"a", nil},
+			},
+			"* m\n" +
+				"|\\\n" +
+				"| * d\n" +
+				"* | b\n" +
+				"| * c\n" +
+				"|/\n" +
+				"* a",
+		},
+		{
+			"two branch heads",
+			[]graphTestCommit{
+				{"b", []string{"a"}},
+				{"c", []string{"a"}},
+				{"a", nil},
+			},
+			"* b\n" +
+				"| * c\n" +
+				"|/\n" +
+				"* a",
+		},
+		{
+			"lanes shift when a lane ends",
+			[]graphTestCommit{
+				{"m", []string{"b", "x"}},
+				{"x", nil},
+				{"b", []string{"a"}},
+				{"a", nil},
+			},
+			"* m\n" +
+				"|\\\n" +
+				"| * x\n" +
+				"* b\n" +
+				"* a",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, drawTestGraph(test.commits))
+		})
+	}
+}
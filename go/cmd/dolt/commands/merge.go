@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/fatih/color"
 
@@ -36,7 +37,10 @@ import (
 )
 
 const (
-	abortParam = "abort"
+	abortParam  = "abort"
+	squashParam = "squash"
+	noFFParam   = "no-ff"
+	ffOnlyParam = "ff-only"
 )
 
 var mergeDocs = cli.CommandDocumentationContent{
@@ -46,10 +50,14 @@ var mergeDocs = cli.CommandDocumentationContent{
 The second syntax ({{.LessThan}}dolt merge --abort{{.GreaterThan}}) can only be run after the merge has resulted in conflicts. git merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will abort the merge process and try to reconstruct the pre-merge state. However, if there were uncommitted changes when the merge started (and especially if those changes were further modified after the merge was started), dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will in some cases be unable to reconstruct the original (pre-merge) changes. Therefore: 
 
 {{.LessThan}}Warning{{.GreaterThan}}: Running dolt merge with non-trivial uncommitted changes is discouraged: while possible, it may leave you in a state that is hard to back out of in the case of a conflict.
+
+When the current branch is an ancestor of the merged branch the merge is resolved as a fast-forward, and the current branch is updated to point at the merged commit. {{.EmphasisLeft}}--no-ff{{.EmphasisRight}} creates a merge commit even when a fast-forward is possible, and {{.EmphasisLeft}}--ff-only{{.EmphasisRight}} refuses to merge unless a fast-forward is possible.
+
+If the merge has no conflicts and a message is given using {{.EmphasisLeft}}-m{{.EmphasisRight}}, the merge is committed immediately. Otherwise the result of the merge is staged, and must be committed using {{.EmphasisLeft}}dolt commit{{.EmphasisRight}}.
 `,
 
 	Synopsis: []string{
-		"{{.LessThan}}branch{{.GreaterThan}}",
+		"[--squash | --no-ff | --ff-only] [-m {{.LessThan}}msg{{.GreaterThan}}] {{.LessThan}}branch{{.GreaterThan}}",
 		"--abort",
 	},
 }
@@ -59,6 +67,21 @@ var abortDetails = `Abort the current conflict resolution process, and try to re
 If there were uncommitted working set changes present when the merge started, {{.EmphasisLeft}}dolt merge --abort{{.EmphasisRight}} will be unable to reconstruct these changes. It is therefore recommended to always commit or stash your changes before running git merge.
 `
 
+var squashDetails = `Stage the result of the merge in the working set without recording that a merge happened. The next commit will have the current branch as its only parent, so that all the changes made by the merged branch are collapsed into a single commit. The current branch is not updated unless {{.EmphasisLeft}}-m{{.EmphasisRight}} is given.`
+
+// mergeOpts are the options which control how a branch is merged into the current branch
+type mergeOpts struct {
+	// squash stages the result of the merge without recording the merged commit as a parent
+	squash bool
+	// noFF creates a merge commit even when the merge could be resolved as a fast-forward
+	noFF bool
+	// ffOnly refuses to merge unless the merge can be resolved as a fast-forward
+	ffOnly bool
+	// msg is the message used to commit the merge when there are no conflicts.  When it is empty the merge is staged
+	// but not committed.
+	msg string
+}
+
 type MergeCmd struct{}
 
 // Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
@@ -80,6 +103,10 @@ func (cmd MergeCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string)
 func (cmd MergeCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.SupportsFlag(abortParam, "", abortDetails)
+	ap.SupportsFlag(squashParam, "", squashDetails)
+	ap.SupportsFlag(noFFParam, "", "Create a merge commit even when the merge resolves as a fast-forward.")
+	ap.SupportsFlag(ffOnlyParam, "", "Refuse to merge and exit with a non-zero status unless the current HEAD is already up to date or the merge can be resolved as a fast-forward.")
+	ap.SupportsString(commitMessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the message of the merge commit, and commit the merge if there are no conflicts.")
 	return ap
 }
 
@@ -108,6 +135,33 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 			return 1
 		}
 
+		opts := mergeOpts{
+			squash: apr.Contains(squashParam),
+			noFF:   apr.Contains(noFFParam),
+			ffOnly: apr.Contains(ffOnlyParam),
+		}
+
+		if opts.squash && opts.noFF {
+			cli.PrintErrln("fatal: You cannot combine --squash with --no-ff.")
+			return 1
+		} else if opts.noFF && opts.ffOnly {
+			cli.PrintErrln("fatal: You cannot combine --no-ff with --ff-only.")
+			return 1
+		}
+
+		if msg, ok := apr.GetValue(commitMessageArg); ok {
+			if msg == "" {
+				return handleCommitErr(ctx, dEnv, actions.ErrEmptyCommitMessage, usage)
+			}
+
+			// fail before the merge is started if the merge could not be committed
+			if _, _, err := actions.GetNameAndEmail(dEnv.Config); err != nil {
+				return handleCommitErr(ctx, dEnv, err, usage)
+			}
+
+			opts.msg = msg
+		}
+
 		branchName := apr.Arg(0)
 		dref, err := dEnv.FindRef(ctx, branchName)
 
@@ -133,15 +187,23 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 				cli.Println("hint: add affected tables using 'dolt add <table>' and commit using {{.EmphasisLeft}}dolt commit -m <msg>{{.EmphasisRight}}")
 				cli.Println("fatal: Exiting because of active merge")
 				return 1
+			} else if dEnv.IsRebaseActive() {
+				cli.Println("error: Merging is not possible because a rebase is in progress.")
+				cli.Println("hint: use 'dolt rebase --continue' or 'dolt rebase --abort' to finish the rebase first")
+				return 1
+			} else if dEnv.IsBisectActive() {
+				cli.Println("error: Merging is not possible because a bisect is in progress.")
+				cli.Println("hint: use 'dolt bisect reset' to end the bisect first")
+				return 1
 			}
 
 			if verr == nil {
-				verr = mergeBranch(ctx, dEnv, dref)
+				verr = mergeBranch(ctx, dEnv, dref, opts)
 			}
 		}
 	}
 
-	return handleCommitErr(ctx, dEnv, verr, usage)
+	return HandleVErrAndExitCode(verr, usage)
 }
 
 func abortMerge(ctx context.Context, doltEnv *env.DoltEnv) errhand.VerboseError {
@@ -158,7 +220,7 @@ func abortMerge(ctx context.Context, doltEnv *env.DoltEnv) errhand.VerboseError
 	return errhand.BuildDError("fatal: failed to revert changes").AddCause(err).Build()
 }
 
-func mergeBranch(ctx context.Context, dEnv *env.DoltEnv, dref ref.DoltRef) errhand.VerboseError {
+func mergeBranch(ctx context.Context, dEnv *env.DoltEnv, dref ref.DoltRef, opts mergeOpts) errhand.VerboseError {
 	cm1, verr := ResolveCommitWithVErr(dEnv, "HEAD", dEnv.RepoState.CWBHeadRef().String())
 
 	if verr != nil {
@@ -205,14 +267,20 @@ func mergeBranch(ctx context.Context, dEnv *env.DoltEnv, dref ref.DoltRef) errha
 		return bldr.Build()
 	}
 
-	if ok, err := cm1.CanFastForwardTo(ctx, cm2); ok {
-		return executeFFMerge(ctx, dEnv, cm2, workingDiffs)
-	} else if err == doltdb.ErrUpToDate || err == doltdb.ErrIsAhead {
+	canFF, err := cm1.CanFastForwardTo(ctx, cm2)
+
+	if err == doltdb.ErrUpToDate || err == doltdb.ErrIsAhead {
 		cli.Println("Already up to date.")
 		return nil
-	} else {
-		return executeMerge(ctx, dEnv, cm1, cm2, dref, workingDiffs)
 	}
+
+	if canFF && !opts.noFF && !opts.squash {
+		return executeFFMerge(ctx, dEnv, cm2, workingDiffs)
+	} else if !canFF && opts.ffOnly {
+		return errhand.BuildDError("fatal: Not possible to fast-forward, aborting.").Build()
+	}
+
+	return executeMerge(ctx, dEnv, cm1, cm2, dref, workingDiffs, opts)
 }
 
 func applyChanges(ctx context.Context, root *doltdb.RootValue, workingDiffs map[string]hash.Hash) (*doltdb.RootValue, errhand.VerboseError) {
@@ -291,8 +359,8 @@ and take the hash for your current branch and use it for the value for "staged"
 	return nil
 }
 
-func executeMerge(ctx context.Context, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commit, dref ref.DoltRef, workingDiffs map[string]hash.Hash) errhand.VerboseError {
-	mergedRoot, tblToStats, err := merge.MergeCommits(ctx, dEnv.DoltDB, cm1, cm2)
+func executeMerge(ctx context.Context, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commit, dref ref.DoltRef, workingDiffs map[string]hash.Hash, opts mergeOpts) errhand.VerboseError {
+	mergedRoot, tblToStats, err := merge.MergeCommits(ctx, dEnv.DoltDB, cm1, cm2, nil)
 
 	if err != nil {
 		switch err {
@@ -314,16 +382,19 @@ func executeMerge(ctx context.Context, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commi
 		}
 	}
 
-	h2, err := cm2.HashOf()
+	// a squash merge doesn't record the merged commit, so the next commit has a single parent
+	if !opts.squash {
+		h2, err := cm2.HashOf()
 
-	if err != nil {
-		return errhand.BuildDError("error: failed to hash commit").AddCause(err).Build()
-	}
+		if err != nil {
+			return errhand.BuildDError("error: failed to hash commit").AddCause(err).Build()
+		}
 
-	err = dEnv.RepoState.StartMerge(dref, h2.String(), dEnv.FS)
+		err = dEnv.RepoState.StartMerge(dref, h2.String(), dEnv.FS)
 
-	if err != nil {
-		return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+		if err != nil {
+			return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+		}
 	}
 
 	unstagedDocs, err := actions.GetUnstagedDocs(ctx, dEnv)
@@ -347,6 +418,10 @@ func executeMerge(ctx context.Context, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commi
 			if verr != nil {
 				// Log a new message here to indicate that merge was successful, only staging failed.
 				cli.Println("Unable to stage changes: add and commit to finish merge")
+			} else if opts.msg != "" {
+				verr = commitMerge(ctx, dEnv, opts.msg)
+			} else if opts.squash {
+				cli.Println("Squash commit -- not updating HEAD")
 			}
 		}
 	}
@@ -354,10 +429,28 @@ func executeMerge(ctx context.Context, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commi
 	return verr
 }
 
+// commitMerge commits the staged result of a merge using the message given, and prints the new commit.
+func commitMerge(ctx context.Context, dEnv *env.DoltEnv, msg string) errhand.VerboseError {
+	err := actions.CommitStaged(ctx, dEnv, msg, time.Now(), false, nil)
+
+	if err != nil {
+		return errhand.BuildDError("error: the merge succeeded, but committing it failed").
+			AddDetails("hint: commit the staged result of the merge using 'dolt commit'").
+			AddCause(err).Build()
+	}
+
+	if exitCode := (LogCmd{}).Exec(ctx, "log", []string{"-n=1"}, dEnv); exitCode != 0 {
+		return errhand.BuildDError("error: failed to print the merge commit").Build()
+	}
+
+	return nil
+}
+
 func printSuccessStats(tblToStats map[string]*merge.MergeStats) bool {
 	printModifications(tblToStats)
 	printAdditions(tblToStats)
 	printDeletions(tblToStats)
+	printAutoResolved(tblToStats)
 	return printConflicts(tblToStats)
 }
 
@@ -377,6 +470,20 @@ func printDeletions(tblToStats map[string]*merge.MergeStats) {
 	}
 }
 
+func printAutoResolved(tblToStats map[string]*merge.MergeStats) {
+	var tbls []string
+	for tblName, stats := range tblToStats {
+		if stats.AutoResolved > 0 {
+			tbls = append(tbls, tblName)
+		}
+	}
+
+	sort.Strings(tbls)
+	for _, tbl := range tbls {
+		cli.Printf("Auto-resolved %d cells in %s using column merge strategies\n", tblToStats[tbl].AutoResolved, tbl)
+	}
+}
+
 func printConflicts(tblToStats map[string]*merge.MergeStats) bool {
 	hasConflicts := false
 	for tblName, stats := range tblToStats {
@@ -384,6 +491,10 @@ func printConflicts(tblToStats map[string]*merge.MergeStats) bool {
 			cli.Println("Auto-merging", tblName)
 			cli.Println("CONFLICT (content): Merge conflict in", tblName)
 
+			hasConflicts = true
+		} else if stats.Operation == merge.TableModified && stats.SchemaConflicts > 0 {
+			cli.Println("CONFLICT (schema): Merge conflict in", tblName)
+
 			hasConflicts = true
 		}
 	}
@@ -399,7 +510,7 @@ func printModifications(tblToStats map[string]*merge.MergeStats) {
 	rowsChanged := 0
 	var tbls []string
 	for tblName, stats := range tblToStats {
-		if stats.Operation == merge.TableModified && stats.Conflicts == 0 {
+		if stats.Operation == merge.TableModified && stats.Conflicts == 0 && stats.SchemaConflicts == 0 {
 			tbls = append(tbls, tblName)
 			nameLen := len(tblName)
 			modCount := stats.Adds + stats.Modifications + stats.Deletes + stats.Conflicts
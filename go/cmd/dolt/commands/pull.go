@@ -20,6 +20,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
 	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
 	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
@@ -31,12 +32,16 @@ var pullDocs = cli.CommandDocumentationContent{
 	LongDesc: `Incorporates changes from a remote repository into the current branch. In its default mode, {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} is shorthand for {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} followed by {{.EmphasisLeft}}dolt merge <remote>/<branch>{{.EmphasisRight}}.
 
 More precisely, dolt pull runs {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} with the given parameters and calls {{.EmphasisLeft}}dolt merge{{.EmphasisRight}} to merge the retrieved branch {{.EmphasisLeft}}HEAD{{.EmphasisRight}} into the current branch.
+
+With {{.EmphasisLeft}}--rebase{{.EmphasisRight}}, {{.EmphasisLeft}}dolt rebase{{.EmphasisRight}} is used instead of {{.EmphasisLeft}}dolt merge{{.EmphasisRight}} to replay the local commits of the current branch on top of the retrieved branch. With {{.EmphasisLeft}}--ff-only{{.EmphasisRight}}, the pull fails unless the current branch can be fast-forwarded to the retrieved branch.
 `,
 	Synopsis: []string{
-		"{{.LessThan}}remote{{.GreaterThan}}",
+		"[--rebase | --ff-only] {{.LessThan}}remote{{.GreaterThan}}",
 	},
 }
 
+const rebaseFlag = "rebase"
+
 type PullCmd struct{}
 
 // Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
@@ -57,6 +62,8 @@ func (cmd PullCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) e
 
 func (cmd PullCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
+	ap.SupportsFlag(rebaseFlag, "", "Rebase the current branch on top of the upstream branch after fetching, instead of merging.")
+	ap.SupportsFlag(ffOnlyParam, "", "Refuse to merge and exit with a non-zero status unless the current branch is already up to date or can be fast-forwarded to the upstream branch.")
 	return ap
 }
 
@@ -76,6 +83,8 @@ func (cmd PullCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	var remoteName string
 	if apr.NArg() > 1 {
 		verr = errhand.BuildDError("").SetPrintUsage().Build()
+	} else if apr.Contains(rebaseFlag) && apr.Contains(ffOnlyParam) {
+		verr = errhand.BuildDError("fatal: You cannot combine --rebase with --ff-only.").Build()
 	} else {
 		if apr.NArg() == 1 {
 			remoteName = apr.Arg(0)
@@ -91,8 +100,14 @@ func (cmd PullCmd) Exec(ctx context.Context, commandStr string, args []string, d
 				remote := dEnv.RepoState.Remotes[refSpecs[0].GetRemote()]
 
 				for _, refSpec := range refSpecs {
-					if remoteTrackRef := refSpec.DestRef(branch); remoteTrackRef != nil {
-						verr = pullRemoteBranch(ctx, dEnv, remote, branch, remoteTrackRef)
+					if _, isTagSpec := refSpec.(ref.TagToTagRefSpec); isTagSpec {
+						verr = fetchRefSpecs(ctx, ref.FastForwardOnly, dEnv, remote, []ref.RemoteRefSpec{refSpec})
+
+						if verr != nil {
+							break
+						}
+					} else if remoteTrackRef := refSpec.DestRef(branch); remoteTrackRef != nil {
+						verr = pullRemoteBranch(ctx, dEnv, remote, branch, remoteTrackRef, apr.Contains(rebaseFlag), mergeOpts{ffOnly: apr.Contains(ffOnlyParam)})
 
 						if verr != nil {
 							break
@@ -106,7 +121,7 @@ func (cmd PullCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	return HandleVErrAndExitCode(verr, usage)
 }
 
-func pullRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, r env.Remote, srcRef, destRef ref.DoltRef) errhand.VerboseError {
+func pullRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, r env.Remote, srcRef, destRef ref.DoltRef, rebase bool, opts mergeOpts) errhand.VerboseError {
 	srcDB, err := r.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
 
 	if err != nil {
@@ -125,5 +140,25 @@ func pullRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, r env.Remote, srcR
 		return errhand.BuildDError("error: fetch failed").AddCause(err).Build()
 	}
 
-	return mergeBranch(ctx, dEnv, destRef)
+	if rebase {
+		return pullWithRebase(ctx, dEnv, srcDBCommit, destRef)
+	}
+
+	return mergeBranch(ctx, dEnv, destRef, opts)
+}
+
+func pullWithRebase(ctx context.Context, dEnv *env.DoltEnv, upstream *doltdb.Commit, upstreamRef ref.DoltRef) errhand.VerboseError {
+	if verr := checkWorkingSetClean(ctx, dEnv, "rebase"); verr != nil {
+		return verr
+	}
+
+	err := rebaseOnto(ctx, dEnv, upstream, upstreamRef.GetPath())
+
+	if verr, ok := err.(errhand.VerboseError); ok {
+		return verr
+	} else if err != nil {
+		return errhand.BuildDError("error: rebase failed").AddCause(err).Build()
+	}
+
+	return nil
 }
@@ -172,6 +172,13 @@ func (cmd PushCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	}
 
 	if verr == nil {
+		refSpec, verr = tagRefSpecForTagName(ctx, dEnv.DoltDB, refSpec)
+	}
+
+	if tagRefSpec, isTagSpec := refSpec.(ref.TagToTagRefSpec); verr == nil && isTagSpec {
+		updateMode := ref.RefUpdateMode{Force: apr.Contains(ForcePushFlag)}
+		verr = pushTagToRemote(ctx, dEnv, updateMode, tagRefSpec, dEnv.DoltDB, remote)
+	} else if verr == nil {
 		hasRef, err := dEnv.DoltDB.HasRef(ctx, currentBranch)
 
 		if err != nil {
@@ -299,6 +306,85 @@ func pushToRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, mode ref.RefUpda
 	return nil
 }
 
+// tagRefSpecForTagName takes a refspec parsed from the command line and, if it names a branch that doesn't exist but a
+// tag with that name does, returns a refspec for pushing the tag instead.
+func tagRefSpecForTagName(ctx context.Context, ddb *doltdb.DoltDB, refSpec ref.RefSpec) (ref.RefSpec, errhand.VerboseError) {
+	if _, ok := refSpec.(ref.BranchToBranchRefSpec); !ok {
+		return refSpec, nil
+	}
+
+	src := refSpec.SrcRef(nil)
+	dest := refSpec.DestRef(src)
+
+	if src == ref.EmptyBranchRef {
+		return refSpec, nil
+	}
+
+	hasBranch, err := ddb.HasRef(ctx, src)
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to read from db").AddCause(err).Build()
+	} else if hasBranch {
+		return refSpec, nil
+	}
+
+	hasTag, err := ddb.HasRef(ctx, ref.NewTagRef(src.GetPath()))
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to read from db").AddCause(err).Build()
+	} else if !hasTag {
+		return refSpec, nil
+	}
+
+	tagRefSpecStr := ref.NewTagRef(src.GetPath()).String() + ":" + ref.NewTagRef(dest.GetPath()).String()
+	tagRefSpec, err := ref.ParseRefSpec(tagRefSpecStr)
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: invalid refspec '%s'", tagRefSpecStr).AddCause(err).Build()
+	}
+
+	return tagRefSpec, nil
+}
+
+func pushTagToRemote(ctx context.Context, dEnv *env.DoltEnv, mode ref.RefUpdateMode, refSpec ref.TagToTagRefSpec, localDB *doltdb.DoltDB, remote env.Remote) errhand.VerboseError {
+	src := refSpec.SrcRef(nil)
+	dest := refSpec.DestRef(src)
+
+	tag, err := localDB.ResolveTag(ctx, src)
+
+	if err == doltdb.ErrTagNotFound {
+		return errhand.BuildDError("error: src refspec %s does not match any.", src.GetPath()).Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: unable to read tag %s", src.GetPath()).AddCause(err).Build()
+	}
+
+	remoteDB, err := remote.GetRemoteDB(ctx, localDB.ValueReadWriter().Format())
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get remote db").AddCause(err).Build()
+	}
+
+	wg, progChan, pullerEventCh := runProgFuncs()
+	err = actions.PushTag(ctx, dEnv, mode, dest.(ref.TagRef), localDB, remoteDB, tag, progChan, pullerEventCh)
+	stopProgFuncs(wg, progChan, pullerEventCh)
+
+	if err != nil {
+		if err == doltdb.ErrUpToDate {
+			cli.Println("Everything up-to-date")
+		} else if err == doltdb.ErrTagExists {
+			cli.Printf("To %s\n", remote.Url)
+			cli.Printf("! [rejected]          %s -> %s (already exists)\n", src.GetPath(), dest.GetPath())
+			cli.Printf("error: failed to push some refs to '%s'\n", remote.Url)
+			cli.Println("hint: Updates were rejected because the tag already exists in the remote.")
+			return errhand.BuildDError("").Build()
+		} else {
+			return errhand.BuildDError("error: push failed").AddCause(err).Build()
+		}
+	}
+
+	return nil
+}
+
 func pullerProgFunc(pullerEventCh chan datas.PullerEvent) {
 	var pos int
 	for evt := range pullerEventCh {
@@ -0,0 +1,401 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/diff"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var rebaseDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Reapply commits on top of another base commit",
+	LongDesc: `Replays the commits made on the current branch since it diverged from {{.LessThan}}upstream{{.GreaterThan}} on top of {{.LessThan}}upstream{{.GreaterThan}}, and moves the current branch to the last replayed commit. Replayed commits keep the message, author, and date of the original commits. Merge commits are not replayed, and commits whose changes are already present upstream are dropped.
+
+Each commit is replayed using a three-way merge where the parent of the commit is the common ancestor. If a merge results in conflicts the rebase stops so that the conflicts can be resolved using {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}. Once they are resolved, add the affected tables and run {{.EmphasisLeft}}dolt rebase --continue{{.EmphasisRight}}. Alternatively, {{.EmphasisLeft}}dolt rebase --abort{{.EmphasisRight}} returns the branch to the commit it pointed at before the rebase began.
+
+The working set must be clean before rebasing.`,
+	Synopsis: []string{
+		"{{.LessThan}}upstream{{.GreaterThan}}",
+		"--continue | --abort",
+	},
+}
+
+const continueParam = "continue"
+
+type RebaseCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RebaseCmd) Name() string {
+	return "rebase"
+}
+
+// Description returns a description of the command
+func (cmd RebaseCmd) Description() string {
+	return "Reapply commits on top of another base commit."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd RebaseCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, rebaseDocs, ap))
+}
+
+func (cmd RebaseCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"upstream", "The branch or commit to replay the commits of the current branch onto."})
+	ap.SupportsFlag(continueParam, "", "Continue the rebase after resolving conflicts and adding the affected tables.")
+	ap.SupportsFlag(abortParam, "", "Abort the rebase and return the current branch to the commit it pointed at before the rebase began.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd RebaseCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, rebaseDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	var err error
+	if apr.ContainsAll(continueParam, abortParam) {
+		err = errhand.BuildDError("error: --%s and --%s are mutually exclusive options.", continueParam, abortParam).Build()
+	} else if apr.Contains(abortParam) || apr.Contains(continueParam) {
+		if apr.NArg() != 0 {
+			usage()
+			return 1
+		}
+
+		if !dEnv.IsRebaseActive() {
+			cli.PrintErrln("fatal: There is no rebase in progress")
+			return 1
+		}
+
+		if apr.Contains(abortParam) {
+			err = abortRebase(ctx, dEnv)
+		} else {
+			err = continueRebase(ctx, dEnv)
+		}
+	} else {
+		if apr.NArg() != 1 {
+			usage()
+			return 1
+		}
+
+		if verr := checkWorkingSetClean(ctx, dEnv, "rebase"); verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		upstream, verr := ResolveCommitWithVErr(dEnv, apr.Arg(0), dEnv.RepoState.CWBHeadRef().String())
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		err = rebaseOnto(ctx, dEnv, upstream, apr.Arg(0))
+	}
+
+	if verr, ok := err.(errhand.VerboseError); ok {
+		return HandleVErrAndExitCode(verr, usage)
+	} else if err != nil {
+		return handleCommitErr(ctx, dEnv, err, usage)
+	}
+
+	return 0
+}
+
+// rebaseOnto starts rebasing the current branch onto the commit upstream.  upstreamName is the name used for upstream
+// in messages.
+func rebaseOnto(ctx context.Context, dEnv *env.DoltEnv, upstream *doltdb.Commit, upstreamName string) error {
+	branchRef := dEnv.RepoState.CWBHeadRef()
+	headCm, verr := ResolveCommitWithVErr(dEnv, "HEAD", branchRef.String())
+
+	if verr != nil {
+		return verr
+	}
+
+	if ok, err := headCm.CanFastForwardTo(ctx, upstream); ok {
+		if verr := resetBranchTo(ctx, dEnv, upstream); verr != nil {
+			return verr
+		}
+
+		cli.Printf("Fast-forwarded %s to %s.\n", branchRef.GetPath(), upstreamName)
+		return nil
+	} else if err == doltdb.ErrUpToDate || err == doltdb.ErrIsAhead {
+		cli.Printf("Current branch %s is up to date.\n", branchRef.GetPath())
+		return nil
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to find the merge base of HEAD and %s", upstreamName).AddCause(err).Build()
+	}
+
+	headHash, err := headCm.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	upstreamHash, err := upstream.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	// the commits on the branch since the merge base, newest first
+	localCommits, err := commitwalk.GetDotDotRevisions(ctx, dEnv.DoltDB, headHash, upstreamHash, -1)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get the commits to replay").AddCause(err).Build()
+	}
+
+	var toReplay []string
+	for i := len(localCommits) - 1; i >= 0; i-- {
+		numParents, err := localCommits[i].NumParents()
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to read the parents of a commit").AddCause(err).Build()
+		} else if numParents > 1 {
+			continue
+		}
+
+		h, err := localCommits[i].HashOf()
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+		}
+
+		toReplay = append(toReplay, h.String())
+	}
+
+	err = dEnv.RepoState.StartRebase(branchRef, headHash.String(), upstreamHash.String(), toReplay, dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+	}
+
+	if verr := resetBranchTo(ctx, dEnv, upstream); verr != nil {
+		return verr
+	}
+
+	return replayRebaseCommits(ctx, dEnv)
+}
+
+// continueRebase commits the resolved changes for the commit the rebase stopped on, and replays the remaining commits.
+func continueRebase(ctx context.Context, dEnv *env.DoltEnv) error {
+	rebaseState := dEnv.RepoState.Rebase
+
+	if !ref.Equals(dEnv.RepoState.CWBHeadRef(), rebaseState.Branch.Ref) {
+		return errhand.BuildDError("error: the branch being rebased, %s, is not checked out.", rebaseState.Branch.Ref.GetPath()).Build()
+	}
+
+	root, verr := GetWorkingWithVErr(dEnv)
+
+	if verr != nil {
+		return verr
+	}
+
+	if has, err := root.HasConflicts(ctx); err != nil {
+		return errhand.BuildDError("error: failed to get conflicts").AddCause(err).Build()
+	} else if has {
+		bdr := errhand.BuildDError("error: you have unmerged tables.")
+		bdr.AddDetails("hint: Fix them up in the working set, and then use 'dolt add <table>'")
+		bdr.AddDetails("hint: as appropriate to mark resolution before continuing.")
+		return bdr.Build()
+	}
+
+	_, notStagedTbls, err := diff.GetTableDiffs(ctx, dEnv)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get table diffs").AddCause(err).Build()
+	}
+
+	if len(notStagedTbls.Tables) > 0 {
+		bdr := errhand.BuildDError("error: you have unstaged changes.")
+		bdr.AddDetails("hint: add the changes to be included in the commit using 'dolt add <table>'")
+		return bdr.Build()
+	}
+
+	if rebaseState.Current != "" {
+		verr := commitRebasedCommit(ctx, dEnv, rebaseState.Current)
+
+		if verr != nil {
+			return verr
+		}
+	}
+
+	return replayRebaseCommits(ctx, dEnv)
+}
+
+// abortRebase returns the branch being rebased to the commit it pointed at before the rebase began.
+func abortRebase(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	rebaseState := dEnv.RepoState.Rebase
+	origHead, verr := ResolveCommitWithVErr(dEnv, rebaseState.OrigHead, rebaseState.Branch.Ref.String())
+
+	if verr != nil {
+		return verr
+	}
+
+	err := dEnv.DoltDB.SetHead(ctx, rebaseState.Branch.Ref, origHead)
+
+	if err != nil {
+		return errhand.BuildDError("fatal: failed to reset %s", rebaseState.Branch.Ref.GetPath()).AddCause(err).Build()
+	}
+
+	if ref.Equals(dEnv.RepoState.CWBHeadRef(), rebaseState.Branch.Ref) {
+		if verr := resetBranchTo(ctx, dEnv, origHead); verr != nil {
+			return verr
+		}
+	}
+
+	err = dEnv.RepoState.ClearRebase(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// replayRebaseCommits replays the remaining commits of the rebase in progress one at a time, stopping if one of them
+// results in conflicts.  Once all the commits have been replayed the rebase state is cleared.
+func replayRebaseCommits(ctx context.Context, dEnv *env.DoltEnv) error {
+	for {
+		cmHashStr, ok, err := dEnv.RepoState.NextRebaseCommit(dEnv.FS)
+
+		if err != nil {
+			return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+		} else if !ok {
+			break
+		}
+
+		cm, verr := ResolveCommitWithVErr(dEnv, cmHashStr, dEnv.RepoState.CWBHeadRef().String())
+
+		if verr != nil {
+			return verr
+		}
+
+		meta, err := cm.GetCommitMeta()
+
+		if err != nil {
+			return errhand.BuildDError("error: failed to get commit metadata").AddCause(err).Build()
+		}
+
+		tblToStats, err := actions.ReplayCommit(ctx, dEnv, cm, false, meta.Description, meta.Time())
+
+		if tblToStats == nil {
+			return errhand.BuildDError("error: could not apply %s", cmHashStr).AddCause(err).Build()
+		}
+
+		if hasConflicts := printConflicts(tblToStats); hasConflicts {
+			cli.Printf("error: could not apply %s... %s\n", cmHashStr, meta.Description)
+			cli.Println("hint: Resolve all conflicts manually, mark them as resolved with")
+			cli.Println("hint: 'dolt add <table>', then run 'dolt rebase --continue'.")
+			cli.Println("hint: To abort and get back to the state before the rebase, run 'dolt rebase --abort'.")
+			return errhand.BuildDError("").Build()
+		}
+
+		if verr := handleRebasedCommitErr(cmHashStr, meta, err); verr != nil {
+			return verr
+		}
+	}
+
+	err := dEnv.RepoState.ClearRebase(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+	}
+
+	cli.Printf("Successfully rebased and updated %s.\n", dEnv.RepoState.CWBHeadRef().String())
+	return nil
+}
+
+// commitRebasedCommit commits the staged changes using the message of the commit being replayed.
+func commitRebasedCommit(ctx context.Context, dEnv *env.DoltEnv, cmHashStr string) errhand.VerboseError {
+	cm, verr := ResolveCommitWithVErr(dEnv, cmHashStr, dEnv.RepoState.CWBHeadRef().String())
+
+	if verr != nil {
+		return verr
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get commit metadata").AddCause(err).Build()
+	}
+
+	err = actions.CommitStaged(ctx, dEnv, meta.Description, meta.Time(), false, nil)
+	return handleRebasedCommitErr(cmHashStr, meta, err)
+}
+
+// handleRebasedCommitErr handles the error from committing a replayed commit.  Commits which no longer change anything
+// are dropped.
+func handleRebasedCommitErr(cmHashStr string, meta *doltdb.CommitMeta, err error) errhand.VerboseError {
+	if actions.IsNothingStaged(err) {
+		cli.Printf("dropping %s %s -- patch contents already upstream\n", cmHashStr, meta.Description)
+		return nil
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to commit %s", cmHashStr).AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// resetBranchTo points the current branch at the commit given and resets the working and staged tables to it.  The
+// working set is expected to be clean apart from changes to docs on the filesystem, which are left in place.
+func resetBranchTo(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit) errhand.VerboseError {
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get root value").AddCause(err).Build()
+	}
+
+	unstagedDocs, err := actions.GetUnstagedDocs(ctx, dEnv)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to determine unstaged docs").AddCause(err).Build()
+	}
+
+	err = dEnv.DoltDB.SetHead(ctx, dEnv.RepoState.CWBHeadRef(), cm)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to update %s", dEnv.RepoState.CWBHeadRef().GetPath()).AddCause(err).Build()
+	}
+
+	verr := UpdateWorkingWithVErr(dEnv, root)
+
+	if verr != nil {
+		return verr
+	}
+
+	verr = UpdateStagedWithVErr(dEnv, root)
+
+	if verr != nil {
+		return verr
+	}
+
+	err = actions.SaveDocsFromWorkingExcludingFSChanges(ctx, dEnv, unstagedDocs)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var reflogDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Show the history of updates to a ref",
+	LongDesc: `Every time a branch head is updated, whether by a commit, merge, reset, fetch, or any other command, the old and new values of the branch are recorded in the reflog. Changes to the working and staged tables are recorded in the {{.EmphasisLeft}}WORKING{{.EmphasisRight}} and {{.EmphasisLeft}}STAGED{{.EmphasisRight}} reflogs. The reflog is stored in the {{.EmphasisLeft}}.dolt{{.EmphasisRight}} directory and is local to the repository.
+
+{{.EmphasisLeft}}dolt reflog{{.EmphasisRight}} lists the entries for {{.LessThan}}ref{{.GreaterThan}} most recent first, or for the current branch if no {{.LessThan}}ref{{.GreaterThan}} is given. The commit a branch pointed at {{.LessThan}}n{{.GreaterThan}} updates ago can be referenced using {{.EmphasisLeft}}<branch>@{<n>}{{.EmphasisRight}} wherever a commit is expected, and {{.EmphasisLeft}}HEAD@{<n>}{{.EmphasisRight}} references entries of the current branch.`,
+	Synopsis: []string{
+		"[{{.LessThan}}ref{{.GreaterThan}}]",
+	},
+}
+
+type ReflogCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd ReflogCmd) Name() string {
+	return "reflog"
+}
+
+// Description returns a description of the command
+func (cmd ReflogCmd) Description() string {
+	return "Show the history of updates to a ref."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd ReflogCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, reflogDocs, ap))
+}
+
+func (cmd ReflogCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"ref", "A branch, a remote tracking branch, HEAD, WORKING, or STAGED."})
+	return ap
+}
+
+// Exec executes the command
+func (cmd ReflogCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, reflogDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() > 1 {
+		usage()
+		return 1
+	}
+
+	refLog := dEnv.DoltDB.RefLog()
+
+	if refLog == nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: no reflog is recorded for this repository").Build(), usage)
+	}
+
+	name, displayName, verr := getRefLogName(ctx, dEnv, apr)
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	entries, err := refLog.Entries(ctx, name)
+
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read the reflog for %s", displayName).AddCause(err).Build(), usage)
+	}
+
+	for i, entry := range entries {
+		cli.Printf("%s %s@{%d}: %s\n", color.YellowString(entry.NewHash), displayName, i, entry.Message)
+	}
+
+	return 0
+}
+
+// getRefLogName returns the name of the reflog referenced by the arguments given, along with the name used when
+// displaying it.
+func getRefLogName(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (string, string, errhand.VerboseError) {
+	if apr.NArg() == 0 || strings.ToLower(apr.Arg(0)) == "head" {
+		cwb := dEnv.RepoState.CWBHeadRef()
+		return cwb.String(), cwb.GetPath(), nil
+	}
+
+	refStr := apr.Arg(0)
+	switch strings.ToUpper(refStr) {
+	case env.WorkingRefLogName, env.StagedRefLogName:
+		return strings.ToUpper(refStr), strings.ToUpper(refStr), nil
+	}
+
+	if ref.IsRef(refStr) {
+		dref, err := ref.Parse(refStr)
+
+		if err != nil {
+			return "", "", errhand.BuildDError("error: '%s' is not a valid ref", refStr).AddCause(err).Build()
+		}
+
+		return dref.String(), dref.GetPath(), nil
+	}
+
+	dref, err := dEnv.FindRef(ctx, refStr)
+
+	if err == doltdb.ErrBranchNotFound && doltdb.IsValidUserBranchName(refStr) {
+		// the branch may have been deleted, in which case its reflog is still available
+		dref, err = ref.NewBranchRef(refStr), nil
+	}
+
+	if err != nil {
+		return "", "", errhand.BuildDError("error: unknown ref '%s'", refStr).AddCause(err).Build()
+	}
+
+	return dref.String(), dref.GetPath(), nil
+}
@@ -28,6 +28,7 @@ import (
 	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/config"
@@ -58,12 +59,16 @@ GCP remote urls should be of the form gs://gcs-bucket/database and will use the
 
 The local filesystem can be used as a remote by providing a repository url in the format file://absolute path. See https://en.wikipedia.org/wiki/File_URI_schemethi
 {{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}, 
-Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking branches and configuration settings for the remote are removed.`,
+Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking branches and configuration settings for the remote are removed.
+
+{{.EmphasisLeft}}prune{{.EmphasisRight}}
+Deletes the remote-tracking branches of the remote named {{.LessThan}}name{{.GreaterThan}} whose branches no longer exist on the remote. Only remote-tracking branches which are updated by the fetch refspecs of the remote are deleted.`,
 
 	Synopsis: []string{
 		"[-v | --verbose]",
 		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
 		"remove {{.LessThan}}name{{.GreaterThan}}",
+		"prune {{.LessThan}}name{{.GreaterThan}}",
 	},
 }
 
@@ -71,6 +76,7 @@ const (
 	addRemoteId         = "add"
 	removeRemoteId      = "remove"
 	removeRemoteShortId = "rm"
+	pruneRemoteId       = "prune"
 )
 
 var awsParams = []string{dbfactory.AWSRegionParam, dbfactory.AWSCredsTypeParam, dbfactory.AWSCredsFileParam, dbfactory.AWSCredsProfile}
@@ -129,6 +135,8 @@ func (cmd RemoteCmd) Exec(ctx context.Context, commandStr string, args []string,
 		verr = removeRemote(ctx, dEnv, apr)
 	case apr.Arg(0) == removeRemoteShortId:
 		verr = removeRemote(ctx, dEnv, apr)
+	case apr.Arg(0) == pruneRemoteId:
+		verr = pruneRemoteCmd(ctx, dEnv, apr)
 	default:
 		verr = errhand.BuildDError("").SetPrintUsage().Build()
 	}
@@ -153,6 +161,10 @@ func removeRemote(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPars
 		return errhand.BuildDError("error: unknown remote " + old).Build()
 	}
 
+	if dEnv.RepoState.LazyRemote == old {
+		return errhand.BuildDError("error: this lazy clone reads its data from '%s'", old).AddDetails("to download all of the data run: dolt fetch --all-chunks").Build()
+	}
+
 	refs, err := dEnv.DoltDB.GetRefsOfType(ctx, map[ref.RefType]struct{}{ref.RemoteRefType: {}})
 
 	if err != nil {
@@ -171,6 +183,13 @@ func removeRemote(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPars
 		}
 	}
 
+	// branches which tracked a branch of the remote no longer have an upstream
+	for branch, config := range dEnv.RepoState.Branches {
+		if config.Remote == old {
+			delete(dEnv.RepoState.Branches, branch)
+		}
+	}
+
 	delete(dEnv.RepoState.Remotes, old)
 	err = dEnv.RepoState.Save(dEnv.FS)
 
@@ -181,6 +200,54 @@ func removeRemote(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPars
 	return nil
 }
 
+func pruneRemoteCmd(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 2 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	name := strings.TrimSpace(apr.Arg(1))
+
+	remotes, err := dEnv.GetRemotes()
+
+	if err != nil {
+		return errhand.BuildDError("error: unable to read remotes").Build()
+	}
+
+	rem, ok := remotes[name]
+
+	if !ok {
+		return errhand.BuildDError("error: unknown remote " + name).Build()
+	}
+
+	return pruneRemote(ctx, dEnv, rem)
+}
+
+// pruneRemote deletes the remote tracking branches of rem whose branches no longer exist on the remote.
+func pruneRemote(ctx context.Context, dEnv *env.DoltEnv, rem env.Remote) errhand.VerboseError {
+	srcDB, err := rem.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get remote db").AddCause(err).Build()
+	}
+
+	pruned, err := actions.PruneRemoteRefs(ctx, dEnv, rem, srcDB)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to prune remote tracking branches").AddCause(err).Build()
+	}
+
+	if len(pruned) > 0 {
+		cli.Println("Pruning", rem.Name)
+		cli.Println("URL:", rem.Url)
+	}
+
+	for _, rr := range pruned {
+		cli.Printf(" * [pruned] %s\n", rr.GetPath())
+	}
+
+	return nil
+}
+
 func getAbsRemoteUrl(fs filesys.Filesys, cfg config.ReadableConfig, urlArg string) (string, string, error) {
 	u, err := earl.Parse(urlArg)
 
@@ -0,0 +1,188 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var revertDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Undo the changes introduced by an existing commit",
+	LongDesc: `Creates a new commit which undoes the changes introduced by {{.LessThan}}commit{{.GreaterThan}}. The commit being reverted is left in the history of the current branch.
+
+The changes are undone using a three-way merge where {{.LessThan}}commit{{.GreaterThan}} is the common ancestor, {{.EmphasisLeft}}HEAD{{.EmphasisRight}} is the current state, and the parent of {{.LessThan}}commit{{.GreaterThan}} is the state being merged in. If the merge results in conflicts, the revert stops so that the conflicts can be resolved using {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}. Once they are resolved, add the affected tables and run {{.EmphasisLeft}}dolt commit{{.EmphasisRight}} to record the revert.
+
+The working set must be clean before reverting. Merge commits cannot be reverted.`,
+	Synopsis: []string{
+		"{{.LessThan}}commit{{.GreaterThan}}",
+		"--abort",
+	},
+}
+
+var revertAbortDetails = `Abort the current conflict resolution process, and reconstruct the state before the revert began.`
+
+type RevertCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RevertCmd) Name() string {
+	return "revert"
+}
+
+// Description returns a description of the command
+func (cmd RevertCmd) Description() string {
+	return "Undo the changes introduced by an existing commit."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd RevertCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, revertDocs, ap))
+}
+
+func (cmd RevertCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "The commit whose changes should be undone."})
+	ap.SupportsFlag(abortParam, "", revertAbortDetails)
+	return ap
+}
+
+// Exec executes the command
+func (cmd RevertCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, revertDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.Contains(abortParam) {
+		if !dEnv.IsRevertActive() {
+			cli.PrintErrln("fatal: There is no revert to abort")
+			return 1
+		}
+
+		return HandleVErrAndExitCode(abortRevert(ctx, dEnv), usage)
+	}
+
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	if verr := checkWorkingSetClean(ctx, dEnv, "revert"); verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	cm, verr := ResolveCommitWithVErr(dEnv, apr.Arg(0), dEnv.RepoState.CWBHeadRef().String())
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	err := revert(ctx, dEnv, cm)
+
+	if verr, ok := err.(errhand.VerboseError); ok {
+		return HandleVErrAndExitCode(verr, usage)
+	} else if err != nil {
+		return handleCommitErr(ctx, dEnv, err, usage)
+	}
+
+	return LogCmd{}.Exec(ctx, "log", []string{"-n=1"}, dEnv)
+}
+
+func abortRevert(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	err := actions.CheckoutAllTables(ctx, dEnv)
+
+	if err == nil {
+		err = dEnv.RepoState.ClearRevert(dEnv.FS)
+
+		if err == nil {
+			return nil
+		}
+	}
+
+	return errhand.BuildDError("fatal: failed to revert changes").AddCause(err).Build()
+}
+
+// revertCommitMessage generates the message used for the commit reverting the commit with the given hash and meta.
+func revertCommitMessage(cmHashStr string, meta *doltdb.CommitMeta) string {
+	return fmt.Sprintf("Revert \"%s\"\n\nThis reverts commit %s.", meta.Description, cmHashStr)
+}
+
+// revert undoes the changes made by cm on top of the current HEAD, and commits the result if there are no conflicts.
+// Errors which occur while committing are returned as is so that they can be handled like errors from dolt commit.
+func revert(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit) error {
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	numParents, err := cm.NumParents()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read the parents of commit %s", h.String()).AddCause(err).Build()
+	} else if numParents == 0 {
+		return errhand.BuildDError("error: commit %s has no parents and cannot be reverted", h.String()).Build()
+	} else if numParents > 1 {
+		return errhand.BuildDError("error: commit %s is a merge and cannot be reverted", h.String()).Build()
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get commit metadata").AddCause(err).Build()
+	}
+
+	err = dEnv.RepoState.StartRevert(h.String(), dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+	}
+
+	msg := revertCommitMessage(h.String(), meta)
+	tblToStats, err := actions.ReplayCommit(ctx, dEnv, cm, true, msg, time.Now())
+
+	if tblToStats == nil {
+		dEnv.RepoState.ClearRevert(dEnv.FS)
+		return errhand.BuildDError("error: could not revert %s", h.String()).AddCause(err).Build()
+	}
+
+	if hasConflicts := printSuccessStats(tblToStats); hasConflicts {
+		cli.Printf("error: could not revert %s... %s\n", h.String(), meta.Description)
+		cli.Println("hint: after resolving the conflicts, mark the corrected tables")
+		cli.Println("hint: with 'dolt add <table>' and run 'dolt commit'")
+		return errhand.BuildDError("").Build()
+	}
+
+	if actions.IsNothingStaged(err) {
+		err = dEnv.RepoState.ClearRevert(dEnv.FS)
+
+		if err != nil {
+			return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+		}
+
+		return errhand.BuildDError("The changes made by commit %s have already been undone.", h.String()).Build()
+	}
+
+	return err
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const statFlag = "stat"
+
+var showDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Show a commit",
+	LongDesc: `Shows the metadata of {{.LessThan}}commit{{.GreaterThan}} followed by the changes it made to the schemas and rows of each table, in the same format as {{.EmphasisLeft}}dolt diff{{.EmphasisRight}}. {{.LessThan}}commit{{.GreaterThan}} defaults to {{.EmphasisLeft}}HEAD{{.EmphasisRight}}.
+
+The changes are shown relative to the first parent of the commit. The parents of merge commits are all listed with the commit metadata.`,
+	Synopsis: []string{
+		"[--stat] [{{.LessThan}}commit{{.GreaterThan}}]",
+	},
+}
+
+type ShowCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd ShowCmd) Name() string {
+	return "show"
+}
+
+// Description returns a description of the command
+func (cmd ShowCmd) Description() string {
+	return "Show a commit."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd ShowCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, showDocs, ap))
+}
+
+func (cmd ShowCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "A commit, branch, or ancestor spec such as HEAD~2."})
+	ap.SupportsFlag(statFlag, "", "Show a summary of the rows changed in each table instead of the full diff.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd ShowCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, showDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() > 1 {
+		usage()
+		return 1
+	}
+
+	cSpecStr := "HEAD"
+	if apr.NArg() == 1 {
+		cSpecStr = apr.Arg(0)
+	}
+
+	cm, verr := ResolveCommitWithVErr(dEnv, cSpecStr, dEnv.RepoState.CWBHeadRef().String())
+
+	if verr == nil {
+		dArgs := &diffArgs{diffParts: SchemaAndDataDiff, diffOutput: TabularDiffOutput}
+		if apr.Contains(statFlag) {
+			dArgs.diffParts = Summary
+		}
+
+		verr = showCommit(ctx, dEnv, cm, dArgs)
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+// showCommit prints the metadata of cm and the diff between it and its first parent.
+func showCommit(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit, dArgs *diffArgs) errhand.VerboseError {
+	lc, err := newLogCommit(ctx, cm)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read commit").AddCause(err).Build()
+	}
+
+	cli.Println(strings.Join(formatCommit(lc, false), "\n"))
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get root value").AddCause(err).Build()
+	}
+
+	parentRoot, err := firstParentRoot(ctx, dEnv.DoltDB, cm)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get the root value of the parent commit").AddCause(err).Build()
+	}
+
+	return diffRoots(ctx, root, parentRoot, nil, nil, dEnv, dArgs)
+}
+
+// firstParentRoot returns the root value of the first parent of cm, or an empty root value if cm has no parents.
+func firstParentRoot(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit) (*doltdb.RootValue, error) {
+	numParents, err := cm.NumParents()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if numParents == 0 {
+		vrw := ddb.ValueReadWriter()
+		ssMap, err := types.NewMap(ctx, vrw)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return doltdb.NewRootValue(ctx, vrw, nil, ssMap)
+	}
+
+	parent, err := ddb.ResolveParent(ctx, cm, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parent.GetRootValue()
+}
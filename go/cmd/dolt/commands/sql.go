@@ -37,6 +37,7 @@ import (
 	eventsapi "github.com/liquidata-inc/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	dsqle "github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
@@ -53,6 +54,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/iohelp"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/osutil"
+	"github.com/liquidata-inc/dolt/go/store/hash"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -285,16 +287,87 @@ func (cmd SqlCmd) Exec(ctx context.Context, commandStr string, args []string, dE
 
 	// If the SQL session wrote a new root value, update the working set with it
 	for name, origRoot := range initialRoots {
+		currEnv := mrEnv[name]
+
+		// a branch checked out with DOLT_CHECKOUT becomes the current branch, and the session's roots belong to it
+		if br, ok := dsess.GetCheckedOutBranch(name); ok && !ref.Equals(br, currEnv.RepoState.CWBHeadRef()) {
+			verr = setCurrentBranch(currEnv, br)
+
+			if verr != nil {
+				break
+			}
+		}
+
+		// a merge started with DOLT_MERGE and not yet committed is finished with dolt commit
+		if cm := dsess.GetMergeCommit(name); cm != nil && !currEnv.IsMergeActive() {
+			verr = startMerge(currEnv, cm)
+
+			if verr != nil {
+				break
+			}
+		}
+
 		root := roots[name]
 		if origRoot != root {
-			currEnv := mrEnv[name]
 			verr = UpdateWorkingWithVErr(currEnv, root)
 		}
+
+		// version control functions may also have staged tables
+		_, val := sqlCtx.Session.Get(name + dsqle.StagedKeySuffix)
+		if stagedHashStr, ok := val.(string); verr == nil && ok && hash.IsValid(stagedHashStr) && stagedHashStr != currEnv.RepoState.Staged {
+			err = currEnv.RepoStateWriter().SetStagedHash(sqlCtx, hash.Parse(stagedHashStr))
+
+			if err != nil {
+				verr = errhand.BuildDError("fatal: failed to update the staged root state").AddCause(err).Build()
+			}
+		}
 	}
 
 	return HandleVErrAndExitCode(verr, usage)
 }
 
+func setCurrentBranch(dEnv *env.DoltEnv, br ref.DoltRef) errhand.VerboseError {
+	unlock, err := dEnv.LockWorktrees()
+
+	if err != nil {
+		return errhand.BuildDError("error: unable to check out '%s'", br.GetPath()).AddCause(err).Build()
+	}
+
+	defer unlock()
+
+	err = dEnv.CheckBranchAvailable(br)
+
+	if err != nil {
+		return errhand.BuildDError("error: unable to check out '%s'", br.GetPath()).AddCause(err).Build()
+	}
+
+	dEnv.RepoState.Head = ref.MarshalableRef{Ref: br}
+	err = dEnv.RepoState.Save(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("fatal: failed to update the current branch").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+func startMerge(dEnv *env.DoltEnv, cm *doltdb.Commit) errhand.VerboseError {
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to hash commit").AddCause(err).Build()
+	}
+
+	// the merged commit is recorded by hash, which resolves regardless of the ref it is resolved against
+	err = dEnv.RepoState.StartMerge(dEnv.RepoState.CWBHeadRef(), h.String(), dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+	}
+
+	return nil
+}
+
 func execShell(sqlCtx *sql.Context, mrEnv env.MultiRepoEnv, roots map[string]*doltdb.RootValue, format resultFormat) (map[string]*doltdb.RootValue, errhand.VerboseError) {
 	dbs := CollectDBs(mrEnv, newDatabase)
 	se, err := newSqlEngine(sqlCtx, mrEnv, roots, format, dbs...)
@@ -0,0 +1,298 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var stashDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Stash the changes in the working set away",
+	LongDesc: `Use {{.EmphasisLeft}}dolt stash{{.EmphasisRight}} to record the current state of the working and staged tables, and go back to a clean working set matching {{.EmphasisLeft}}HEAD{{.EmphasisRight}}. Stash entries are listed most recent first, and are referred to as {{.EmphasisLeft}}stash@{<n>}{{.EmphasisRight}} where {{.EmphasisLeft}}stash@{0}{{.EmphasisRight}} is the most recently created entry.  When a {{.LessThan}}stash{{.GreaterThan}} is not given the most recent entry is used.
+
+{{.EmphasisLeft}}push{{.EmphasisRight}}
+Save the working and staged tables to a new stash entry and reset them to {{.EmphasisLeft}}HEAD{{.EmphasisRight}}. This is the default when no subcommand is given.
+
+{{.EmphasisLeft}}list{{.EmphasisRight}}
+List the stash entries.
+
+{{.EmphasisLeft}}apply{{.EmphasisRight}}
+Apply the changes recorded in {{.LessThan}}stash{{.GreaterThan}} to the working set using a three-way merge, where the commit which was {{.EmphasisLeft}}HEAD{{.EmphasisRight}} when the changes were stashed is the common ancestor. Any conflicts are left in the working set to be resolved with {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}. If the changes apply cleanly, the stashed staged tables are restored as well.
+
+{{.EmphasisLeft}}pop{{.EmphasisRight}}
+Like {{.EmphasisLeft}}apply{{.EmphasisRight}}, but {{.LessThan}}stash{{.GreaterThan}} is dropped once it has been applied without conflicts.
+
+{{.EmphasisLeft}}drop{{.EmphasisRight}}
+Remove {{.LessThan}}stash{{.GreaterThan}} from the list of stash entries.`,
+	Synopsis: []string{
+		"[push] [-m {{.LessThan}}msg{{.GreaterThan}}]",
+		"list",
+		"apply [{{.LessThan}}stash{{.GreaterThan}}]",
+		"pop [{{.LessThan}}stash{{.GreaterThan}}]",
+		"drop [{{.LessThan}}stash{{.GreaterThan}}]",
+	},
+}
+
+const (
+	pushStashId  = "push"
+	listStashId  = "list"
+	applyStashId = "apply"
+	popStashId   = "pop"
+	dropStashId  = "drop"
+)
+
+var stashSpecRegex = regexp.MustCompile(`^(?:stash@\{(\d+)\}|(\d+))$`)
+
+type StashCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd StashCmd) Name() string {
+	return "stash"
+}
+
+// Description returns a description of the command
+func (cmd StashCmd) Description() string {
+	return "Stash the changes in the working set away."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd StashCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, stashDocs, ap))
+}
+
+func (cmd StashCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"stash", "A stash entry in the form stash@{<n>}, or just <n>."})
+	ap.SupportsString(commitMessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} to describe the stash entry.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd StashCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, stashDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	var verr errhand.VerboseError
+
+	switch {
+	case apr.NArg() == 0:
+		verr = stashPush(ctx, dEnv, apr)
+	case apr.Arg(0) == pushStashId:
+		verr = stashPush(ctx, dEnv, apr)
+	case apr.Arg(0) == listStashId:
+		verr = stashList(ctx, dEnv, apr)
+	case apr.Arg(0) == applyStashId:
+		verr = stashApply(ctx, dEnv, apr, false)
+	case apr.Arg(0) == popStashId:
+		verr = stashApply(ctx, dEnv, apr, true)
+	case apr.Arg(0) == dropStashId:
+		verr = stashDrop(ctx, dEnv, apr)
+	default:
+		verr = errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+// checkCanStash returns an error if the working set is in a state where stashes cannot be created or applied.
+func checkCanStash(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	root, verr := GetWorkingWithVErr(dEnv)
+
+	if verr != nil {
+		return verr
+	}
+
+	if has, err := root.HasConflicts(ctx); err != nil {
+		return errhand.BuildDError("error: failed to get conflicts").AddCause(err).Build()
+	} else if has {
+		bdr := errhand.BuildDError("error: you have unmerged tables.")
+		bdr.AddDetails("hint: Fix them up in the working set, and then use 'dolt add <table>'")
+		bdr.AddDetails("hint: as appropriate to mark resolution.")
+		return bdr.Build()
+	} else if dEnv.IsMergeActive() {
+		return errhand.BuildDError("error: a merge is in progress.").Build()
+	} else if dEnv.IsCherryPickActive() {
+		return errhand.BuildDError("error: a cherry-pick is in progress.").Build()
+	} else if dEnv.IsRevertActive() {
+		return errhand.BuildDError("error: a revert is in progress.").Build()
+	} else if dEnv.IsRebaseActive() {
+		return errhand.BuildDError("error: a rebase is in progress.").Build()
+	} else if dEnv.IsBisectActive() {
+		return errhand.BuildDError("error: a bisect is in progress.").Build()
+	}
+
+	return nil
+}
+
+func stashPush(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() > 1 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	if verr := checkCanStash(ctx, dEnv); verr != nil {
+		return verr
+	}
+
+	msg, _ := apr.GetValue(commitMessageArg)
+	stash, err := actions.StashChanges(ctx, dEnv, msg)
+
+	if err == actions.ErrNothingToStash {
+		cli.Println("No local changes to save")
+		return nil
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to stash changes").AddCause(err).Build()
+	}
+
+	cli.Println("Saved working set and staged tables", stash.Meta.Description)
+	return nil
+}
+
+func stashList(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 1 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	stashes, err := dEnv.DoltDB.GetStashes(ctx)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read stash entries").AddCause(err).Build()
+	}
+
+	for i, stash := range stashes {
+		cli.Printf("stash@{%d}: %s\n", i, stash.Meta.Description)
+	}
+
+	return nil
+}
+
+// getStashForArgs returns the index and the stash entry referred to by the argument following the subcommand, or the
+// most recent stash entry if no argument was given.
+func getStashForArgs(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (int, *doltdb.Stash, errhand.VerboseError) {
+	if apr.NArg() > 2 {
+		return 0, nil, errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	idx := 0
+	if apr.NArg() == 2 {
+		matches := stashSpecRegex.FindStringSubmatch(apr.Arg(1))
+
+		if matches == nil {
+			return 0, nil, errhand.BuildDError("error: '%s' is not a valid stash reference", apr.Arg(1)).Build()
+		}
+
+		idxStr := matches[1]
+		if idxStr == "" {
+			idxStr = matches[2]
+		}
+
+		var err error
+		idx, err = strconv.Atoi(idxStr)
+
+		if err != nil {
+			return 0, nil, errhand.BuildDError("error: '%s' is not a valid stash reference", apr.Arg(1)).Build()
+		}
+	}
+
+	stashes, err := dEnv.DoltDB.GetStashes(ctx)
+
+	if err != nil {
+		return 0, nil, errhand.BuildDError("error: failed to read stash entries").AddCause(err).Build()
+	}
+
+	if len(stashes) == 0 {
+		return 0, nil, errhand.BuildDError("No stash entries found.").Build()
+	} else if idx >= len(stashes) {
+		return 0, nil, errhand.BuildDError("error: stash@{%d} does not exist", idx).Build()
+	}
+
+	return idx, stashes[idx], nil
+}
+
+func stashApply(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, drop bool) errhand.VerboseError {
+	idx, stash, verr := getStashForArgs(ctx, dEnv, apr)
+
+	if verr != nil {
+		return verr
+	}
+
+	if verr := checkCanStash(ctx, dEnv); verr != nil {
+		return verr
+	}
+
+	tblToStats, err := actions.ApplyStash(ctx, dEnv, stash)
+
+	if err == actions.ErrStashStagedConflicts {
+		bdr := errhand.BuildDError("error: the staged changes in stash@{%d} conflict with the staged tables", idx)
+		bdr.AddDetails("hint: commit or unstage your staged changes, then apply the stash entry again")
+		return bdr.Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to apply stash@{%d}", idx).AddCause(err).Build()
+	}
+
+	if hasConflicts := printSuccessStats(tblToStats); hasConflicts {
+		cli.Println("Applying the stash entry failed; fix the conflicts and mark them resolved using 'dolt add <table>'.")
+
+		if drop {
+			cli.Println("The stash entry is kept in case you need it again.")
+		}
+
+		return errhand.BuildDError("").Build()
+	}
+
+	if drop {
+		return dropStash(ctx, dEnv, idx, stash)
+	}
+
+	return nil
+}
+
+func stashDrop(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	idx, stash, verr := getStashForArgs(ctx, dEnv, apr)
+
+	if verr != nil {
+		return verr
+	}
+
+	return dropStash(ctx, dEnv, idx, stash)
+}
+
+func dropStash(ctx context.Context, dEnv *env.DoltEnv, idx int, stash *doltdb.Stash) errhand.VerboseError {
+	h, err := stash.Working.HashOf()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of stash entry").AddCause(err).Build()
+	}
+
+	err = dEnv.DoltDB.DeleteStash(ctx, stash.Ref)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to drop stash@{%d}", idx).AddCause(err).Build()
+	}
+
+	cli.Printf("Dropped stash@{%d} (%s)\n", idx, h.String())
+	return nil
+}
@@ -97,7 +97,14 @@ func (cmd StatusCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return 1
 	}
 
-	printStatus(ctx, dEnv, stagedTblDiffs, notStagedTblDiffs, workingTblsInConflict, workingDocsInConflict, stagedDocDiffs, notStagedDocDiffs)
+	upstreamStatus, err := actions.GetUpstreamStatus(ctx, dEnv, dEnv.RepoState.CWBHeadRef())
+
+	if err != nil {
+		cli.PrintErrln(toStatusVErr((err)))
+		return 1
+	}
+
+	printStatus(ctx, dEnv, upstreamStatus, stagedTblDiffs, notStagedTblDiffs, workingTblsInConflict, workingDocsInConflict, stagedDocDiffs, notStagedDocDiffs)
 	return 0
 }
 
@@ -139,6 +146,59 @@ const (
   (use "dolt commit" to conclude merge)
 `
 
+	unmergedCherryPickHeader = `You are currently cherry-picking commit %s.
+  (fix conflicts and run "dolt commit")
+  (use "dolt cherry-pick --abort" to cancel the cherry-pick operation)
+`
+
+	allMergedCherryPickHeader = `You are currently cherry-picking commit %s.
+  (all conflicts fixed: run "dolt commit")
+  (use "dolt cherry-pick --abort" to cancel the cherry-pick operation)
+`
+
+	unmergedRevertHeader = `You are currently reverting commit %s.
+  (fix conflicts and run "dolt commit")
+  (use "dolt revert --abort" to cancel the revert operation)
+`
+
+	allMergedRevertHeader = `You are currently reverting commit %s.
+  (all conflicts fixed: run "dolt commit")
+  (use "dolt revert --abort" to cancel the revert operation)
+`
+
+	unmergedRebaseHeader = `You are currently rebasing branch '%s' on '%s'.
+  (fix conflicts, add the tables and then run "dolt rebase --continue")
+  (use "dolt rebase --abort" to check out the original branch)
+`
+
+	allMergedRebaseHeader = `You are currently rebasing branch '%s' on '%s'.
+  (all conflicts fixed: add the tables and run "dolt rebase --continue")
+  (use "dolt rebase --abort" to check out the original branch)
+`
+
+	bisectHeader = `You are currently bisecting, started from branch '%s'.
+  (use "dolt bisect reset" to get back to the original branch)
+`
+
+	upToDateHeader = `Your branch is up to date with '%s'.
+`
+
+	aheadHeader = `Your branch is ahead of '%s' by %s.
+  (use "dolt push" to publish your local commits)
+`
+
+	behindHeader = `Your branch is behind '%s' by %s, and can be fast-forwarded.
+  (use "dolt pull" to update your local branch)
+`
+
+	divergedHeader = `Your branch and '%s' have diverged,
+and have %d and %d different commits each, respectively.
+  (use "dolt pull" to merge the remote branch into yours)
+`
+
+	upstreamGoneHeader = `Your branch is based on '%s', but the upstream is gone.
+`
+
 	mergedTableHeader = `Unmerged paths:`
 	mergedTableHelp   = `  (use "dolt add <file>..." to mark resolution)`
 
@@ -297,8 +357,40 @@ func getAddedNotStaged(notStagedTbls *diff.TableDiffs, notStagedDocs *diff.DocDi
 	return lines
 }
 
-func printStatus(ctx context.Context, dEnv *env.DoltEnv, stagedTbls, notStagedTbls *diff.TableDiffs, workingTblsInConflict []string, workingDocsInConflict *diff.DocDiffs, stagedDocs, notStagedDocs *diff.DocDiffs) {
+// printUpstreamStatus prints how the current branch compares to its upstream, if it has one.
+func printUpstreamStatus(us *actions.UpstreamStatus) {
+	if us == nil {
+		return
+	}
+
+	upstream := us.Upstream.GetPath()
+	switch {
+	case us.Gone:
+		cli.Printf(upstreamGoneHeader, upstream)
+	case us.Ahead > 0 && us.Behind > 0:
+		cli.Printf(divergedHeader, upstream, us.Ahead, us.Behind)
+	case us.Ahead > 0:
+		cli.Printf(aheadHeader, upstream, pluralizeCommits(us.Ahead))
+	case us.Behind > 0:
+		cli.Printf(behindHeader, upstream, pluralizeCommits(us.Behind))
+	default:
+		cli.Printf(upToDateHeader, upstream)
+	}
+
+	cli.Println()
+}
+
+func pluralizeCommits(n int) string {
+	if n == 1 {
+		return "1 commit"
+	}
+
+	return fmt.Sprintf("%d commits", n)
+}
+
+func printStatus(ctx context.Context, dEnv *env.DoltEnv, upstreamStatus *actions.UpstreamStatus, stagedTbls, notStagedTbls *diff.TableDiffs, workingTblsInConflict []string, workingDocsInConflict *diff.DocDiffs, stagedDocs, notStagedDocs *diff.DocDiffs) {
 	cli.Printf(branchHeader, dEnv.RepoState.CWBHeadRef().GetPath())
+	printUpstreamStatus(upstreamStatus)
 
 	if dEnv.RepoState.Merge != nil {
 		if len(workingTblsInConflict) > 0 {
@@ -306,6 +398,27 @@ func printStatus(ctx context.Context, dEnv *env.DoltEnv, stagedTbls, notStagedTb
 		} else {
 			cli.Println(allMergedHeader)
 		}
+	} else if dEnv.IsCherryPickActive() {
+		if len(workingTblsInConflict) > 0 {
+			cli.Printf(unmergedCherryPickHeader+"\n", dEnv.RepoState.CherryPick.Commit)
+		} else {
+			cli.Printf(allMergedCherryPickHeader+"\n", dEnv.RepoState.CherryPick.Commit)
+		}
+	} else if dEnv.IsRevertActive() {
+		if len(workingTblsInConflict) > 0 {
+			cli.Printf(unmergedRevertHeader+"\n", dEnv.RepoState.Revert.Commit)
+		} else {
+			cli.Printf(allMergedRevertHeader+"\n", dEnv.RepoState.Revert.Commit)
+		}
+	} else if dEnv.IsRebaseActive() {
+		rebaseState := dEnv.RepoState.Rebase
+		if len(workingTblsInConflict) > 0 {
+			cli.Printf(unmergedRebaseHeader+"\n", rebaseState.Branch.Ref.GetPath(), rebaseState.Onto)
+		} else {
+			cli.Printf(allMergedRebaseHeader+"\n", rebaseState.Branch.Ref.GetPath(), rebaseState.Onto)
+		}
+	} else if dEnv.IsBisectActive() {
+		cli.Printf(bisectHeader+"\n", dEnv.RepoState.Bisect.Branch.Ref.GetPath())
 	}
 
 	n := printStagedDiffs(cli.CliOut, stagedTbls, stagedDocs, true)
@@ -0,0 +1,207 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/fatih/color"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/set"
+)
+
+var tagDocs = cli.CommandDocumentationContent{
+	ShortDesc: `List, create, or delete tags`,
+	LongDesc: `If there are no non-option arguments, existing tags are listed.
+
+The command's second form creates a new tag named {{.LessThan}}tagname{{.GreaterThan}} which points to the current {{.EmphasisLeft}}HEAD{{.EmphasisRight}}, or {{.LessThan}}ref{{.GreaterThan}} if given. Unlike a branch, a tag never moves once it has been created. If {{.EmphasisLeft}}-m{{.EmphasisRight}} is given an annotated tag is created which records the tagger, the date, and the message along with the tagged commit.
+
+Tags can be used anywhere a commit is expected, and are transferred by {{.EmphasisLeft}}dolt push{{.EmphasisRight}}, {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}}, {{.EmphasisLeft}}dolt pull{{.EmphasisRight}}, and {{.EmphasisLeft}}dolt clone{{.EmphasisRight}}.
+
+With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}tagname{{.GreaterThan}} will be deleted. You may specify more than one tag for deletion.`,
+	Synopsis: []string{
+		`[-v] [{{.LessThan}}tagname{{.GreaterThan}}...]`,
+		`[-f] [-m {{.LessThan}}msg{{.GreaterThan}}] {{.LessThan}}tagname{{.GreaterThan}} [{{.LessThan}}ref{{.GreaterThan}}]`,
+		`-d {{.LessThan}}tagname{{.GreaterThan}}...`,
+	},
+}
+
+const (
+	tagMessageArg = "message"
+)
+
+type TagCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd TagCmd) Name() string {
+	return "tag"
+}
+
+// Description returns a description of the command
+func (cmd TagCmd) Description() string {
+	return "Create, list, delete tags."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd TagCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, tagDocs, ap))
+}
+
+func (cmd TagCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"ref", "A commit that a new tag should point at."})
+	ap.SupportsString(tagMessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the tag message, creating an annotated tag.")
+	ap.SupportsFlag(forceFlag, "f", "Replace an existing tag with the given name instead of failing.")
+	ap.SupportsFlag(deleteFlag, "d", "Delete a tag.")
+	ap.SupportsFlag(verboseFlag, "v", "When in list mode, show the tagged commit and the tag message for each tag.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd TagCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, tagDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	switch {
+	case apr.Contains(deleteFlag):
+		return deleteTags(ctx, dEnv, apr, usage)
+	case apr.Contains(verboseFlag) || apr.NArg() == 0:
+		return printTags(ctx, dEnv, apr, usage)
+	default:
+		return createTag(ctx, dEnv, apr, usage)
+	}
+}
+
+func printTags(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, _ cli.UsagePrinter) int {
+	tagSet := set.NewStrSet(apr.Args())
+	verbose := apr.Contains(verboseFlag)
+
+	tagRefs, err := dEnv.DoltDB.GetTags(ctx)
+
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read tags from db").AddCause(err).Build(), nil)
+	}
+
+	sort.Slice(tagRefs, func(i, j int) bool {
+		return tagRefs[i].String() < tagRefs[j].String()
+	})
+
+	for _, tagRef := range tagRefs {
+		if tagSet.Size() > 0 && !tagSet.Contains(tagRef.GetPath()) {
+			continue
+		}
+
+		if !verbose {
+			cli.Println(tagRef.GetPath())
+			continue
+		}
+
+		tag, err := dEnv.DoltDB.ResolveTag(ctx, tagRef)
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read tag '%s'", tagRef.GetPath()).AddCause(err).Build(), nil)
+		}
+
+		h, err := tag.Commit.HashOf()
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to hash commit").AddCause(err).Build(), nil)
+		}
+
+		tagLen := len(tagRef.GetPath())
+		fmtStr := fmt.Sprintf("%%s%%%ds\t%%s", 48-tagLen)
+		cli.Println(fmt.Sprintf(fmtStr, tagRef.GetPath(), "", color.YellowString(h.String())))
+
+		if tag.IsAnnotated() {
+			cli.Printf("\tTagger: %s <%s>\n", tag.Meta.Name, tag.Meta.Email)
+			cli.Printf("\tDate:   %s\n", tag.Meta.FormatTS())
+			cli.Printf("\n\t%s\n\n", tag.Meta.Description)
+		}
+	}
+
+	return 0
+}
+
+func createTag(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
+	if apr.NArg() > 2 {
+		usage()
+		return 1
+	}
+
+	tagName := apr.Arg(0)
+	startPt := "head"
+
+	if apr.NArg() == 2 {
+		startPt = apr.Arg(1)
+	}
+
+	msg, _ := apr.GetValue(tagMessageArg)
+	err := actions.CreateTag(ctx, dEnv, tagName, startPt, msg, apr.Contains(forceFlag))
+
+	var verr errhand.VerboseError
+	if err != nil {
+		if err == actions.ErrAlreadyExists {
+			verr = errhand.BuildDError("fatal: tag '%s' already exists", tagName).Build()
+		} else if err == doltdb.ErrInvTagName {
+			verr = errhand.BuildDError("fatal: '%s' is not a valid tag name.", tagName).Build()
+		} else if err == doltdb.ErrInvHash || doltdb.IsNotACommit(err) {
+			verr = errhand.BuildDError("fatal: '%s' is not a valid commit", startPt).Build()
+		} else if err == actions.ErrNameNotConfigured {
+			bdr := errhand.BuildDError("Could not determine %s.", env.UserNameKey)
+			verr = bdr.AddDetails("dolt config [-global|local] -add %[1]s:\"FIRST LAST\"", env.UserNameKey).Build()
+		} else if err == actions.ErrEmailNotConfigured {
+			bdr := errhand.BuildDError("Could not determine %s.", env.UserEmailKey)
+			verr = bdr.AddDetails("dolt config [-global|local] -add %[1]s:\"EMAIL_ADDRESS\"", env.UserEmailKey).Build()
+		} else {
+			verr = errhand.BuildDError("fatal: Unexpected error creating tag '%s'", tagName).AddCause(err).Build()
+		}
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func deleteTags(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	for _, tagName := range apr.Args() {
+		err := actions.DeleteTags(ctx, dEnv, tagName)
+
+		if err == doltdb.ErrTagNotFound {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: tag '%s' not found.", tagName).Build(), usage)
+		} else if err == doltdb.ErrInvTagName {
+			return HandleVErrAndExitCode(errhand.BuildDError("fatal: '%s' is not a valid tag name.", tagName).Build(), usage)
+		} else if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("fatal: Unexpected error deleting tag '%s'", tagName).AddCause(err).Build(), usage)
+		}
+
+		cli.Println(fmt.Sprintf("Deleted tag '%s'", tagName))
+	}
+
+	return 0
+}
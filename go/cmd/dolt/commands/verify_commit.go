@@ -0,0 +1,168 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fatih/color"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/creds"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+const trustedKeysParam = "trusted-keys"
+
+var verifyCommitDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Check the signatures of commits",
+	LongDesc: `Checks that each {{.LessThan}}commit{{.GreaterThan}} was signed using {{.EmphasisLeft}}dolt commit -S{{.EmphasisRight}}, that the signature matches the root value, parents and metadata of the commit, and that the key it was signed with is trusted.
+
+Trusted keys are read from {{.EmphasisLeft}}~/.dolt/trusted_keys{{.EmphasisRight}}, or from the file given with {{.EmphasisLeft}}--trusted-keys{{.EmphasisRight}}. Each line of the file holds a public key, as shown by {{.EmphasisLeft}}dolt creds ls -v{{.EmphasisRight}}, optionally followed by the name of the owner of the key. Empty lines and lines starting with {{.EmphasisLeft}}#{{.EmphasisRight}} are ignored.
+
+The command fails if any of the commits are unsigned, have a bad signature, or were signed with a key which is not trusted.`,
+	Synopsis: []string{
+		"[--trusted-keys {{.LessThan}}file{{.GreaterThan}}] {{.LessThan}}commit{{.GreaterThan}}...",
+	},
+}
+
+type VerifyCommitCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd VerifyCommitCmd) Name() string {
+	return "verify-commit"
+}
+
+// Description returns a description of the command
+func (cmd VerifyCommitCmd) Description() string {
+	return "Check the signatures of commits."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd VerifyCommitCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, verifyCommitDocs, ap))
+}
+
+func (cmd VerifyCommitCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "A commit, branch, or ancestor spec such as HEAD~2."})
+	ap.SupportsString(trustedKeysParam, "", "file", "Read the trusted public keys from {{.LessThan}}file{{.GreaterThan}} instead of ~/.dolt/trusted_keys.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd VerifyCommitCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, verifyCommitDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	tk, verr := loadTrustedKeys(dEnv, apr.GetValueOrDefault(trustedKeysParam, ""))
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	allGood := true
+	for _, cSpecStr := range apr.Args() {
+		cm, verr := ResolveCommitWithVErr(dEnv, cSpecStr, dEnv.RepoState.CWBHeadRef().String())
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to get commit hash").AddCause(err).Build(), usage)
+		}
+
+		status, good, err := signatureStatus(cm, tk)
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read the signature of %s", h.String()).AddCause(err).Build(), usage)
+		}
+
+		cli.Println(fmt.Sprintf("commit %s: %s", h.String(), status))
+		allGood = allGood && good
+	}
+
+	if !allGood {
+		return 1
+	}
+
+	return 0
+}
+
+// loadTrustedKeys reads the trusted keys from path, or from the trusted keys file in the dolt home directory if path is
+// empty.
+func loadTrustedKeys(dEnv *env.DoltEnv, path string) (creds.TrustedKeys, errhand.VerboseError) {
+	if path == "" {
+		var err error
+		path, err = dEnv.TrustedKeysPath()
+
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to find the trusted keys file").AddCause(err).Build()
+		}
+	}
+
+	tk, err := creds.TrustedKeysReadFromFile(dEnv.FS, path)
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to read the trusted keys file '%s'", path).AddCause(err).Build()
+	}
+
+	return tk, nil
+}
+
+// signatureStatus returns a line describing the signature of cm, and whether the signature is valid and was made with
+// one of the trusted keys.
+func signatureStatus(cm *doltdb.Commit, tk creds.TrustedKeys) (string, bool, error) {
+	sig, err := cm.VerifySignature()
+
+	switch err {
+	case nil:
+	case doltdb.ErrUnsignedCommit:
+		return "No signature", false, nil
+	case doltdb.ErrInvalidSignature:
+		if sig == nil {
+			return color.RedString("BAD signature"), false, nil
+		}
+
+		return color.RedString("BAD signature with key %s", sig.KeyID), false, nil
+	default:
+		return "", false, err
+	}
+
+	name, trusted := tk.IsTrusted(sig.PubKey)
+
+	if !trusted {
+		return color.YellowString("Good signature with untrusted key %s", sig.KeyID), false, nil
+	} else if name == "" {
+		return color.GreenString("Good signature with key %s", sig.KeyID), true, nil
+	}
+
+	return color.GreenString(`Good signature from "%s" with key %s`, name, sig.KeyID), true, nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/cli"
+	"github.com/liquidata-inc/dolt/go/cmd/dolt/errhand"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/argparser"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var worktreeDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Manage multiple working trees",
+	LongDesc: `Manage the working trees of the repository. A linked worktree is a directory with its own checked out branch, working tables and staged tables, which reads and writes the database of the repository it was created from. Commits and branches made in any worktree are seen by all the others. A branch can be checked out in only one worktree at a time.
+
+{{.EmphasisLeft}}add{{.EmphasisRight}}
+Create a worktree in {{.LessThan}}dir{{.GreaterThan}} with {{.LessThan}}branch{{.GreaterThan}} checked out. {{.LessThan}}dir{{.GreaterThan}} must not exist or be empty, and {{.LessThan}}branch{{.GreaterThan}} must not be checked out in another worktree. The remotes and branch configuration of the current worktree are copied to the new one.
+
+{{.EmphasisLeft}}list{{.EmphasisRight}}
+List the main worktree followed by each linked worktree, and the branch checked out in it. Worktrees whose directory has been deleted are marked as missing.
+
+{{.EmphasisLeft}}remove{{.EmphasisRight}}
+Delete the linked {{.LessThan}}worktree{{.GreaterThan}}, given by its directory or name. A worktree with uncommitted changes is only removed with {{.EmphasisLeft}}--force{{.EmphasisRight}}.
+
+{{.EmphasisLeft}}prune{{.EmphasisRight}}
+Forget the linked worktrees whose directories have been deleted.`,
+	Synopsis: []string{
+		"add {{.LessThan}}dir{{.GreaterThan}} {{.LessThan}}branch{{.GreaterThan}}",
+		"list",
+		"remove [-f] {{.LessThan}}worktree{{.GreaterThan}}",
+		"prune",
+	},
+}
+
+const (
+	addWorktreeId    = "add"
+	listWorktreeId   = "list"
+	removeWorktreeId = "remove"
+	pruneWorktreeId  = "prune"
+)
+
+type WorktreeCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd WorktreeCmd) Name() string {
+	return "worktree"
+}
+
+// Description returns a description of the command
+func (cmd WorktreeCmd) Description() string {
+	return "Manage multiple working trees."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd WorktreeCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, worktreeDocs, ap))
+}
+
+func (cmd WorktreeCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"dir", "The directory of the new worktree."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"branch", "The branch to check out in the new worktree."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"worktree", "The directory or name of a linked worktree."})
+	ap.SupportsFlag(forceFlag, "f", "Remove a worktree even if it has uncommitted changes.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd WorktreeCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, worktreeDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	var verr errhand.VerboseError
+	switch apr.Arg(0) {
+	case addWorktreeId:
+		if apr.NArg() != 3 {
+			usage()
+			return 1
+		}
+
+		verr = addWorktree(ctx, dEnv, apr.Arg(1), apr.Arg(2))
+	case listWorktreeId:
+		if apr.NArg() != 1 {
+			usage()
+			return 1
+		}
+
+		verr = listWorktrees(dEnv)
+	case removeWorktreeId:
+		if apr.NArg() != 2 {
+			usage()
+			return 1
+		}
+
+		verr = removeWorktree(ctx, dEnv, apr.Arg(1), apr.Contains(forceFlag))
+	case pruneWorktreeId:
+		if apr.NArg() != 1 {
+			usage()
+			return 1
+		}
+
+		verr = pruneWorktrees(dEnv)
+	default:
+		verr = errhand.BuildDError("error: unknown subcommand '%s'", apr.Arg(0)).SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func addWorktree(ctx context.Context, dEnv *env.DoltEnv, dir, branch string) errhand.VerboseError {
+	if !doltdb.IsValidUserBranchName(branch) {
+		return errhand.BuildDError("fatal: '%s' is not a valid branch name.", branch).Build()
+	}
+
+	wt, err := dEnv.AddWorktree(ctx, dir, ref.NewBranchRef(branch))
+
+	if err != nil {
+		if err == doltdb.ErrBranchNotFound {
+			return errhand.BuildDError("fatal: Branch '%s' not found.", branch).Build()
+		} else if env.IsBranchCheckedOut(err) {
+			return errhand.BuildDError("fatal: %s", err.Error()).Build()
+		} else if err == env.ErrWorktreeDirExists {
+			return errhand.BuildDError("fatal: '%s' already exists and is not empty", dir).Build()
+		}
+
+		return errhand.BuildDError("fatal: failed to create the worktree at '%s'", dir).AddCause(err).Build()
+	}
+
+	cli.Printf("Created worktree '%s' at '%s' with branch '%s' checked out\n", wt.Name, wt.Dir, branch)
+	return nil
+}
+
+func listWorktrees(dEnv *env.DoltEnv) errhand.VerboseError {
+	worktrees, err := dEnv.Worktrees()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to read the worktrees").AddCause(err).Build()
+	}
+
+	width := 0
+	for _, wt := range worktrees {
+		if len(wt.Dir) > width {
+			width = len(wt.Dir)
+		}
+	}
+
+	for _, wt := range worktrees {
+		if wt.Missing {
+			cli.Println(fmt.Sprintf("%-*s  (missing)", width, wt.Dir))
+		} else {
+			cli.Println(fmt.Sprintf("%-*s  [%s]", width, wt.Dir, wt.RepoState.CWBHeadRef().GetPath()))
+		}
+	}
+
+	return nil
+}
+
+func removeWorktree(ctx context.Context, dEnv *env.DoltEnv, dirOrName string, force bool) errhand.VerboseError {
+	err := dEnv.RemoveWorktree(ctx, dirOrName, force)
+
+	if err != nil {
+		switch err {
+		case env.ErrWorktreeNotFound:
+			return errhand.BuildDError("fatal: '%s' is not a worktree of this repository", dirOrName).Build()
+		case env.ErrRemoveMainWorktree, env.ErrRemoveCurrentWorktree:
+			return errhand.BuildDError("fatal: %s", err.Error()).Build()
+		case env.ErrWorktreeHasChanges:
+			return errhand.BuildDError("fatal: '%s' has uncommitted changes, use --force to remove it anyway", dirOrName).Build()
+		}
+
+		return errhand.BuildDError("fatal: failed to remove the worktree '%s'", dirOrName).AddCause(err).Build()
+	}
+
+	return nil
+}
+
+func pruneWorktrees(dEnv *env.DoltEnv) errhand.VerboseError {
+	pruned, err := dEnv.PruneWorktrees()
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to prune worktrees").AddCause(err).Build()
+	}
+
+	for _, wt := range pruned {
+		cli.Printf("Removing worktree '%s': '%s' does not exist\n", wt.Name, wt.Dir)
+	}
+
+	return nil
+}
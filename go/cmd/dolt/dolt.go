@@ -56,16 +56,29 @@ var doltCommand = cli.NewSubCommandHandler("dolt", "it's git for data", []cli.Co
 	commands.SqlCmd{VersionStr: Version},
 	sqlserver.SqlServerCmd{VersionStr: Version},
 	commands.LogCmd{},
+	commands.ShowCmd{},
+	commands.VerifyCommitCmd{},
 	commands.DiffCmd{},
 	commands.BlameCmd{},
 	commands.MergeCmd{},
+	commands.CherryPickCmd{},
+	commands.RevertCmd{},
+	commands.StashCmd{},
+	commands.RebaseCmd{},
+	commands.BisectCmd{},
+	commands.ReflogCmd{},
+	commands.GarbageCollectionCmd{},
+	commands.FsckCmd{},
 	commands.BranchCmd{},
+	commands.TagCmd{},
 	commands.CheckoutCmd{},
+	commands.WorktreeCmd{},
 	commands.RemoteCmd{},
 	commands.PushCmd{},
 	commands.PullCmd{},
 	commands.FetchCmd{},
 	commands.CloneCmd{},
+	commands.BundleCmd{},
 	credcmds.Commands,
 	commands.LoginCmd{},
 	commands.VersionCmd{VersionStr: Version},
@@ -209,6 +222,14 @@ func runMain() int {
 
 	res := doltCommand.Exec(ctx, "dolt", args, dEnv)
 
+	// chunks read by a lazy clone are written locally once the command is done, so they are not fetched again by the
+	// next command
+	if dEnv.DoltDB != nil && dEnv.DoltDB.IsLazy() {
+		if err := dEnv.DoltDB.PersistFetchedChunks(ctx); err != nil {
+			cli.PrintErrln(color.YellowString("Failed to save the chunks fetched from the remote. %v", err))
+		}
+	}
+
 	if csMetrics && dEnv.DoltDB != nil {
 		metricsSummary := dEnv.DoltDB.CSMetricsSummary()
 		cli.PrintErrln(metricsSummary)
@@ -237,13 +258,22 @@ func commandNeedsMigrationCheck(args []string) bool {
 		sqlserver.SqlServerCmd{},
 		commands.DiffCmd{},
 		commands.MergeCmd{},
+		commands.CherryPickCmd{},
+		commands.RevertCmd{},
+		commands.StashCmd{},
+		commands.RebaseCmd{},
+		commands.BisectCmd{},
+		commands.GarbageCollectionCmd{},
 		commands.BranchCmd{},
+		commands.TagCmd{},
 		commands.CheckoutCmd{},
+		commands.WorktreeCmd{},
 		commands.RemoteCmd{},
 		commands.PushCmd{},
 		commands.PullCmd{},
 		commands.FetchCmd{},
 		commands.CloneCmd{},
+		commands.BundleCmd{},
 		schcmds.ImportCmd{},
 		tblcmds.ImportCmd{},
 		tblcmds.RmCmd{},
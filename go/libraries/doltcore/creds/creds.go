@@ -117,6 +117,15 @@ func (dc DoltCreds) Sign(data []byte) []byte {
 	return ed25519.Sign(dc.PrivKey, data)
 }
 
+// Verify returns true if sig is a valid signature of data made with the private key matching pubKey.
+func Verify(pubKey, data, sig []byte) bool {
+	if len(pubKey) != pubKeySize {
+		return false
+	}
+
+	return ed25519.Verify(pubKey, data, sig)
+}
+
 func (dc DoltCreds) toBearerToken() (string, error) {
 	b32KIDStr := dc.KeyIDBase32Str()
 	key := jose.SigningKey{Algorithm: jose.EdDSA, Key: ed25519.PrivateKey(dc.PrivKey)}
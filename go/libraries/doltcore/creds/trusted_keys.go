@@ -0,0 +1,73 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creds
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+// TrustedKeys maps the base32 encoded public keys listed in a trusted keys file to the names given to them.
+type TrustedKeys map[string]string
+
+// ParseTrustedKeys parses the contents of a trusted keys file.  Each line of the file holds a base32 encoded public
+// key, optionally followed by a name for the owner of the key.  Empty lines, and lines starting with '#' are ignored.
+func ParseTrustedKeys(data string) (TrustedKeys, error) {
+	tk := make(TrustedKeys)
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		pub, err := B32CredsEncoding.DecodeString(fields[0])
+
+		if err != nil || len(pub) != pubKeySize {
+			return nil, fmt.Errorf("line %d: '%s' is not a valid public key", i+1, fields[0])
+		}
+
+		tk[fields[0]] = strings.Join(fields[1:], " ")
+	}
+
+	return tk, nil
+}
+
+// TrustedKeysReadFromFile reads and parses the trusted keys file at path.  If the file does not exist no keys are
+// trusted.
+func TrustedKeysReadFromFile(fs filesys.Filesys, path string) (TrustedKeys, error) {
+	if exists, isDir := fs.Exists(path); !exists {
+		return TrustedKeys{}, nil
+	} else if isDir {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+
+	data, err := fs.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseTrustedKeys(string(data))
+}
+
+// IsTrusted returns whether pubKey is one of the trusted keys, along with the name given to it.
+func (tk TrustedKeys) IsTrusted(pubKey []byte) (string, bool) {
+	name, ok := tk[B32CredsEncoding.EncodeToString(pubKey)]
+	return name, ok
+}
@@ -0,0 +1,67 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	creds, err := GenerateCredentials()
+	require.NoError(t, err)
+
+	other, err := GenerateCredentials()
+	require.NoError(t, err)
+
+	data := []byte("some data")
+	sig := creds.Sign(data)
+
+	assert.True(t, Verify(creds.PubKey, data, sig))
+	assert.False(t, Verify(creds.PubKey, []byte("other data"), sig))
+	assert.False(t, Verify(other.PubKey, data, sig))
+	assert.False(t, Verify(creds.PubKey[1:], data, sig))
+}
+
+func TestParseTrustedKeys(t *testing.T) {
+	alice, err := GenerateCredentials()
+	require.NoError(t, err)
+
+	bob, err := GenerateCredentials()
+	require.NoError(t, err)
+
+	mallory, err := GenerateCredentials()
+	require.NoError(t, err)
+
+	data := "# trusted keys\n\n" + alice.PubKeyBase32Str() + " Alice Smith\n  " + bob.PubKeyBase32Str() + "  \n"
+	tk, err := ParseTrustedKeys(data)
+	require.NoError(t, err)
+
+	name, ok := tk.IsTrusted(alice.PubKey)
+	assert.True(t, ok)
+	assert.Equal(t, "Alice Smith", name)
+
+	name, ok = tk.IsTrusted(bob.PubKey)
+	assert.True(t, ok)
+	assert.Equal(t, "", name)
+
+	_, ok = tk.IsTrusted(mallory.PubKey)
+	assert.False(t, ok)
+
+	_, err = ParseTrustedKeys(alice.PubKeyBase32Str() + "\nnotakey Bob\n")
+	assert.Error(t, err)
+}
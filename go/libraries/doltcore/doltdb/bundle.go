@@ -0,0 +1,348 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/datas"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/nbs"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// A bundle is a single file holding the chunks needed to bring a repository which has the prerequisite commits up to
+// date with a set of branches.  It starts with a text header:
+//
+//	# dolt bundle v1
+//	-<prerequisite commit hash>
+//	<commit hash> <ref>
+//
+// with one line per prerequisite and per ref, followed by an empty line.  The rest of the file is a sequence of NBS
+// table files, each preceded by a line giving its file id, number of chunks and length in bytes.
+const (
+	bundleSignature = "# dolt bundle v1"
+
+	bundleMemTableSize = 128 * 1024 * 1024
+)
+
+var ErrNotABundle = errors.New("not a dolt bundle")
+var ErrBundleTruncated = errors.New("bundle is truncated")
+
+// BundleRef is a ref stored in a bundle, and the commit it points to.
+type BundleRef struct {
+	Ref  ref.DoltRef
+	Hash hash.Hash
+}
+
+// BundleHeader describes the contents of a bundle.  The chunks reachable from the commits of Refs are in the bundle,
+// except for those which are also reachable from the commits in Prerequisites.
+type BundleHeader struct {
+	Refs          []BundleRef
+	Prerequisites hash.HashSlice
+}
+
+// IsBundle returns whether the data read from rd starts with the signature of a bundle.
+func IsBundle(rd io.Reader) bool {
+	line, err := bufio.NewReader(rd).ReadString('\n')
+
+	return err == nil && strings.TrimSuffix(line, "\n") == bundleSignature
+}
+
+// WriteBundle writes a bundle holding the chunks described by header to wr.  The chunks are first pulled into a
+// temporary table file store in a new directory within tempDir.
+func (ddb *DoltDB) WriteBundle(ctx context.Context, wr io.Writer, tempDir string, header BundleHeader, progChan chan datas.PullProgress) error {
+	dir, err := ioutil.TempDir(tempDir, "bundle")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(dir)
+
+	st, err := nbs.NewLocalStore(ctx, ddb.Format().VersionString(), dir, bundleMemTableSize)
+
+	if err != nil {
+		return err
+	}
+
+	bundleDB := datas.NewDatabase(st)
+	defer bundleDB.Close()
+
+	heads := make(hash.HashSlice, len(header.Refs))
+	for i, br := range header.Refs {
+		heads[i] = br.Hash
+	}
+
+	err = datas.PullExcluding(ctx, ddb.db, bundleDB, heads, header.Prerequisites, progChan)
+
+	if err != nil {
+		return err
+	}
+
+	_, tblFiles, err := st.Sources(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(wr)
+	err = writeBundleHeader(bw, header)
+
+	if err != nil {
+		return err
+	}
+
+	for _, tf := range tblFiles {
+		err = writeBundleTableFile(ctx, bw, filepath.Join(dir, tf.FileID()), tf)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+func writeBundleHeader(bw *bufio.Writer, header BundleHeader) error {
+	lines := []string{bundleSignature}
+	for _, h := range header.Prerequisites {
+		lines = append(lines, "-"+h.String())
+	}
+
+	for _, br := range header.Refs {
+		lines = append(lines, br.Hash.String()+" "+br.Ref.String())
+	}
+
+	_, err := bw.WriteString(strings.Join(lines, "\n") + "\n\n")
+	return err
+}
+
+func writeBundleTableFile(ctx context.Context, bw *bufio.Writer, path string, tf nbs.TableFile) error {
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(bw, "%s %d %d\n", tf.FileID(), tf.NumChunks(), info.Size())
+
+	if err != nil {
+		return err
+	}
+
+	rd, err := tf.Open(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer rd.Close()
+
+	n, err := io.Copy(bw, rd)
+
+	if err != nil {
+		return err
+	} else if n != info.Size() {
+		return fmt.Errorf("table file %s changed while it was being bundled", tf.FileID())
+	}
+
+	return nil
+}
+
+// ReadBundleHeader reads the header of a bundle from br, leaving br positioned at the first table file.
+func ReadBundleHeader(br *bufio.Reader) (BundleHeader, error) {
+	var header BundleHeader
+	line, err := br.ReadString('\n')
+
+	if err != nil || strings.TrimSuffix(line, "\n") != bundleSignature {
+		return header, ErrNotABundle
+	}
+
+	for {
+		line, err = br.ReadString('\n')
+
+		if err == io.EOF {
+			return header, ErrBundleTruncated
+		} else if err != nil {
+			return header, err
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+
+		if line == "" {
+			return header, nil
+		}
+
+		if strings.HasPrefix(line, "-") {
+			h, ok := hash.MaybeParse(line[1:])
+
+			if !ok {
+				return header, fmt.Errorf("invalid prerequisite in bundle header: '%s'", line)
+			}
+
+			header.Prerequisites = append(header.Prerequisites, h)
+			continue
+		}
+
+		tokens := strings.SplitN(line, " ", 2)
+
+		if len(tokens) != 2 {
+			return header, fmt.Errorf("invalid ref in bundle header: '%s'", line)
+		}
+
+		h, ok := hash.MaybeParse(tokens[0])
+
+		if !ok {
+			return header, fmt.Errorf("invalid ref in bundle header: '%s'", line)
+		}
+
+		dref, err := ref.Parse(tokens[1])
+
+		if err != nil {
+			return header, fmt.Errorf("invalid ref in bundle header: '%s'", line)
+		}
+
+		header.Refs = append(header.Refs, BundleRef{dref, h})
+	}
+}
+
+// Bundle is a bundle which has been unpacked into a temporary table file store, so the chunks in it can be pulled
+// into another database.
+type Bundle struct {
+	Header BundleHeader
+	DB     *DoltDB
+
+	dir string
+}
+
+// OpenBundle unpacks the bundle read from rd into a new directory within tempDir.  The bundle must be closed to remove
+// the directory.
+func OpenBundle(ctx context.Context, nbf *types.NomsBinFormat, rd io.Reader, tempDir string) (*Bundle, error) {
+	br := bufio.NewReader(rd)
+	header, err := ReadBundleHeader(br)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := ioutil.TempDir(tempDir, "bundle")
+
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := openBundleTableFiles(ctx, nbf, br, dir)
+
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	bundle.Header = header
+	return bundle, nil
+}
+
+func openBundleTableFiles(ctx context.Context, nbf *types.NomsBinFormat, br *bufio.Reader, dir string) (*Bundle, error) {
+	st, err := nbs.NewLocalStore(ctx, nbf.VersionString(), dir, bundleMemTableSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		line, err := br.ReadString('\n')
+
+		if err == io.EOF && line == "" {
+			break
+		} else if err == io.EOF {
+			return nil, ErrBundleTruncated
+		} else if err != nil {
+			return nil, err
+		}
+
+		tokens := strings.Split(strings.TrimSuffix(line, "\n"), " ")
+
+		if len(tokens) != 3 {
+			return nil, fmt.Errorf("invalid table file in bundle: '%s'", line)
+		}
+
+		// the file id names the table file which is written, so it must not be able to name a path outside of dir
+		if _, ok := hash.MaybeParse(tokens[0]); !ok {
+			return nil, fmt.Errorf("invalid table file in bundle: '%s'", line)
+		}
+
+		numChunks, err := strconv.Atoi(tokens[1])
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid table file in bundle: '%s'", line)
+		}
+
+		length, err := strconv.ParseUint(tokens[2], 10, 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid table file in bundle: '%s'", line)
+		}
+
+		lr := &io.LimitedReader{R: br, N: int64(length)}
+		err = st.WriteTableFile(ctx, tokens[0], numChunks, lr, length, nil)
+
+		if err != nil {
+			return nil, err
+		} else if lr.N != 0 {
+			return nil, ErrBundleTruncated
+		}
+	}
+
+	return &Bundle{DB: DoltDBFromCS(st), dir: dir}, nil
+}
+
+// Close closes the database of the bundle and removes the directory it was unpacked into.
+func (b *Bundle) Close() error {
+	err := b.DB.db.Close()
+	rmErr := os.RemoveAll(b.dir)
+
+	if err == nil {
+		err = rmErr
+	}
+
+	return err
+}
+
+// MissingPrerequisites returns the prerequisite commits of the bundle which are not in ddb.
+func (ddb *DoltDB) MissingPrerequisites(ctx context.Context, header BundleHeader) (hash.HashSlice, error) {
+	var missing hash.HashSlice
+	for _, h := range header.Prerequisites {
+		v, err := ddb.db.ReadValue(ctx, h)
+
+		if err != nil {
+			return nil, err
+		} else if v == nil {
+			missing = append(missing, h)
+		}
+	}
+
+	return missing, nil
+}
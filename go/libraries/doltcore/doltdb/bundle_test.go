@@ -0,0 +1,124 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestBundle(t *testing.T) {
+	ctx := context.Background()
+	tempDir, err := ioutil.TempDir("", "TestBundle")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	master := ref.NewBranchRef("master")
+	cs, _ := NewCommitSpec("HEAD", master.String())
+	first, err := ddb.Resolve(ctx, cs)
+	require.NoError(t, err)
+	firstHash, err := first.HashOf()
+	require.NoError(t, err)
+	root, err := first.GetRootValue()
+	require.NoError(t, err)
+	valHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+
+	meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "second")
+	require.NoError(t, err)
+	second, err := ddb.CommitWithParentSpecs(ctx, valHash, master, nil, meta)
+	require.NoError(t, err)
+	secondHash, err := second.HashOf()
+	require.NoError(t, err)
+
+	header := BundleHeader{
+		Refs:          []BundleRef{{master, secondHash}},
+		Prerequisites: hash.HashSlice{firstHash},
+	}
+
+	buf := &bytes.Buffer{}
+	err = ddb.WriteBundle(ctx, buf, tempDir, header, nil)
+	require.NoError(t, err)
+	bundleBytes := buf.Bytes()
+
+	bundle, err := OpenBundle(ctx, types.Format_7_18, bytes.NewReader(bundleBytes), tempDir)
+	require.NoError(t, err)
+	defer bundle.Close()
+
+	assert.Equal(t, header, bundle.Header)
+
+	cs, _ = NewCommitSpec(secondHash.String(), "")
+	cm, err := bundle.DB.Resolve(ctx, cs)
+	require.NoError(t, err)
+	meta, err = cm.GetCommitMeta()
+	require.NoError(t, err)
+	assert.Equal(t, "second", meta.Description)
+
+	// the prerequisite and the chunks reachable from it are not in the bundle
+	cs, _ = NewCommitSpec(firstHash.String(), "")
+	_, err = bundle.DB.Resolve(ctx, cs)
+	assert.Error(t, err)
+
+	missing, err := ddb.MissingPrerequisites(ctx, bundle.Header)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+
+	emptyDB, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+	missing, err = emptyDB.MissingPrerequisites(ctx, bundle.Header)
+	require.NoError(t, err)
+	assert.Equal(t, hash.HashSlice{firstHash}, missing)
+
+	_, err = OpenBundle(ctx, types.Format_7_18, bytes.NewReader(bundleBytes[:len(bundleBytes)-10]), tempDir)
+	assert.Equal(t, ErrBundleTruncated, err)
+
+	_, err = OpenBundle(ctx, types.Format_7_18, bytes.NewReader([]byte("not a bundle\n")), tempDir)
+	assert.Equal(t, ErrNotABundle, err)
+
+	// a table file id which is not a hash could name a path outside of the directory the bundle is unpacked into
+	br := bufio.NewReader(bytes.NewReader(bundleBytes))
+	_, err = ReadBundleHeader(br)
+	require.NoError(t, err)
+	tableFileLine, err := br.ReadString('\n')
+	require.NoError(t, err)
+	fileID := strings.Split(tableFileLine, " ")[0]
+
+	escaping := bytes.Replace(bundleBytes, []byte(fileID+" "), []byte("../../escaped "), 1)
+	_, err = OpenBundle(ctx, types.Format_7_18, bytes.NewReader(escaping), tempDir)
+	assert.Error(t, err)
+	_, err = os.Stat(filepath.Join(tempDir, "escaped"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(filepath.Dir(tempDir), "escaped"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -36,10 +36,14 @@ var errHasNoRootValue = errors.New("no root value")
 type Commit struct {
 	vrw      types.ValueReadWriter
 	commitSt types.Struct
+
+	// shallow is true for commits on the boundary of a shallow clone, whose parents have not been pulled.  Shallow
+	// commits are treated as if they had no parents.
+	shallow bool
 }
 
 func NewCommit(vrw types.ValueReadWriter, commitSt types.Struct) *Commit {
-	return &Commit{vrw, commitSt}
+	return &Commit{vrw: vrw, commitSt: commitSt}
 }
 
 // HashOf returns the hash of the commit
@@ -98,6 +102,10 @@ func (c *Commit) ParentHashes(ctx context.Context) ([]hash.Hash, error) {
 }
 
 func (c *Commit) getParents() (types.Set, error) {
+	if c.shallow {
+		return types.NewSet(context.Background(), c.vrw)
+	}
+
 	if parVal, found, err := c.commitSt.MaybeGet(parentsField); err != nil {
 		return types.EmptySet, err
 	} else if found && parVal != nil {
@@ -210,7 +218,7 @@ func GetCommitAncestor(ctx context.Context, cm1, cm2 *Commit) (*Commit, error) {
 		return nil, err
 	}
 
-	return &Commit{cm1.vrw, ancestorSt}, nil
+	return &Commit{vrw: cm1.vrw, commitSt: ancestorSt}, nil
 }
 
 func getCommitAncestorRef(ctx context.Context, ref1, ref2 types.Ref, vrw types.ValueReadWriter) (types.Ref, error) {
@@ -145,6 +145,8 @@ func (cmItr *commitItr) Next(ctx context.Context) (hash.Hash, *Commit, error) {
 		return hash.Hash{}, nil, err
 	}
 
+	cmItr.curr = cmItr.ddb.newCommit(cmItr.curr.commitSt)
+
 	return next, cmItr.curr, nil
 }
 
@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/creds"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -44,6 +45,9 @@ type CommitMeta struct {
 	Timestamp     uint64
 	Description   string
 	UserTimestamp int64
+
+	// signer signs the commit written with this metadata.  It is set by SignWith, and is not stored in the metadata.
+	signer *creds.DoltCreds
 }
 
 var uMilliToNano = uint64(time.Millisecond / time.Nanosecond)
@@ -71,7 +75,7 @@ func NewCommitMetaWithUserTS(name, email, desc string, userTS time.Time) (*Commi
 
 	userMS := userTS.UnixNano() / milliToNano
 
-	return &CommitMeta{n, e, ms, d, userMS}, nil
+	return &CommitMeta{n, e, ms, d, userMS, nil}, nil
 }
 
 func getRequiredFromSt(st types.Struct, k string) (types.Value, error) {
@@ -123,6 +127,7 @@ func commitMetaFromNomsSt(st types.Struct) (*CommitMeta, error) {
 		uint64(ts.(types.Uint)),
 		string(d.(types.String)),
 		int64(userTS.(types.Int)),
+		nil,
 	}, nil
 }
 
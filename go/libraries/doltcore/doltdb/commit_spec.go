@@ -17,6 +17,7 @@ package doltdb
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
@@ -30,6 +31,8 @@ func (s stringer) String() string {
 
 var hashRegex = regexp.MustCompile(`^[0-9a-v]{32}$`)
 
+var refLogSuffixRegex = regexp.MustCompile(`^(.+)@\{(\d+)\}$`)
+
 const head string = "head"
 
 // IsValidUserBranchName returns true if name isn't a valid commit hash, it is not named "head" and
@@ -52,11 +55,15 @@ const (
 // CommitSpec handles three different types of string representations of commits.  Commits can either be represented
 // by the hash of the commit, a branch name, or using "head" to represent the latest commit of the current branch.
 // An Ancestor spec can be appended to the end of any of these in order to reach commits that are in the ancestor tree
-// of the referenced commit.
+// of the referenced commit.  A ref may also be followed by @{<n>} in order to reference the commit that the ref pointed
+// at <n> updates ago, as recorded in the reflog.
 type CommitSpec struct {
 	CommitStringer fmt.Stringer
 	CSType         CommitSpecType
 	ASpec          *AncestorSpec
+
+	// RefLogIdx is the index of the reflog entry referenced by the spec, or -1 if the spec references the ref directly.
+	RefLogIdx int
 }
 
 // NewCommitSpec takes a spec string and the current working branch.  The current working branch is only relevant when
@@ -70,12 +77,27 @@ func NewCommitSpec(cSpecStr, cwb string) (*CommitSpec, error) {
 		return nil, err
 	}
 
+	refLogIdx := -1
+	if matches := refLogSuffixRegex.FindStringSubmatch(name); matches != nil {
+		refLogIdx, err = strconv.Atoi(matches[2])
+
+		if err != nil {
+			return nil, ErrInvalidRefLogSpec
+		}
+
+		name = matches[1]
+	}
+
 	if strings.ToLower(name) == head {
 		name = cwb
 	}
 
 	if hashRegex.MatchString(name) {
-		return &CommitSpec{stringer(name), HashCommitSpec, as}, nil
+		if refLogIdx != -1 {
+			return nil, ErrInvalidRefLogSpec
+		}
+
+		return &CommitSpec{stringer(name), HashCommitSpec, as, refLogIdx}, nil
 	} else if ref.IsRef(name) {
 		dref, err := ref.Parse(name)
 
@@ -83,9 +105,9 @@ func NewCommitSpec(cSpecStr, cwb string) (*CommitSpec, error) {
 			return nil, err
 		}
 
-		return &CommitSpec{dref, RefCommitSpec, as}, nil
+		return &CommitSpec{dref, RefCommitSpec, as, refLogIdx}, nil
 	} else if IsValidUserBranchName(name) {
-		return &CommitSpec{ref.NewBranchRef(name), RefCommitSpec, as}, nil
+		return &CommitSpec{ref.NewBranchRef(name), RefCommitSpec, as, refLogIdx}, nil
 	}
 
 	return nil, ErrInvalidBranchOrHash
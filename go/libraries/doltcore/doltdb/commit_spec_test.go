@@ -51,6 +51,9 @@ func TestNewCommitSpec(t *testing.T) {
 		{"head^~2", "master", "refs/heads/master", "^~2", false},
 		{"00000000000000000000000000000000", "", "00000000000000000000000000000000", "", false},
 		{"head", "", "", "", true},
+		{"master@{2}", "", "refs/heads/master", "", false},
+		{"head@{1}^", "refs/heads/master", "refs/heads/master", "^", false},
+		{"00000000000000000000000000000000@{1}", "", "", "", true},
 	}
 
 	for _, test := range tests {
@@ -31,6 +31,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/utils/pantoerr"
 	"github.com/liquidata-inc/dolt/go/store/datas"
 	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/nbs"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -58,14 +59,16 @@ var InMemDoltDB = "mem://"
 // Additionally the noms codebase uses panics in a way that is non idiomatic and I've opted to recover and return
 // errors in many cases.
 type DoltDB struct {
-	db datas.Database
+	db      datas.Database
+	refLog  RefLog
+	shallow hash.HashSet
 }
 
 // DoltDBFromCS creates a DoltDB from a noms chunks.ChunkStore
 func DoltDBFromCS(cs chunks.ChunkStore) *DoltDB {
 	db := datas.NewDatabase(cs)
 
-	return &DoltDB{db}
+	return &DoltDB{db: db}
 }
 
 // LoadDoltDB will acquire a reference to the underlying noms db.  If the Location is InMemDoltDB then a reference
@@ -92,7 +95,7 @@ func LoadDoltDBWithParams(ctx context.Context, nbf *types.NomsBinFormat, urlStr
 		return nil, err
 	}
 
-	return &DoltDB{db}, nil
+	return &DoltDB{db: db}, nil
 }
 
 func (ddb *DoltDB) CSMetricsSummary() string {
@@ -184,7 +187,11 @@ func (ddb *DoltDB) WriteEmptyRepoWithCommitTime(ctx context.Context, name, email
 
 	_, err = ddb.db.SetHead(ctx, ds, headRef)
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return ddb.logRefUpdate(ctx, dref, hash.Hash{}, headRef.TargetHash(), "commit (initial): Initialize data repository")
 }
 
 func getCommitStForRef(ctx context.Context, db datas.Database, dref ref.DoltRef) (types.Struct, error) {
@@ -202,6 +209,38 @@ func getCommitStForRef(ctx context.Context, db datas.Database, dref ref.DoltRef)
 	return types.EmptyStruct(db.Format()), ErrBranchNotFound
 }
 
+// getCommitStForRefOrTag resolves the commit for a ref.  Tags are peeled to the commit they refer to, and branch names
+// which do not exist are resolved as tag names.
+func getCommitStForRefOrTag(ctx context.Context, db datas.Database, dref ref.DoltRef) (types.Struct, error) {
+	commitSt, err := getCommitStForRef(ctx, db, dref)
+
+	switch dref.GetType() {
+	case ref.BranchRefType:
+		if err != ErrBranchNotFound {
+			return commitSt, err
+		}
+
+		tagSt, tagErr := getCommitStForRef(ctx, db, ref.NewTagRef(dref.GetPath()))
+
+		if tagErr != nil {
+			return commitSt, err
+		}
+
+		return peelTag(ctx, db, tagSt)
+
+	case ref.TagRefType:
+		if err == ErrBranchNotFound {
+			return commitSt, ErrTagNotFound
+		} else if err != nil {
+			return commitSt, err
+		}
+
+		return peelTag(ctx, db, commitSt)
+	}
+
+	return commitSt, err
+}
+
 func getCommitStForHash(ctx context.Context, db datas.Database, c string) (types.Struct, error) {
 	prefixed := c
 
@@ -230,14 +269,15 @@ func getCommitStForHash(ctx context.Context, db datas.Database, c string) (types
 	return valSt, nil
 }
 
-func walkAncestorSpec(ctx context.Context, db datas.Database, commitSt types.Struct, aSpec *AncestorSpec) (types.Struct, error) {
+func (ddb *DoltDB) walkAncestorSpec(ctx context.Context, commitSt types.Struct, aSpec *AncestorSpec) (types.Struct, error) {
+	db := ddb.db
 	if aSpec == nil || len(aSpec.Instructions) == 0 {
 		return commitSt, nil
 	}
 
 	instructions := aSpec.Instructions
 	for _, inst := range instructions {
-		cm := Commit{db, commitSt}
+		cm := ddb.newCommit(commitSt)
 
 		numPars, err := cm.NumParents()
 
@@ -274,21 +314,28 @@ func (ddb *DoltDB) Resolve(ctx context.Context, cs *CommitSpec) (*Commit, error)
 	var err error
 	if cs.CSType == HashCommitSpec {
 		commitSt, err = getCommitStForHash(ctx, ddb.db, cs.CommitStringer.String())
+	} else if cs.CSType == RefCommitSpec && cs.RefLogIdx >= 0 {
+		var cm *Commit
+		cm, err = ddb.ResolveRefLogEntry(ctx, cs.CommitStringer.(ref.DoltRef), cs.RefLogIdx)
+
+		if err == nil {
+			commitSt = cm.commitSt
+		}
 	} else if cs.CSType == RefCommitSpec {
-		commitSt, err = getCommitStForRef(ctx, ddb.db, cs.CommitStringer.(ref.DoltRef))
+		commitSt, err = getCommitStForRefOrTag(ctx, ddb.db, cs.CommitStringer.(ref.DoltRef))
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	commitSt, err = walkAncestorSpec(ctx, ddb.db, commitSt, cs.ASpec)
+	commitSt, err = ddb.walkAncestorSpec(ctx, commitSt, cs.ASpec)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &Commit{ddb.db, commitSt}, nil
+	return ddb.newCommit(commitSt), nil
 }
 
 // TODO: convenience method to resolve the head commit of a branch.
@@ -349,6 +396,12 @@ func (ddb *DoltDB) FastForward(ctx context.Context, branch ref.DoltRef, commit *
 		return err
 	}
 
+	oldHash, err := headHashForDataset(ds)
+
+	if err != nil {
+		return err
+	}
+
 	rf, err := types.NewRef(commit.commitSt, ddb.db.Format())
 
 	if err != nil {
@@ -357,7 +410,11 @@ func (ddb *DoltDB) FastForward(ctx context.Context, branch ref.DoltRef, commit *
 
 	_, err = ddb.db.FastForward(ctx, ds, rf)
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return ddb.logRefUpdate(ctx, branch, oldHash, rf.TargetHash(), "fast-forward")
 }
 
 // CanFastForward returns whether the given branch can be fast-forwarded to the commit given.
@@ -384,6 +441,12 @@ func (ddb *DoltDB) SetHead(ctx context.Context, ref ref.DoltRef, cm *Commit) err
 		return err
 	}
 
+	oldHash, err := headHashForDataset(ds)
+
+	if err != nil {
+		return err
+	}
+
 	r, err := types.NewRef(cm.commitSt, ddb.db.Format())
 
 	if err != nil {
@@ -391,7 +454,12 @@ func (ddb *DoltDB) SetHead(ctx context.Context, ref ref.DoltRef, cm *Commit) err
 	}
 
 	_, err = ddb.db.SetHead(ctx, ds, r)
-	return err
+
+	if err != nil {
+		return err
+	}
+
+	return ddb.logRefUpdate(ctx, ref, oldHash, r.TargetHash(), "reset: moving to "+r.TargetHash().String())
 }
 
 // CommitWithParentSpecs commits the value hash given to the branch given, using the list of parent hashes given. Returns an
@@ -455,14 +523,14 @@ func (ddb *DoltDB) WriteCommitDanglingCommit(ctx context.Context, valHash hash.H
 		return nil, err
 	}
 
-	commitOpts := datas.CommitOptions{Parents: parents, Meta: st, Policy: nil}
+	commitOpts := datas.CommitOptions{Parents: parents, Meta: st, Policy: nil, Signer: cm.commitSigner(ddb.db.Format())}
 	commitSt, err = ddb.db.CommitDangling(ctx, val, commitOpts)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &Commit{ddb.db, commitSt}, nil
+	return ddb.newCommit(commitSt), nil
 }
 
 func (ddb *DoltDB) CommitWithParentCommits(ctx context.Context, valHash hash.Hash, dref ref.DoltRef, parentCommits []*Commit, cm *CommitMeta) (*Commit, error) {
@@ -531,7 +599,7 @@ func (ddb *DoltDB) CommitWithParentCommits(ctx context.Context, valHash hash.Has
 		return nil, err
 	}
 
-	commitOpts := datas.CommitOptions{Parents: parents, Meta: st, Policy: nil}
+	commitOpts := datas.CommitOptions{Parents: parents, Meta: st, Policy: nil, Signer: cm.commitSigner(ddb.db.Format())}
 	ds, err = ddb.db.GetDataset(ctx, dref.String())
 
 	if err != nil {
@@ -550,7 +618,29 @@ func (ddb *DoltDB) CommitWithParentCommits(ctx context.Context, valHash hash.Has
 		return nil, errors.New("commit has no head but commit succeeded (How?!?!?)")
 	}
 
-	return &Commit{ddb.db, commitSt}, nil
+	newHash, err := headHashForDataset(ds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var oldHash hash.Hash
+	if hasHead {
+		oldHash = headRef.TargetHash()
+	}
+
+	msg := "commit: " + cm.Description
+	if len(parentCommits) > 0 {
+		msg = "commit (merge): " + cm.Description
+	}
+
+	err = ddb.logRefUpdate(ctx, dref, oldHash, newHash, msg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ddb.newCommit(commitSt), nil
 }
 
 // dangling commits are unreferenced by any branch or ref. They are created in the course of programmatic updates
@@ -603,7 +693,7 @@ func (ddb *DoltDB) CommitDanglingWithParentCommits(ctx context.Context, valHash
 			return err
 		}
 
-		commitOpts := datas.CommitOptions{Parents: parents, Meta: st, Policy: nil}
+		commitOpts := datas.CommitOptions{Parents: parents, Meta: st, Policy: nil, Signer: cm.commitSigner(ddb.db.Format())}
 
 		commitSt, err = ddb.db.CommitDangling(ctx, val, commitOpts)
 
@@ -614,7 +704,7 @@ func (ddb *DoltDB) CommitDanglingWithParentCommits(ctx context.Context, valHash
 		return nil, err
 	}
 
-	return &Commit{ddb.db, commitSt}, nil
+	return ddb.newCommit(commitSt), nil
 }
 
 // ValueReadWriter returns the underlying noms database as a types.ValueReadWriter.
@@ -681,7 +771,7 @@ func (ddb *DoltDB) ResolveParent(ctx context.Context, commit *Commit, parentIdx
 
 	parentCommitSt = parentVal.(types.Struct)
 
-	return &Commit{ddb.ValueReadWriter(), parentCommitSt}, nil
+	return ddb.newCommit(parentCommitSt), nil
 }
 
 func (ddb *DoltDB) ResolveAllParents(ctx context.Context, commit *Commit) ([]*Commit, error) {
@@ -713,7 +803,7 @@ func (ddb *DoltDB) ResolveAllParents(ctx context.Context, commit *Commit) ([]*Co
 
 		parentCommitSt = parentVal.(types.Struct)
 
-		allParents = append(allParents, &Commit{ddb.ValueReadWriter(), parentCommitSt})
+		allParents = append(allParents, ddb.newCommit(parentCommitSt))
 	}
 	return allParents, nil
 }
@@ -782,6 +872,12 @@ func (ddb *DoltDB) NewBranchAtCommit(ctx context.Context, dref ref.DoltRef, comm
 		return err
 	}
 
+	oldHash, err := headHashForDataset(ds)
+
+	if err != nil {
+		return err
+	}
+
 	rf, err := types.NewRef(commit.commitSt, ddb.db.Format())
 
 	if err != nil {
@@ -790,7 +886,11 @@ func (ddb *DoltDB) NewBranchAtCommit(ctx context.Context, dref ref.DoltRef, comm
 
 	_, err = ddb.db.SetHead(ctx, ds, rf)
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return ddb.logRefUpdate(ctx, dref, oldHash, rf.TargetHash(), "branch: Created from "+rf.TargetHash().String())
 }
 
 // DeleteBranch deletes the branch given, returning an error if it doesn't exist.
@@ -805,8 +905,19 @@ func (ddb *DoltDB) DeleteBranch(ctx context.Context, dref ref.DoltRef) error {
 		return ErrBranchNotFound
 	}
 
+	oldHash, err := headHashForDataset(ds)
+
+	if err != nil {
+		return err
+	}
+
 	_, err = ddb.db.Delete(ctx, ds)
-	return err
+
+	if err != nil {
+		return err
+	}
+
+	return ddb.logRefUpdate(ctx, dref, oldHash, hash.Hash{}, "branch: Deleted")
 }
 
 // PushChunks initiates a push into a database from the source database given, at the commit given. Pull progress is
@@ -860,3 +971,11 @@ func (ddb *DoltDB) PullChunks(ctx context.Context, tempDir string, srcDB *DoltDB
 func (ddb *DoltDB) Clone(ctx context.Context, destDB *DoltDB, eventCh chan<- datas.TableFileEvent) error {
 	return datas.Clone(ctx, ddb.db, destDB.db, eventCh)
 }
+
+// CollectGarbage removes all chunks from the database which are not reachable from a ref, or from one of the values
+// or commits whose hashes are given in extraRoots.  Root values which are not part of a commit, such as the working and
+// staged roots, must be included in extraRoots in order to be kept.  If dryRun is true nothing is removed, and the
+// returned stats describe what would have been removed.
+func (ddb *DoltDB) CollectGarbage(ctx context.Context, extraRoots hash.HashSet, dryRun bool) (nbs.GCStats, error) {
+	return datas.GarbageCollect(ctx, ddb.db, extraRoots, dryRun)
+}
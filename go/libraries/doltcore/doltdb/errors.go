@@ -17,19 +17,26 @@ package doltdb
 import "errors"
 
 var ErrInvBranchName = errors.New("not a valid user branch name")
+var ErrInvTagName = errors.New("not a valid tag name")
 var ErrInvTableName = errors.New("not a valid table name")
 var ErrInvHash = errors.New("not a valid hash")
 var ErrInvalidAncestorSpec = errors.New("invalid ancestor spec")
 var ErrInvalidBranchOrHash = errors.New("string is not a valid branch or hash")
 var ErrInvalidHash = errors.New("string is not a valid hash")
+var ErrInvalidRefLogSpec = errors.New("reflog entries can only be referenced for refs")
 
 var ErrFoundHashNotACommit = errors.New("the value retrieved for this hash is not a commit")
 
 var ErrHashNotFound = errors.New("could not find a value for this hash")
 var ErrBranchNotFound = errors.New("branch not found")
+var ErrTagNotFound = errors.New("tag not found")
+var ErrStashNotFound = errors.New("stash entry not found")
+var ErrRefLogNotFound = errors.New("no reflog is recorded for this database")
+var ErrRefLogEntryNotFound = errors.New("reflog entry not found")
 var ErrTableNotFound = errors.New("table not found")
 var ErrTableExists = errors.New("table already exists")
 var ErrAlreadyOnBranch = errors.New("Already on branch")
+var ErrTagExists = errors.New("tag already exists")
 
 var ErrNomsIO = errors.New("error reading from or writing to noms")
 
@@ -40,7 +47,7 @@ var ErrIsBehind = errors.New("cannot reverse from b to a. b is a is behind a alr
 
 func IsInvalidFormatErr(err error) bool {
 	switch err {
-	case ErrInvBranchName, ErrInvTableName, ErrInvHash, ErrInvalidAncestorSpec, ErrInvalidBranchOrHash:
+	case ErrInvBranchName, ErrInvTagName, ErrInvTableName, ErrInvHash, ErrInvalidAncestorSpec, ErrInvalidBranchOrHash, ErrInvalidRefLogSpec:
 		return true
 	default:
 		return false
@@ -49,7 +56,7 @@ func IsInvalidFormatErr(err error) bool {
 
 func IsNotFoundErr(err error) bool {
 	switch err {
-	case ErrHashNotFound, ErrBranchNotFound, ErrTableNotFound:
+	case ErrHashNotFound, ErrBranchNotFound, ErrTagNotFound, ErrTableNotFound, ErrRefLogEntryNotFound:
 		return true
 	default:
 		return false
@@ -58,7 +65,7 @@ func IsNotFoundErr(err error) bool {
 
 func IsNotACommit(err error) bool {
 	switch err {
-	case ErrHashNotFound, ErrBranchNotFound, ErrFoundHashNotACommit:
+	case ErrHashNotFound, ErrBranchNotFound, ErrTagNotFound, ErrFoundHashNotACommit:
 		return true
 	default:
 		return false
@@ -0,0 +1,231 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/datas"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/nbs"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// maxRowProblemsPerTable is the number of invalid rows of a table which are described individually by VerifyTable.
+const maxRowProblemsPerTable = 10
+
+// VerifyTableFiles checks the integrity of the table files the database is stored in.
+func (ddb *DoltDB) VerifyTableFiles(ctx context.Context) (nbs.TableFileReport, error) {
+	return datas.VerifyTableFiles(ctx, ddb.db)
+}
+
+// VerifyReachableChunks checks that every chunk which is reachable from a ref, or from one of the values or commits
+// whose hashes are given in extraRoots, is present and can be decoded.  The parents of the commits on the boundary of
+// a shallow clone are not expected to be present.
+func (ddb *DoltDB) VerifyReachableChunks(ctx context.Context, extraRoots hash.HashSet) (datas.ChunkReport, error) {
+	missingOK := hash.NewHashSet()
+	for h := range ddb.ShallowCommits() {
+		v, err := ddb.db.ReadValue(ctx, h)
+
+		if err != nil {
+			return datas.ChunkReport{}, err
+		}
+
+		st, ok := v.(types.Struct)
+
+		if !ok {
+			continue
+		}
+
+		parents, ok, err := st.MaybeGet(parentsField)
+
+		if err != nil {
+			return datas.ChunkReport{}, err
+		} else if !ok {
+			continue
+		}
+
+		err = parents.(types.Set).IterAll(ctx, func(v types.Value) error {
+			missingOK.Insert(v.(types.Ref).TargetHash())
+			return nil
+		})
+
+		if err != nil {
+			return datas.ChunkReport{}, err
+		}
+	}
+
+	return datas.VerifyReachableChunks(ctx, ddb.db, extraRoots, missingOK)
+}
+
+// VerifyTable checks that every row of tbl matches its schema, and that the data of each of its indexes has exactly one
+// entry for each row.  It returns the number of rows checked and the problems found.  An error is returned if the
+// table can't be read, which includes the panics caused by reading corrupt data.
+func VerifyTable(ctx context.Context, tbl *Table) (rows uint64, problems []error, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to read table: %v", r)
+		}
+	}()
+
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// indexes whose data is missing are reported, and aren't checked any further
+	var indexes []schema.Index
+	var indexData []types.Map
+	for _, index := range sch.Indexes().AllIndexes() {
+		data, err := tbl.GetIndexRowData(ctx, index.Name())
+
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+
+		indexes = append(indexes, index)
+		indexData = append(indexData, data)
+	}
+
+	var invalidRows uint64
+	rowProblem := func(key types.Value, err error) {
+		invalidRows++
+
+		if invalidRows <= maxRowProblemsPerTable {
+			keyStr, encErr := types.EncodedValue(ctx, key)
+
+			if encErr != nil {
+				keyStr = "<unknown>"
+			}
+
+			problems = append(problems, fmt.Errorf("row %s: %v", keyStr, err))
+		}
+	}
+
+	indexed := make([]uint64, len(indexes))
+	err = rowData.IterAll(ctx, func(key, value types.Value) error {
+		rows++
+
+		r, err := verifyRow(sch, key, value)
+
+		if err != nil {
+			rowProblem(key, err)
+			return nil
+		}
+
+		for i, index := range indexes {
+			indexRow, err := r.ReduceToIndex(index)
+
+			if err != nil {
+				return err
+			}
+
+			indexKey, err := indexRow.NomsMapKey(index.Schema()).Value(ctx)
+
+			if err != nil {
+				return err
+			}
+
+			has, err := indexData[i].Has(ctx, indexKey)
+
+			if err != nil {
+				return err
+			}
+
+			if has {
+				indexed[i]++
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if invalidRows > maxRowProblemsPerTable {
+		problems = append(problems, fmt.Errorf("%d more rows are invalid", invalidRows-maxRowProblemsPerTable))
+	}
+
+	validRows := rows - invalidRows
+	for i, index := range indexes {
+		if missing := validRows - indexed[i]; missing > 0 {
+			problems = append(problems, fmt.Errorf("index `%s` is missing the entries of %d rows", index.Name(), missing))
+		}
+
+		// entries which may belong to invalid rows are not counted as extra
+		if stored := indexData[i].Len(); stored > indexed[i]+invalidRows {
+			problems = append(problems, fmt.Errorf("index `%s` has %d entries which don't match a row", index.Name(), stored-indexed[i]-invalidRows))
+		}
+	}
+
+	return rows, problems, nil
+}
+
+// verifyRow checks that the key and value of a row of a table's row data match the schema of the table.
+func verifyRow(sch schema.Schema, key, value types.Value) (row.Row, error) {
+	keyTpl, ok := key.(types.Tuple)
+
+	if !ok {
+		return nil, errors.New("key is not a tuple")
+	}
+
+	valTpl, ok := value.(types.Tuple)
+
+	if !ok {
+		return nil, errors.New("value is not a tuple")
+	}
+
+	r, err := row.FromNoms(sch, keyTpl, valTpl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = sch.GetPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if val, ok := r.GetColVal(tag); !ok || types.IsNull(val) {
+			return true, fmt.Errorf("primary key column `%s` is missing", col.Name)
+		}
+
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	col, err := row.GetInvalidCol(r, sch)
+
+	if err != nil {
+		return nil, err
+	} else if col != nil {
+		return nil, fmt.Errorf("value of column `%s` is invalid", col.Name)
+	}
+
+	return r, nil
+}
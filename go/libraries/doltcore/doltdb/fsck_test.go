@@ -0,0 +1,97 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestVerifyTable(t *testing.T) {
+	ctx := context.Background()
+	db, _ := dbfactory.MemFactory{}.CreateDB(ctx, types.Format_7_18, nil, nil)
+	colColl, _ := schema.NewColCollection(
+		schema.NewColumn("pk1", 1, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("v1", 2, types.IntKind, false),
+	)
+	sch := schema.SchemaFromCols(colColl)
+	_, err := sch.Indexes().AddIndexByColTags("idx_v1", []uint64{2}, false, "")
+	require.NoError(t, err)
+	rowData, _ := createTestRowDataFromTaggedValues(t, db, sch,
+		row.TaggedValues{1: types.Int(1), 2: types.Int(1)},
+		row.TaggedValues{1: types.Int(2), 2: types.Int(2)},
+		row.TaggedValues{1: types.Int(3), 2: types.Int(3)},
+	)
+	schVal, err := encoding.MarshalSchemaAsNomsValue(ctx, db, sch)
+	require.NoError(t, err)
+	tbl, err := NewTable(ctx, db, schVal, rowData, nil)
+	require.NoError(t, err)
+	tbl, err = tbl.RebuildIndexData(ctx)
+	require.NoError(t, err)
+
+	rows, problems, err := VerifyTable(ctx, tbl)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), rows)
+	assert.Empty(t, problems)
+
+	// a row added without updating the index
+	r, err := row.New(types.Format_7_18, sch, row.TaggedValues{1: types.Int(4), 2: types.Int(4)})
+	require.NoError(t, err)
+	updatedRowData, err := rowData.Edit().Set(r.NomsMapKey(sch), r.NomsMapValue(sch)).Map(ctx)
+	require.NoError(t, err)
+	updated, err := tbl.UpdateRows(ctx, updatedRowData)
+	require.NoError(t, err)
+
+	rows, problems, err = VerifyTable(ctx, updated)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), rows)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Error(), "missing the entries of 1 rows")
+
+	// a row removed without updating the index
+	k, err := types.NewTuple(types.Format_7_18, types.Uint(1), types.Int(1))
+	require.NoError(t, err)
+	updatedRowData, err = rowData.Edit().Remove(k).Map(ctx)
+	require.NoError(t, err)
+	updated, err = tbl.UpdateRows(ctx, updatedRowData)
+	require.NoError(t, err)
+
+	_, problems, err = VerifyTable(ctx, updated)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Error(), "1 entries which don't match a row")
+
+	// a row whose value doesn't match the type of its column
+	v, err := types.NewTuple(types.Format_7_18, types.Uint(2), types.String("not an int"))
+	require.NoError(t, err)
+	updatedRowData, err = rowData.Edit().Set(k, v).Map(ctx)
+	require.NoError(t, err)
+	updated, err = tbl.UpdateRows(ctx, updatedRowData)
+	require.NoError(t, err)
+
+	_, problems, err = VerifyTable(ctx, updated)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Error(), "row ")
+}
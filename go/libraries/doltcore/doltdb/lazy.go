@@ -0,0 +1,62 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/store/datas"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// LazyClone sets the root of the empty database to the root of srcDB without pulling any of its chunks.  The database
+// can't be read until SetLazyRemote is called with srcDB.
+func (ddb *DoltDB) LazyClone(ctx context.Context, srcDB *DoltDB) error {
+	return datas.LazyClone(ctx, srcDB.db, ddb.db)
+}
+
+// SetLazyRemote makes chunks which are missing from the database be read from srcDB.  Every chunk read from srcDB is
+// persisted in the database.  It must be called before anything is read from or written to the database.
+func (ddb *DoltDB) SetLazyRemote(srcDB *DoltDB) {
+	ddb.db = datas.NewLazyDatabase(ddb.db, srcDB.db)
+}
+
+// IsLazy returns whether chunks which are missing from the database are read from a remote database.
+func (ddb *DoltDB) IsLazy() bool {
+	return datas.IsLazy(ddb.db)
+}
+
+// LocalOnly returns a DoltDB which reads only the chunks of a lazy database which are stored locally, so that nothing
+// read through it is fetched from the remote.  Values whose chunks have not been fetched read as missing.  If the
+// database is not lazy it is returned as is.
+func (ddb *DoltDB) LocalOnly() *DoltDB {
+	if !ddb.IsLazy() {
+		return ddb
+	}
+
+	return &DoltDB{db: datas.LocalDatabase(ddb.db), refLog: ddb.refLog, shallow: ddb.shallow}
+}
+
+// PersistFetchedChunks writes the chunks which a lazy database has fetched from its remote, and has yet to write, to the
+// local database.  It does nothing if the database is not lazy.
+func (ddb *DoltDB) PersistFetchedChunks(ctx context.Context) error {
+	return datas.PersistFetchedChunks(ctx, ddb.db)
+}
+
+// CompleteLazyClone fetches every chunk which is missing from the lazy database, and is reachable from a ref or from one
+// of the values whose hashes are given in extraRoots.
+func (ddb *DoltDB) CompleteLazyClone(ctx context.Context, extraRoots hash.HashSet) error {
+	return datas.CompleteLazyClone(ctx, ddb.db, extraRoots)
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/datas"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// RefLogEntry is a single entry in the reflog of a ref.  It records the value of the ref before and after an update,
+// who made the update, when, and a message describing it.
+type RefLogEntry struct {
+	OldHash   string `json:"old"`
+	NewHash   string `json:"new"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Timestamp int64  `json:"timestamp"`
+	Message   string `json:"message"`
+}
+
+// Time returns the time at which the entry was recorded.
+func (e RefLogEntry) Time() time.Time {
+	return time.Unix(0, e.Timestamp*int64(time.Millisecond))
+}
+
+// RefLog is an append-only log of the values a ref has pointed at.  Refs are identified by their string form, such as
+// refs/heads/master.
+type RefLog interface {
+	// Append records that the ref with the given name was updated from oldHash to newHash.
+	Append(ctx context.Context, name string, oldHash, newHash hash.Hash, msg string) error
+
+	// Entries returns the entries recorded for the ref with the given name, most recent first.
+	Entries(ctx context.Context, name string) ([]RefLogEntry, error)
+
+	// Names returns the names of all the refs which have entries in the log.
+	Names(ctx context.Context) ([]string, error)
+}
+
+// SetRefLog sets the RefLog that updates to branch and remote refs made through this DoltDB are recorded in.  By default
+// updates are not recorded.
+func (ddb *DoltDB) SetRefLog(refLog RefLog) {
+	ddb.refLog = refLog
+}
+
+// RefLog returns the RefLog updates are recorded in, or nil if updates are not being recorded.
+func (ddb *DoltDB) RefLog() RefLog {
+	return ddb.refLog
+}
+
+// ResolveRefLogEntry returns the commit the given ref pointed at n updates ago.
+func (ddb *DoltDB) ResolveRefLogEntry(ctx context.Context, dref ref.DoltRef, n int) (*Commit, error) {
+	if ddb.refLog == nil {
+		return nil, ErrRefLogNotFound
+	}
+
+	entries, err := ddb.refLog.Entries(ctx, dref.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if n < 0 || n >= len(entries) {
+		return nil, ErrRefLogEntryNotFound
+	}
+
+	h, ok := hash.MaybeParse(entries[n].NewHash)
+
+	// entries recording the deletion of a ref have an empty new hash
+	if !ok || h.IsEmpty() {
+		return nil, ErrRefLogEntryNotFound
+	}
+
+	commitSt, err := getCommitStForHash(ctx, ddb.db, h.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ddb.newCommit(commitSt), nil
+}
+
+func isLoggedRefType(dref ref.DoltRef) bool {
+	t := dref.GetType()
+	return t == ref.BranchRefType || t == ref.RemoteRefType
+}
+
+// headHashForDataset returns the hash of the commit at the head of a dataset, or the empty hash if it has no head.
+func headHashForDataset(ds datas.Dataset) (hash.Hash, error) {
+	headRef, ok, err := ds.MaybeHeadRef()
+
+	if err != nil || !ok {
+		return hash.Hash{}, err
+	}
+
+	return headRef.TargetHash(), nil
+}
+
+// logRefUpdate records an update to a ref in the reflog if one is set and the ref is of a type which is logged.
+func (ddb *DoltDB) logRefUpdate(ctx context.Context, dref ref.DoltRef, oldHash, newHash hash.Hash, msg string) error {
+	if ddb.refLog == nil || !isLoggedRefType(dref) || oldHash == newHash {
+		return nil
+	}
+
+	return ddb.refLog.Append(ctx, dref.String(), oldHash, newHash, msg)
+}
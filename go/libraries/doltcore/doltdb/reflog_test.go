@@ -0,0 +1,130 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+type memRefLog map[string][]RefLogEntry
+
+func (rl memRefLog) Append(ctx context.Context, name string, oldHash, newHash hash.Hash, msg string) error {
+	entry := RefLogEntry{OldHash: oldHash.String(), NewHash: newHash.String(), Message: msg}
+	rl[name] = append([]RefLogEntry{entry}, rl[name]...)
+	return nil
+}
+
+func (rl memRefLog) Entries(ctx context.Context, name string) ([]RefLogEntry, error) {
+	return rl[name], nil
+}
+
+func (rl memRefLog) Names(ctx context.Context) ([]string, error) {
+	var names []string
+	for name := range rl {
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+func TestRefLog(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+
+	refLog := memRefLog{}
+	ddb.SetRefLog(refLog)
+
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	master := ref.NewBranchRef("master")
+	cs, _ := NewCommitSpec("HEAD", master.String())
+	initial, err := ddb.Resolve(ctx, cs)
+	require.NoError(t, err)
+	initialHash, err := initial.HashOf()
+	require.NoError(t, err)
+	root, err := initial.GetRootValue()
+	require.NoError(t, err)
+	valHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+
+	meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "second")
+	require.NoError(t, err)
+	second, err := ddb.CommitWithParentSpecs(ctx, valHash, master, nil, meta)
+	require.NoError(t, err)
+	secondHash, err := second.HashOf()
+	require.NoError(t, err)
+
+	branch := ref.NewBranchRef("branch")
+	err = ddb.NewBranchAtCommit(ctx, branch, initial)
+	require.NoError(t, err)
+	err = ddb.SetHead(ctx, master, initial)
+	require.NoError(t, err)
+	err = ddb.DeleteBranch(ctx, branch)
+	require.NoError(t, err)
+
+	entries := refLog[master.String()]
+	require.Len(t, entries, 3)
+	assert.Equal(t, "reset: moving to "+initialHash.String(), entries[0].Message)
+	assert.Equal(t, secondHash.String(), entries[0].OldHash)
+	assert.Equal(t, "commit: second", entries[1].Message)
+	assert.Equal(t, "commit (initial): Initialize data repository", entries[2].Message)
+
+	entries = refLog[branch.String()]
+	require.Len(t, entries, 2)
+	assert.Equal(t, "branch: Deleted", entries[0].Message)
+	assert.Equal(t, hash.Hash{}.String(), entries[0].NewHash)
+
+	tests := []struct {
+		specStr      string
+		expectedHash hash.Hash
+		expectedErr  error
+	}{
+		{"master@{0}", initialHash, nil},
+		{"HEAD@{1}", secondHash, nil},
+		{"master@{1}~1", initialHash, nil},
+		{"master@{3}", hash.Hash{}, ErrRefLogEntryNotFound},
+		{"branch@{1}", initialHash, nil},
+		{"branch@{0}", hash.Hash{}, ErrRefLogEntryNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.specStr, func(t *testing.T) {
+			cs, err := NewCommitSpec(test.specStr, master.String())
+			require.NoError(t, err)
+
+			cm, err := ddb.Resolve(ctx, cs)
+
+			if test.expectedErr != nil {
+				assert.Equal(t, test.expectedErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			h, err := cm.HashOf()
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedHash, h)
+		})
+	}
+}
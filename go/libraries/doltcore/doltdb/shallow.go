@@ -0,0 +1,62 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/store/datas"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// SetShallowCommits sets the commits on the boundary of a shallow clone.  The parents of these commits are not in the
+// database, so they are treated as if they had no parents.
+func (ddb *DoltDB) SetShallowCommits(shallow hash.HashSet) {
+	ddb.shallow = shallow
+}
+
+// ShallowCommits returns the commits on the boundary of a shallow clone, or an empty set if the database has the
+// complete history of its commits.
+func (ddb *DoltDB) ShallowCommits() hash.HashSet {
+	if ddb.shallow == nil {
+		return hash.HashSet{}
+	}
+
+	return ddb.shallow
+}
+
+// IsShallow returns whether the database is a shallow clone.
+func (ddb *DoltDB) IsShallow() bool {
+	return len(ddb.shallow) > 0
+}
+
+func (ddb *DoltDB) newCommit(commitSt types.Struct) *Commit {
+	cm := &Commit{vrw: ddb.db, commitSt: commitSt}
+
+	if len(ddb.shallow) > 0 {
+		h, err := commitSt.Hash(ddb.db.Format())
+		cm.shallow = err == nil && ddb.shallow.Has(h)
+	}
+
+	return cm
+}
+
+// PullShallow pulls the commits which are fewer than depth parent links away from one of the commits in heads, along
+// with the data they reference, from srcDB.  The hashes of the pulled commits whose parents were not pulled are
+// returned.  Callers are responsible for updating the shallow commits of the database.
+func (ddb *DoltDB) PullShallow(ctx context.Context, srcDB *DoltDB, heads hash.HashSlice, depth int, progChan chan datas.PullProgress) (hash.HashSet, error) {
+	return datas.PullShallow(ctx, srcDB.db, ddb.db, heads, depth, progChan)
+}
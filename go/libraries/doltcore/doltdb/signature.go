@@ -0,0 +1,151 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"errors"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/creds"
+	"github.com/liquidata-inc/dolt/go/store/datas"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const (
+	signatureStructName = "signature"
+	signatureKeyIDKey   = "key_id"
+	signaturePubKeyKey  = "pub_key"
+	signatureSigKey     = "sig"
+)
+
+var ErrUnsignedCommit = errors.New("commit is not signed")
+var ErrInvalidSignature = errors.New("commit signature is not valid")
+
+// CommitSignature is the signature of a commit.  The signature is made with the private key matching PubKey over the
+// hash of the commit without its signature, which covers the root value, the parents and the metadata of the commit.
+// KeyID is derived from PubKey, as the signature struct itself is not covered by the signature.
+type CommitSignature struct {
+	KeyID     string
+	PubKey    []byte
+	Signature []byte
+}
+
+// PubKeyBase32Str returns the base32 encoded public key of the key the commit was signed with.
+func (cs *CommitSignature) PubKeyBase32Str() string {
+	return creds.B32CredsEncoding.EncodeToString(cs.PubKey)
+}
+
+// SignWith causes the commit written with this metadata to be signed with the private key of dc.
+func (cm *CommitMeta) SignWith(dc creds.DoltCreds) {
+	cm.signer = &dc
+}
+
+func (cm *CommitMeta) commitSigner(nbf *types.NomsBinFormat) datas.CommitSigner {
+	if cm.signer == nil {
+		return nil
+	}
+
+	dc := *cm.signer
+	return func(h hash.Hash) (types.Value, error) {
+		sig := dc.Sign(h[:])
+
+		return types.NewStruct(nbf, signatureStructName, types.StructData{
+			signaturePubKeyKey: types.String(dc.PubKeyBase32Str()),
+			signatureSigKey:    types.String(creds.B32CredsEncoding.EncodeToString(sig)),
+		})
+	}
+}
+
+// GetSignature returns the signature of the commit, or ErrUnsignedCommit if the commit is not signed.  The signature is
+// not verified.  ErrInvalidSignature is returned if the signature names a key id which does not belong to its public
+// key.
+func (c *Commit) GetSignature() (*CommitSignature, error) {
+	sigVal, ok, err := c.commitSt.MaybeGet(datas.SignatureField)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrUnsignedCommit
+	}
+
+	sigSt, ok := sigVal.(types.Struct)
+
+	if !ok {
+		return nil, ErrInvalidSignature
+	}
+
+	var strs [2]string
+	for i, k := range []string{signaturePubKeyKey, signatureSigKey} {
+		v, err := getRequiredFromSt(sigSt, k)
+
+		if err != nil {
+			return nil, err
+		}
+
+		str, ok := v.(types.String)
+
+		if !ok {
+			return nil, ErrInvalidSignature
+		}
+
+		strs[i] = string(str)
+	}
+
+	pubKey, err := creds.B32CredsEncoding.DecodeString(strs[0])
+
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	sig, err := creds.B32CredsEncoding.DecodeString(strs[1])
+
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	keyID := creds.PubKeyToKIDStr(pubKey)
+
+	// signatures used to include the key id, which is not trusted unless it matches the public key
+	if v, ok, err := sigSt.MaybeGet(signatureKeyIDKey); err != nil {
+		return nil, err
+	} else if ok && v != types.String(keyID) {
+		return nil, ErrInvalidSignature
+	}
+
+	return &CommitSignature{KeyID: keyID, PubKey: pubKey, Signature: sig}, nil
+}
+
+// VerifySignature checks that the commit was signed with the private key matching the public key in its signature,
+// and returns the signature.  ErrUnsignedCommit is returned if the commit is not signed, and ErrInvalidSignature if the
+// signature does not match the commit.  Whether the key is trusted is up to the caller.
+func (c *Commit) VerifySignature() (*CommitSignature, error) {
+	sig, err := c.GetSignature()
+
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := datas.UnsignedCommitHash(c.commitSt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !creds.Verify(sig.PubKey, h[:], sig.Signature) {
+		return sig, ErrInvalidSignature
+	}
+
+	return sig, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/creds"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/datas"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestSignedCommits(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	cs, _ := NewCommitSpec("HEAD", "master")
+	head, err := ddb.Resolve(ctx, cs)
+	require.NoError(t, err)
+	root, err := head.GetRootValue()
+	require.NoError(t, err)
+	rootHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+
+	_, err = head.VerifySignature()
+	assert.Equal(t, ErrUnsignedCommit, err)
+
+	dc, err := creds.GenerateCredentials()
+	require.NoError(t, err)
+
+	meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "signed")
+	require.NoError(t, err)
+	meta.SignWith(dc)
+
+	signed, err := ddb.CommitWithParentCommits(ctx, rootHash, ref.NewBranchRef("master"), nil, meta)
+	require.NoError(t, err)
+
+	sig, err := signed.VerifySignature()
+	require.NoError(t, err)
+	assert.Equal(t, dc.KeyIDBase32Str(), sig.KeyID)
+	assert.Equal(t, dc.PubKeyBase32Str(), sig.PubKeyBase32Str())
+
+	parents, err := signed.ParentHashes(ctx)
+	require.NoError(t, err)
+	assert.Len(t, parents, 1)
+
+	otherMeta, err := NewCommitMeta("Mallory", "mallory@fake.horse", "signed")
+	require.NoError(t, err)
+	otherMetaSt, err := otherMeta.toNomsStruct(types.Format_7_18)
+	require.NoError(t, err)
+	tamperedSt, err := signed.commitSt.Set(metaField, otherMetaSt)
+	require.NoError(t, err)
+
+	_, err = NewCommit(ddb.ValueReadWriter(), tamperedSt).VerifySignature()
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	// the signature struct is not covered by the signature, so a key id naming someone else's key is rejected
+	other, err := creds.GenerateCredentials()
+	require.NoError(t, err)
+
+	withKeyID := func(keyID string) *Commit {
+		sigVal, ok, err := signed.commitSt.MaybeGet(datas.SignatureField)
+		require.NoError(t, err)
+		require.True(t, ok)
+		sigSt, err := sigVal.(types.Struct).Set(signatureKeyIDKey, types.String(keyID))
+		require.NoError(t, err)
+		st, err := signed.commitSt.Set(datas.SignatureField, sigSt)
+		require.NoError(t, err)
+		return NewCommit(ddb.ValueReadWriter(), st)
+	}
+
+	_, err = withKeyID(other.KeyIDBase32Str()).VerifySignature()
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	sig, err = withKeyID(dc.KeyIDBase32Str()).VerifySignature()
+	require.NoError(t, err)
+	assert.Equal(t, dc.KeyIDBase32Str(), sig.KeyID)
+}
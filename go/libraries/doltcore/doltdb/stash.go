@@ -0,0 +1,164 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+)
+
+// Stash is an entry in the stash of uncommitted changes.  The staged and working roots are stored as a chain of
+// dangling commits: the staged commit has the commit which was HEAD when the changes were stashed as its parent, and
+// the working commit has the staged commit as its parent.  The stash ref points at the working commit.
+type Stash struct {
+	Ref     ref.DoltRef
+	Meta    *CommitMeta
+	Working *Commit
+	Staged  *Commit
+	Base    *Commit
+}
+
+// WorkingRoot returns the stashed working root
+func (s *Stash) WorkingRoot() (*RootValue, error) {
+	return s.Working.GetRootValue()
+}
+
+// StagedRoot returns the stashed staged root
+func (s *Stash) StagedRoot() (*RootValue, error) {
+	return s.Staged.GetRootValue()
+}
+
+// NewStash stores the working and staged roots given as a new stash entry on top of the commit base, and returns it.
+func (ddb *DoltDB) NewStash(ctx context.Context, base *Commit, working, staged *RootValue, meta *CommitMeta) (*Stash, error) {
+	stashRefs, err := ddb.GetRefsOfType(ctx, stashRefFilter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nextID := 0
+	for _, stashRef := range stashRefs {
+		if id, err := strconv.Atoi(stashRef.GetPath()); err == nil && id >= nextID {
+			nextID = id + 1
+		}
+	}
+
+	stagedHash, err := ddb.WriteRootValue(ctx, staged)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stagedCm, err := ddb.CommitDanglingWithParentCommits(ctx, stagedHash, []*Commit{base}, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	workingHash, err := ddb.WriteRootValue(ctx, working)
+
+	if err != nil {
+		return nil, err
+	}
+
+	workingCm, err := ddb.CommitDanglingWithParentCommits(ctx, workingHash, []*Commit{stagedCm}, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stashRef := ref.NewStashRef(strconv.Itoa(nextID))
+	err = ddb.SetHead(ctx, stashRef, workingCm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stash{Ref: stashRef, Meta: meta, Working: workingCm, Staged: stagedCm, Base: base}, nil
+}
+
+// ResolveStash takes a stash ref and returns the Stash it refers to.
+func (ddb *DoltDB) ResolveStash(ctx context.Context, stashRef ref.DoltRef) (*Stash, error) {
+	workingSt, err := getCommitStForRef(ctx, ddb.db, stashRef)
+
+	if err == ErrBranchNotFound {
+		return nil, ErrStashNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	working := ddb.newCommit(workingSt)
+	meta, err := working.GetCommitMeta()
+
+	if err != nil {
+		return nil, err
+	}
+
+	staged, err := ddb.ResolveParent(ctx, working, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := ddb.ResolveParent(ctx, staged, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stash{Ref: stashRef, Meta: meta, Working: working, Staged: staged, Base: base}, nil
+}
+
+var stashRefFilter = map[ref.RefType]struct{}{ref.StashRefType: {}}
+
+// GetStashes returns all the entries in the stash, with the most recent entry first.
+func (ddb *DoltDB) GetStashes(ctx context.Context) ([]*Stash, error) {
+	stashRefs, err := ddb.GetRefsOfType(ctx, stashRefFilter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(stashRefs, func(i, j int) bool {
+		idI, _ := strconv.Atoi(stashRefs[i].GetPath())
+		idJ, _ := strconv.Atoi(stashRefs[j].GetPath())
+		return idI > idJ
+	})
+
+	stashes := make([]*Stash, len(stashRefs))
+	for i, stashRef := range stashRefs {
+		stashes[i], err = ddb.ResolveStash(ctx, stashRef)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stashes, nil
+}
+
+// DeleteStash deletes the stash entry given, returning ErrStashNotFound if it doesn't exist.
+func (ddb *DoltDB) DeleteStash(ctx context.Context, stashRef ref.DoltRef) error {
+	err := ddb.DeleteBranch(ctx, stashRef)
+
+	if err == ErrBranchNotFound {
+		return ErrStashNotFound
+	}
+
+	return err
+}
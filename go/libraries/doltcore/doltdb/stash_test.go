@@ -0,0 +1,89 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestStashes(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	cs, _ := NewCommitSpec("HEAD", "master")
+	head, err := ddb.Resolve(ctx, cs)
+	require.NoError(t, err)
+	headHash, err := head.HashOf()
+	require.NoError(t, err)
+	root, err := head.GetRootValue()
+	require.NoError(t, err)
+
+	for _, desc := range []string{"first", "second", "third"} {
+		meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", desc)
+		require.NoError(t, err)
+		_, err = ddb.NewStash(ctx, head, root, root, meta)
+		require.NoError(t, err)
+	}
+
+	stashes, err := ddb.GetStashes(ctx)
+	require.NoError(t, err)
+	require.Len(t, stashes, 3)
+	assert.Equal(t, "third", stashes[0].Meta.Description)
+	assert.Equal(t, "second", stashes[1].Meta.Description)
+	assert.Equal(t, "first", stashes[2].Meta.Description)
+
+	baseHash, err := stashes[0].Base.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, headHash, baseHash)
+
+	stagedHash, err := stashes[0].Staged.HashOf()
+	require.NoError(t, err)
+	assert.NotEqual(t, headHash, stagedHash)
+
+	err = ddb.DeleteStash(ctx, stashes[1].Ref)
+	require.NoError(t, err)
+	err = ddb.DeleteStash(ctx, stashes[1].Ref)
+	assert.Equal(t, ErrStashNotFound, err)
+
+	_, err = ddb.ResolveStash(ctx, ref.NewStashRef("100"))
+	assert.Equal(t, ErrStashNotFound, err)
+
+	meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "fourth")
+	require.NoError(t, err)
+	fourth, err := ddb.NewStash(ctx, head, root, root, meta)
+	require.NoError(t, err)
+	assert.Equal(t, "3", fourth.Ref.GetPath())
+
+	stashes, err = ddb.GetStashes(ctx)
+	require.NoError(t, err)
+	require.Len(t, stashes, 3)
+	assert.Equal(t, "fourth", stashes[0].Meta.Description)
+	assert.Equal(t, "first", stashes[2].Meta.Description)
+
+	branches, err := ddb.GetBranches(ctx)
+	require.NoError(t, err)
+	assert.Len(t, branches, 1)
+}
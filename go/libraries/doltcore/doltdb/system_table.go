@@ -100,6 +100,14 @@ func GetGeneratedSystemTables(ctx context.Context, root *RootValue) ([]string, e
 	for _, pre := range generatedSystemTablePrefixes {
 		s.Add(funcitr.MapStrings(tn, func(s string) string { return pre + s })...)
 	}
+
+	// conflicts tables are only generated for tables which have conflicts
+	cnfTbls, err := root.TablesInConflict(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.Add(funcitr.MapStrings(cnfTbls, func(s string) string { return DoltConflictsTablePrefix + s })...)
+
 	return s.AsSlice(), nil
 }
 
@@ -122,17 +130,21 @@ func GetAllTableNames(ctx context.Context, root *RootValue) ([]string, error) {
 var writeableSystemTables = []string{
 	DoltQueryCatalogTableName,
 	SchemasTableName,
+	CommitChecksTableName,
 }
 
 var persistedSystemTables = []string{
 	DocTableName,
 	DoltQueryCatalogTableName,
 	SchemasTableName,
+	CommitChecksTableName,
 }
 
 var generatedSystemTables = []string{
 	BranchesTableName,
 	LogTableName,
+	ConflictsTableName,
+	StatusTableName,
 }
 
 var generatedSystemTablePrefixes = []string{
@@ -204,6 +216,24 @@ const (
 	DoltSchemasFragmentTag
 )
 
+const (
+	// CommitChecksTableName is the name of the table of queries which are run before each commit. A commit fails if
+	// any of the queries return rows.
+	CommitChecksTableName = "dolt_commit_checks"
+
+	// CommitChecksNameCol is the name of the primary key column of the commit checks table
+	CommitChecksNameCol = "name"
+
+	// CommitChecksQueryCol is the name of the column containing the query of a commit check
+	CommitChecksQueryCol = "query"
+)
+const (
+	// Tags for dolt_commit_checks table
+	// for info on unaligned constant: https://github.com/liquidata-inc/dolt/pull/663
+	CommitChecksNameTag = iota + SystemTableReservedMin + uint64(5000)
+	CommitChecksQueryTag
+)
+
 const (
 	DoltHistoryTablePrefix = "dolt_history_"
 )
@@ -232,3 +262,16 @@ const (
 	// LogTableName is the system table name
 	LogTableName = "dolt_log"
 )
+
+const (
+	// ConflictsTableName is the name of the system table which lists the tables in conflict
+	ConflictsTableName = "dolt_conflicts"
+
+	// DoltConflictsTablePrefix is the name prefix of the system tables which show the conflicts of a table
+	DoltConflictsTablePrefix = "dolt_conflicts_"
+)
+
+const (
+	// StatusTableName is the name of the system table which lists the changed tables
+	StatusTableName = "dolt_status"
+)
@@ -34,6 +34,7 @@ const (
 	tableRowsKey       = "rows"
 	conflictsKey       = "conflicts"
 	conflictSchemasKey = "conflict_schemas"
+	schemaConflictKey  = "schema_conflict"
 	indexesKey         = "indexes"
 
 	// TableNameRegexStr is the regular expression that valid tables must match.
@@ -168,6 +169,7 @@ func (t *Table) GetConflicts(ctx context.Context) (Conflict, types.Map, error) {
 	return schemas, confMap, nil
 }
 
+// HasConflicts returns whether the table has conflicting rows or a schema conflict
 func (t *Table) HasConflicts() (bool, error) {
 	if t == nil {
 		return false, nil
@@ -175,7 +177,11 @@ func (t *Table) HasConflicts() (bool, error) {
 
 	_, ok, err := t.tableStruct.MaybeGet(conflictSchemasKey)
 
-	return ok, err
+	if err != nil || ok {
+		return ok, err
+	}
+
+	return t.HasSchemaConflict()
 }
 
 func (t *Table) NumRowsInConflict(ctx context.Context) (uint64, error) {
@@ -224,6 +230,90 @@ func (t *Table) ClearConflicts() (*Table, error) {
 	return &Table{t.vrw, tSt}, nil
 }
 
+// SetSchemaConflict records that the schema of the table couldn't be merged. The ancestor, our and their versions of
+// the table are kept so that the merge of the table can be finished once the conflict is resolved.
+func (t *Table) SetSchemaConflict(ctx context.Context, anc, ours, theirs *Table) (*Table, error) {
+	var refs []types.Value
+	for _, tbl := range []*Table{anc, ours, theirs} {
+		tblRef, err := writeValAndGetRef(ctx, t.vrw, tbl.tableStruct)
+
+		if err != nil {
+			return nil, err
+		}
+
+		refs = append(refs, tblRef)
+	}
+
+	tpl, err := NewConflict(refs[0], refs[1], refs[2]).ToNomsList(t.vrw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedSt, err := t.tableStruct.Set(schemaConflictKey, tpl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{t.vrw, updatedSt}, nil
+}
+
+// GetSchemaConflict returns the ancestor, our and their versions of a table whose schema couldn't be merged, or
+// ErrNoConflicts if the table doesn't have a schema conflict.
+func (t *Table) GetSchemaConflict(ctx context.Context) (anc, ours, theirs *Table, err error) {
+	cnfVal, ok, err := t.tableStruct.MaybeGet(schemaConflictKey)
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if !ok {
+		return nil, nil, nil, ErrNoConflicts
+	}
+
+	cnf, err := ConflictFromTuple(cnfVal.(types.Tuple))
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var tbls []*Table
+	for _, tblRef := range []types.Value{cnf.Base, cnf.Value, cnf.MergeValue} {
+		tblVal, err := tblRef.(types.Ref).TargetValue(ctx, t.vrw)
+
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		tbls = append(tbls, &Table{t.vrw, tblVal.(types.Struct)})
+	}
+
+	return tbls[0], tbls[1], tbls[2], nil
+}
+
+// HasSchemaConflict returns whether the schema of the table couldn't be merged
+func (t *Table) HasSchemaConflict() (bool, error) {
+	if t == nil {
+		return false, nil
+	}
+
+	_, ok, err := t.tableStruct.MaybeGet(schemaConflictKey)
+
+	return ok, err
+}
+
+// ClearSchemaConflict removes the schema conflict of the table
+func (t *Table) ClearSchemaConflict() (*Table, error) {
+	tSt, err := t.tableStruct.Delete(schemaConflictKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{t.vrw, tSt}, nil
+}
+
 func (t *Table) GetConflictSchemas(ctx context.Context) (base, sch, mergeSch schema.Schema, err error) {
 	schemasVal, ok, err := t.tableStruct.MaybeGet(conflictSchemasKey)
 
@@ -0,0 +1,321 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/datas"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const (
+	tagMetaStructName = "tagmetadata"
+	tagMetaVersion    = "1.0"
+)
+
+// TagMeta is the metadata stored for an annotated tag.  It records who created the tag, when, and why.
+type TagMeta struct {
+	Name          string
+	Email         string
+	Timestamp     uint64
+	Description   string
+	UserTimestamp int64
+}
+
+// NewTagMeta returns TagMeta that can be used to create an annotated tag.
+func NewTagMeta(name, email, desc string) (*TagMeta, error) {
+	return NewTagMetaWithUserTS(name, email, desc, CommitNowFunc())
+}
+
+// NewTagMetaWithUserTS returns TagMeta with a user supplied timestamp that can be used to create an annotated tag.
+func NewTagMetaWithUserTS(name, email, desc string, userTS time.Time) (*TagMeta, error) {
+	n := strings.TrimSpace(name)
+	e := strings.TrimSpace(email)
+	d := strings.TrimSpace(desc)
+
+	if n == "" || e == "" || d == "" {
+		return nil, errors.New("Aborting tag due to empty tag message.")
+	}
+
+	ns := uint64(CommitNowFunc().UnixNano())
+	ms := ns / uMilliToNano
+
+	userMS := userTS.UnixNano() / milliToNano
+
+	return &TagMeta{n, e, ms, d, userMS}, nil
+}
+
+func tagMetaFromNomsSt(st types.Struct) (*TagMeta, error) {
+	e, err := getRequiredFromSt(st, commitMetaEmailKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := getRequiredFromSt(st, commitMetaNameKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := getRequiredFromSt(st, commitMetaDescKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := getRequiredFromSt(st, commitMetaTimestampKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	userTS, err := getRequiredFromSt(st, commitMetaUserTSKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TagMeta{
+		string(n.(types.String)),
+		string(e.(types.String)),
+		uint64(ts.(types.Uint)),
+		string(d.(types.String)),
+		int64(userTS.(types.Int)),
+	}, nil
+}
+
+func (tm *TagMeta) toNomsStruct(nbf *types.NomsBinFormat) (types.Struct, error) {
+	metadata := types.StructData{
+		commitMetaNameKey:      types.String(tm.Name),
+		commitMetaEmailKey:     types.String(tm.Email),
+		commitMetaDescKey:      types.String(tm.Description),
+		commitMetaTimestampKey: types.Uint(tm.Timestamp),
+		commitMetaVersionKey:   types.String(tagMetaVersion),
+		commitMetaUserTSKey:    types.Int(tm.UserTimestamp),
+	}
+
+	return types.NewStruct(nbf, tagMetaStructName, metadata)
+}
+
+// Time returns the time at which the tag was created
+func (tm *TagMeta) Time() time.Time {
+	seconds := tm.UserTimestamp / secToMilli
+	nanos := (tm.UserTimestamp % secToMilli) * milliToNano
+	return time.Unix(seconds, nanos)
+}
+
+// FormatTS takes the internal timestamp and turns it into a human readable string in the time.RubyDate format
+// which looks like: "Mon Jan 02 15:04:05 -0700 2006"
+func (tm *TagMeta) FormatTS() string {
+	return tm.Time().In(CommitLoc).Format(time.RubyDate)
+}
+
+func (tm *TagMeta) String() string {
+	return fmt.Sprintf("name: %s, email: %s, timestamp: %s, description: %s", tm.Name, tm.Email, tm.FormatTS(), tm.Description)
+}
+
+// Tag is a named, permanent reference to a commit.  Lightweight tags point directly at the tagged commit.  Annotated
+// tags point at a commit which stores the TagMeta and whose only parent is the tagged commit.
+type Tag struct {
+	Name   string
+	Meta   *TagMeta
+	Commit *Commit
+
+	head *Commit
+}
+
+// IsAnnotated returns true if the tag was created with a message
+func (t *Tag) IsAnnotated() bool {
+	return t.Meta != nil
+}
+
+// Head returns the commit the tag ref points at.  For lightweight tags this is the tagged commit.  For annotated tags
+// it is the commit that stores the tag's metadata.  This is the commit that should be used when moving a tag between
+// databases.
+func (t *Tag) Head() *Commit {
+	return t.head
+}
+
+// isTagCommitSt returns true if the commit struct given stores the metadata of an annotated tag
+func isTagCommitSt(commitSt types.Struct) (bool, error) {
+	metaVal, found, err := commitSt.MaybeGet(metaField)
+
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	metaSt, ok := metaVal.(types.Struct)
+	return ok && metaSt.Name() == tagMetaStructName, nil
+}
+
+// peelTag takes the commit struct at the head of a tag ref and returns the struct for the tagged commit.
+func peelTag(ctx context.Context, vrw types.ValueReadWriter, commitSt types.Struct) (types.Struct, error) {
+	isTag, err := isTagCommitSt(commitSt)
+
+	if err != nil {
+		return types.EmptyStruct(vrw.Format()), err
+	}
+
+	if !isTag {
+		return commitSt, nil
+	}
+
+	cm := Commit{vrw: vrw, commitSt: commitSt}
+	parentSt, err := cm.getParent(ctx, 0)
+
+	if err != nil {
+		return types.EmptyStruct(vrw.Format()), err
+	}
+
+	if parentSt == nil {
+		return types.EmptyStruct(vrw.Format()), ErrTagNotFound
+	}
+
+	return *parentSt, nil
+}
+
+// NewTagAtCommit creates a tag pointing at the commit given.  If meta is nil a lightweight tag is created, otherwise
+// an annotated tag storing the meta is created.  Returns ErrTagExists if the tag already exists.
+func (ddb *DoltDB) NewTagAtCommit(ctx context.Context, tagRef ref.DoltRef, c *Commit, meta *TagMeta) error {
+	if tagRef.GetType() != ref.TagRefType {
+		panic("invalid tag ref " + tagRef.String())
+	}
+
+	if !ref.IsValidTagName(tagRef.GetPath()) {
+		return ErrInvTagName
+	}
+
+	if has, err := ddb.HasRef(ctx, tagRef); err != nil {
+		return err
+	} else if has {
+		return ErrTagExists
+	}
+
+	head := c
+	if meta != nil {
+		rootVal, _, err := c.commitSt.MaybeGet(rootValueField)
+
+		if err != nil {
+			return err
+		}
+
+		rf, err := types.NewRef(c.commitSt, ddb.db.Format())
+
+		if err != nil {
+			return err
+		}
+
+		parents, err := types.NewSet(ctx, ddb.db, rf)
+
+		if err != nil {
+			return err
+		}
+
+		st, err := meta.toNomsStruct(ddb.db.Format())
+
+		if err != nil {
+			return err
+		}
+
+		commitOpts := datas.CommitOptions{Parents: parents, Meta: st, Policy: nil}
+		tagSt, err := ddb.db.CommitDangling(ctx, rootVal, commitOpts)
+
+		if err != nil {
+			return err
+		}
+
+		head = ddb.newCommit(tagSt)
+	}
+
+	return ddb.SetHead(ctx, tagRef, head)
+}
+
+// ResolveTag takes a tag ref and returns the Tag it refers to, or ErrTagNotFound if no such tag exists.
+func (ddb *DoltDB) ResolveTag(ctx context.Context, tagRef ref.DoltRef) (*Tag, error) {
+	headSt, err := getCommitStForRef(ctx, ddb.db, tagRef)
+
+	if err == ErrBranchNotFound {
+		return nil, ErrTagNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	head := ddb.newCommit(headSt)
+	tag := &Tag{Name: tagRef.GetPath(), Commit: head, head: head}
+
+	isTag, err := isTagCommitSt(headSt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if isTag {
+		metaVal, _, err := headSt.MaybeGet(metaField)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tag.Meta, err = tagMetaFromNomsSt(metaVal.(types.Struct))
+
+		if err != nil {
+			return nil, err
+		}
+
+		taggedSt, err := peelTag(ctx, ddb.db, headSt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tag.Commit = ddb.newCommit(taggedSt)
+	}
+
+	return tag, nil
+}
+
+// SetTag sets the tag ref given to point at the head of the tag given.  Used when moving tags between databases.
+func (ddb *DoltDB) SetTag(ctx context.Context, tagRef ref.DoltRef, tag *Tag) error {
+	return ddb.SetHead(ctx, tagRef, tag.Head())
+}
+
+var tagRefFilter = map[ref.RefType]struct{}{ref.TagRefType: {}}
+
+// GetTags returns a list of all tags in the database.
+func (ddb *DoltDB) GetTags(ctx context.Context) ([]ref.DoltRef, error) {
+	return ddb.GetRefsOfType(ctx, tagRefFilter)
+}
+
+// DeleteTag deletes the tag given, returning ErrTagNotFound if it doesn't exist.
+func (ddb *DoltDB) DeleteTag(ctx context.Context, tagRef ref.DoltRef) error {
+	err := ddb.DeleteBranch(ctx, tagRef)
+
+	if err == ErrBranchNotFound {
+		return ErrTagNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestTags(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	cs, _ := NewCommitSpec("HEAD", "master")
+	head, err := ddb.Resolve(ctx, cs)
+	require.NoError(t, err)
+	headHash, err := head.HashOf()
+	require.NoError(t, err)
+
+	lwRef := ref.NewTagRef("v1")
+	err = ddb.NewTagAtCommit(ctx, lwRef, head, nil)
+	require.NoError(t, err)
+
+	meta, err := NewTagMeta("Bill Billerson", "bigbillieb@fake.horse", "release v2")
+	require.NoError(t, err)
+	annRef := ref.NewTagRef("v2")
+	err = ddb.NewTagAtCommit(ctx, annRef, head, meta)
+	require.NoError(t, err)
+
+	err = ddb.NewTagAtCommit(ctx, lwRef, head, nil)
+	assert.Equal(t, ErrTagExists, err)
+
+	err = ddb.NewTagAtCommit(ctx, ref.NewTagRef("bad:name"), head, nil)
+	assert.Equal(t, ErrInvTagName, err)
+
+	lw, err := ddb.ResolveTag(ctx, lwRef)
+	require.NoError(t, err)
+	assert.False(t, lw.IsAnnotated())
+	lwHash, err := lw.Commit.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, headHash, lwHash)
+
+	ann, err := ddb.ResolveTag(ctx, annRef)
+	require.NoError(t, err)
+	assert.True(t, ann.IsAnnotated())
+	assert.Equal(t, "release v2", ann.Meta.Description)
+	annHash, err := ann.Commit.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, headHash, annHash)
+	annHeadHash, err := ann.Head().HashOf()
+	require.NoError(t, err)
+	assert.NotEqual(t, headHash, annHeadHash)
+
+	for _, spec := range []string{"v1", "v2", "refs/tags/v2", "v2~0"} {
+		cs, err := NewCommitSpec(spec, "master")
+		require.NoError(t, err)
+		cm, err := ddb.Resolve(ctx, cs)
+		require.NoError(t, err, spec)
+		h, err := cm.HashOf()
+		require.NoError(t, err)
+		assert.Equal(t, headHash, h, spec)
+	}
+
+	tags, err := ddb.GetTags(ctx)
+	require.NoError(t, err)
+	assert.Len(t, tags, 2)
+
+	err = ddb.DeleteTag(ctx, lwRef)
+	require.NoError(t, err)
+	err = ddb.DeleteTag(ctx, lwRef)
+	assert.Equal(t, ErrTagNotFound, err)
+
+	_, err = ddb.ResolveTag(ctx, lwRef)
+	assert.Equal(t, ErrTagNotFound, err)
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// ErrBisectBadIsGood is returned when the bad commit of a bisect is reachable from one of the good commits.
+var ErrBisectBadIsGood = errors.New("the bad commit is an ancestor of a good commit")
+
+// BisectStep describes the state of a bisect after a commit has been marked.
+type BisectStep struct {
+	// Candidates are the commits which could still be the first bad commit, newest first.  The first candidate is always
+	// the bad commit.
+	Candidates []*doltdb.Commit
+
+	// FirstBad is the first bad commit, once it has been found.
+	FirstBad *doltdb.Commit
+
+	// Next is the commit which should be tested next.  It is nil once the first bad commit has been found, or when all
+	// the candidates which haven't been tested have been skipped.
+	Next *doltdb.Commit
+
+	// Untested is the number of candidates other than the bad commit which haven't been skipped.
+	Untested int
+}
+
+// NextBisectStep finds the commits in the first-parent history of the bad commit which aren't reachable from any good
+// commit, and picks the one in the middle of them to be tested next.  Commits in skipped are never picked.
+func NextBisectStep(ctx context.Context, ddb *doltdb.DoltDB, bad hash.Hash, good, skipped []hash.Hash) (*BisectStep, error) {
+	candidates, err := commitwalk.GetFirstParentDotDotRevisions(ctx, ddb, bad, good)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) == 0 {
+		return nil, ErrBisectBadIsGood
+	}
+
+	step := &BisectStep{Candidates: candidates}
+
+	if len(candidates) == 1 {
+		step.FirstBad = candidates[0]
+		return step, nil
+	}
+
+	isSkipped := make(map[hash.Hash]bool, len(skipped))
+	for _, h := range skipped {
+		isSkipped[h] = true
+	}
+
+	// the first bad commit is one of the candidates, so the commit in the middle of the ones older than the bad commit
+	// halves the number of candidates whether it is good or bad.
+	middle := len(candidates) / 2
+	bestDist := len(candidates)
+	for i := 1; i < len(candidates); i++ {
+		h, err := candidates[i].HashOf()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if isSkipped[h] {
+			continue
+		}
+
+		step.Untested++
+
+		dist := i - middle
+		if dist < 0 {
+			dist = -dist
+		}
+
+		if dist < bestDist {
+			bestDist = dist
+			step.Next = candidates[i]
+		}
+	}
+
+	return step, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func mustHashOf(t *testing.T, cm *doltdb.Commit) hash.Hash {
+	h, err := cm.HashOf()
+	require.NoError(t, err)
+	return h
+}
+
+func TestNextBisectStep(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := doltdb.LoadDoltDB(ctx, types.Format_Default, doltdb.InMemDoltDB)
+	require.NoError(t, err)
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	master := ref.NewBranchRef("master")
+	cs, _ := doltdb.NewCommitSpec("HEAD", master.String())
+	initial, err := ddb.Resolve(ctx, cs)
+	require.NoError(t, err)
+	root, err := initial.GetRootValue()
+	require.NoError(t, err)
+	valHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+
+	// commits[0] is the oldest commit
+	commits := []*doltdb.Commit{initial}
+	for i := 1; i < 9; i++ {
+		meta, err := doltdb.NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "commit")
+		require.NoError(t, err)
+		cm, err := ddb.CommitWithParentSpecs(ctx, valHash, master, nil, meta)
+		require.NoError(t, err)
+		commits = append(commits, cm)
+	}
+
+	hashes := make([]hash.Hash, len(commits))
+	for i, cm := range commits {
+		hashes[i] = mustHashOf(t, cm)
+	}
+
+	step, err := NextBisectStep(ctx, ddb, hashes[8], []hash.Hash{hashes[0]}, nil)
+	require.NoError(t, err)
+	assert.Len(t, step.Candidates, 8)
+	assert.Nil(t, step.FirstBad)
+	assert.Equal(t, 7, step.Untested)
+	assert.Equal(t, hashes[4], mustHashOf(t, step.Next))
+
+	step, err = NextBisectStep(ctx, ddb, hashes[4], []hash.Hash{hashes[0]}, nil)
+	require.NoError(t, err)
+	assert.Len(t, step.Candidates, 4)
+	assert.Equal(t, hashes[2], mustHashOf(t, step.Next))
+
+	step, err = NextBisectStep(ctx, ddb, hashes[4], []hash.Hash{hashes[0]}, []hash.Hash{hashes[2]})
+	require.NoError(t, err)
+	assert.Equal(t, 2, step.Untested)
+	assert.NotEqual(t, hashes[2], mustHashOf(t, step.Next))
+
+	step, err = NextBisectStep(ctx, ddb, hashes[4], []hash.Hash{hashes[2]}, []hash.Hash{hashes[3]})
+	require.NoError(t, err)
+	assert.Nil(t, step.FirstBad)
+	assert.Nil(t, step.Next)
+	assert.Equal(t, 0, step.Untested)
+	assert.Len(t, step.Candidates, 2)
+
+	step, err = NextBisectStep(ctx, ddb, hashes[4], []hash.Hash{hashes[3], hashes[1]}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, step.Next)
+	assert.Equal(t, hashes[4], mustHashOf(t, step.FirstBad))
+
+	_, err = NextBisectStep(ctx, ddb, hashes[3], []hash.Hash{hashes[4]}, nil)
+	assert.Equal(t, ErrBisectBadIsGood, err)
+}
@@ -34,7 +34,27 @@ func MoveBranch(ctx context.Context, dEnv *env.DoltEnv, oldBranch, newBranch str
 	oldRef := ref.NewBranchRef(oldBranch)
 	newRef := ref.NewBranchRef(newBranch)
 
-	err := CopyBranch(ctx, dEnv, oldBranch, newBranch, force)
+	unlock, err := dEnv.LockWorktrees()
+
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	err = dEnv.CheckBranchAvailable(oldRef)
+
+	if err != nil {
+		return err
+	}
+
+	err = dEnv.CheckBranchAvailable(newRef)
+
+	if err != nil {
+		return err
+	}
+
+	err = CopyBranchOnDB(ctx, dEnv.DoltDB, oldBranch, newBranch, force)
 
 	if err != nil {
 		return err
@@ -49,10 +69,26 @@ func MoveBranch(ctx context.Context, dEnv *env.DoltEnv, oldBranch, newBranch str
 		}
 	}
 
-	return DeleteBranch(ctx, dEnv, oldBranch, true)
+	return DeleteBranchOnDB(ctx, dEnv.DoltDB, oldRef, true)
 }
 
 func CopyBranch(ctx context.Context, dEnv *env.DoltEnv, oldBranch, newBranch string, force bool) error {
+	if force {
+		unlock, err := dEnv.LockWorktrees()
+
+		if err != nil {
+			return err
+		}
+
+		defer unlock()
+
+		err = dEnv.CheckBranchAvailable(ref.NewBranchRef(newBranch))
+
+		if err != nil {
+			return err
+		}
+	}
+
 	return CopyBranchOnDB(ctx, dEnv.DoltDB, oldBranch, newBranch, force)
 }
 
@@ -97,6 +133,20 @@ func DeleteBranch(ctx context.Context, dEnv *env.DoltEnv, brName string, force b
 		return ErrCOBranchDelete
 	}
 
+	unlock, err := dEnv.LockWorktrees()
+
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	err = dEnv.CheckBranchAvailable(dref)
+
+	if err != nil {
+		return err
+	}
+
 	return DeleteBranchOnDB(ctx, dEnv.DoltDB, dref, force)
 }
 
@@ -153,6 +203,20 @@ func CreateBranch(ctx context.Context, dEnv *env.DoltEnv, newBranch, startingPoi
 
 	if !force && hasRef {
 		return ErrAlreadyExists
+	} else if hasRef {
+		unlock, err := dEnv.LockWorktrees()
+
+		if err != nil {
+			return err
+		}
+
+		defer unlock()
+
+		err = dEnv.CheckBranchAvailable(newRef)
+
+		if err != nil {
+			return err
+		}
 	}
 
 	if !doltdb.IsValidUserBranchName(newBranch) {
@@ -186,60 +250,57 @@ func CheckoutBranch(ctx context.Context, dEnv *env.DoltEnv, brName string) error
 		return doltdb.ErrAlreadyOnBranch
 	}
 
-	currRoots, err := getRoots(ctx, dEnv, HeadRoot, WorkingRoot, StagedRoot)
+	unlock, err := dEnv.LockWorktrees()
 
 	if err != nil {
 		return err
 	}
 
-	cs, err := doltdb.NewCommitSpec("head", brName)
+	defer unlock()
+
+	err = dEnv.CheckBranchAvailable(dref)
 
 	if err != nil {
-		return RootValueUnreadable{HeadRoot, err}
+		return err
 	}
 
-	cm, err := dEnv.DoltDB.Resolve(ctx, cs)
+	currRoots, err := getRoots(ctx, dEnv, HeadRoot, WorkingRoot, StagedRoot)
 
 	if err != nil {
-		return RootValueUnreadable{HeadRoot, err}
+		return err
 	}
 
-	newRoot, err := cm.GetRootValue()
+	cs, err := doltdb.NewCommitSpec("head", brName)
 
 	if err != nil {
-		return err
+		return RootValueUnreadable{HeadRoot, err}
 	}
 
-	ssMap, err := newRoot.GetSuperSchemaMap(ctx)
+	cm, err := dEnv.DoltDB.Resolve(ctx, cs)
 
 	if err != nil {
-		return err
+		return RootValueUnreadable{HeadRoot, err}
 	}
 
-	conflicts := set.NewStrSet([]string{})
-	wrkTblHashes, err := tblHashesForCO(ctx, currRoots[HeadRoot], newRoot, currRoots[WorkingRoot], conflicts)
+	newRoot, err := cm.GetRootValue()
 
 	if err != nil {
 		return err
 	}
 
-	stgTblHashes, err := tblHashesForCO(ctx, currRoots[HeadRoot], newRoot, currRoots[StagedRoot], conflicts)
+	wrkRoot, stgRoot, err := RootsForBranchCheckout(ctx, dEnv.DoltDB.ValueReadWriter(), currRoots[HeadRoot], newRoot, currRoots[WorkingRoot], currRoots[StagedRoot])
 
 	if err != nil {
 		return err
 	}
 
-	if conflicts.Size() > 0 {
-		return CheckoutWouldOverwrite{conflicts.AsSlice()}
-	}
-
-	wrkHash, err := writeRoot(ctx, dEnv, wrkTblHashes, ssMap)
+	wrkHash, err := dEnv.DoltDB.WriteRootValue(ctx, wrkRoot)
 
 	if err != nil {
 		return err
 	}
 
-	stgHash, err := writeRoot(ctx, dEnv, stgTblHashes, ssMap)
+	stgHash, err := dEnv.DoltDB.WriteRootValue(ctx, stgRoot)
 
 	if err != nil {
 		return err
@@ -265,6 +326,48 @@ func CheckoutBranch(ctx context.Context, dEnv *env.DoltEnv, brName string) error
 
 var emptyHash = hash.Hash{}
 
+// RootsForBranchCheckout returns the working and staged roots which result from moving from headRoot to newHeadRoot,
+// carrying over the changes in workingRoot and stagedRoot. If a changed table would be overwritten, a
+// CheckoutWouldOverwrite error listing the tables is returned.
+func RootsForBranchCheckout(ctx context.Context, vrw types.ValueReadWriter, headRoot, newHeadRoot, workingRoot, stagedRoot *doltdb.RootValue) (*doltdb.RootValue, *doltdb.RootValue, error) {
+	ssMap, err := newHeadRoot.GetSuperSchemaMap(ctx)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conflicts := set.NewStrSet([]string{})
+	wrkTblHashes, err := tblHashesForCO(ctx, headRoot, newHeadRoot, workingRoot, conflicts)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stgTblHashes, err := tblHashesForCO(ctx, headRoot, newHeadRoot, stagedRoot, conflicts)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if conflicts.Size() > 0 {
+		return nil, nil, CheckoutWouldOverwrite{conflicts.AsSlice()}
+	}
+
+	wrkRoot, err := rootForCO(ctx, vrw, wrkTblHashes, ssMap)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stgRoot, err := rootForCO(ctx, vrw, stgTblHashes, ssMap)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return wrkRoot, stgRoot, nil
+}
+
 func tblHashesForCO(ctx context.Context, oldRoot, newRoot, changedRoot *doltdb.RootValue, conflicts *set.StrSet) (map[string]hash.Hash, error) {
 	resultMap := make(map[string]hash.Hash)
 	tblNames, err := newRoot.GetTableNames(ctx)
@@ -334,23 +437,22 @@ func tblHashesForCO(ctx context.Context, oldRoot, newRoot, changedRoot *doltdb.R
 	return resultMap, nil
 }
 
-func writeRoot(ctx context.Context, dEnv *env.DoltEnv, tblHashes map[string]hash.Hash, ssMap types.Map) (hash.Hash, error) {
+func rootForCO(ctx context.Context, vrw types.ValueReadWriter, tblHashes map[string]hash.Hash, ssMap types.Map) (*doltdb.RootValue, error) {
 	for k, v := range tblHashes {
 		if v == emptyHash {
 			delete(tblHashes, k)
 		}
 	}
 
-	root, err := doltdb.NewRootValue(ctx, dEnv.DoltDB.ValueReadWriter(), tblHashes, ssMap)
+	root, err := doltdb.NewRootValue(ctx, vrw, tblHashes, ssMap)
 	if err != nil {
 		if err == doltdb.ErrHashNotFound {
-			return emptyHash, errors.New("corrupted database? Can't find hash of current table")
+			return nil, errors.New("corrupted database? Can't find hash of current table")
 		}
-		return emptyHash, doltdb.ErrNomsIO
+		return nil, doltdb.ErrNomsIO
 	}
 
-	return dEnv.DoltDB.WriteRootValue(ctx, root)
-
+	return root, nil
 }
 
 func RootsWithTable(ctx context.Context, dEnv *env.DoltEnv, table string) (RootTypeSet, error) {
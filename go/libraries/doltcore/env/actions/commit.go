@@ -20,6 +20,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/creds"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/diff"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
@@ -30,6 +31,7 @@ import (
 var ErrNameNotConfigured = errors.New("name not configured")
 var ErrEmailNotConfigured = errors.New("email not configured")
 var ErrEmptyCommitMessage = errors.New("commit message empty")
+var ErrBisectActive = errors.New("bisect in progress")
 
 // GetNameAndEmail returns the name and email from the supplied config
 func GetNameAndEmail(cfg config.ReadableConfig) (string, string, error) {
@@ -52,13 +54,20 @@ func GetNameAndEmail(cfg config.ReadableConfig) (string, string, error) {
 	return name, email, nil
 }
 
-func CommitStaged(ctx context.Context, dEnv *env.DoltEnv, msg string, date time.Time, allowEmpty bool) error {
+// CommitStaged commits the staged tables to the current branch.  If signer is not nil the commit is signed with its
+// private key.
+func CommitStaged(ctx context.Context, dEnv *env.DoltEnv, msg string, date time.Time, allowEmpty bool, signer *creds.DoltCreds) error {
 	stagedTbls, notStagedTbls, err := diff.GetTableDiffs(ctx, dEnv)
 
 	if msg == "" {
 		return ErrEmptyCommitMessage
 	}
 
+	// the working set holds the tables of the commit being tested, which shouldn't be committed on top of the branch
+	if dEnv.IsBisectActive() {
+		return ErrBisectActive
+	}
+
 	if err != nil {
 		return err
 	}
@@ -79,6 +88,24 @@ func CommitStaged(ctx context.Context, dEnv *env.DoltEnv, msg string, date time.
 		return err
 	}
 
+	// cherry-picked and rebased commits keep the author and date of the original commit
+	var origCmHashStr string
+	if dEnv.IsCherryPickActive() {
+		origCmHashStr = dEnv.RepoState.CherryPick.Commit
+	} else if dEnv.IsRebaseActive() {
+		origCmHashStr = dEnv.RepoState.Rebase.Current
+	}
+
+	if origCmHashStr != "" {
+		origMeta, err := getOriginalCommitMeta(ctx, dEnv, origCmHashStr)
+
+		if err != nil {
+			return err
+		}
+
+		name, email, date = origMeta.Name, origMeta.Email, origMeta.Time()
+	}
+
 	var mergeCmSpec []*doltdb.CommitSpec
 	if dEnv.IsMergeActive() {
 		spec, err := doltdb.NewCommitSpec(dEnv.RepoState.Merge.Commit, dEnv.RepoState.Merge.Head.Ref.String())
@@ -131,15 +158,37 @@ func CommitStaged(ctx context.Context, dEnv *env.DoltEnv, msg string, date time.
 		return ErrEmptyCommitMessage
 	}
 
+	if signer != nil {
+		meta.SignWith(*signer)
+	}
+
 	_, err = dEnv.DoltDB.CommitWithParentSpecs(ctx, h, dEnv.RepoState.CWBHeadRef(), mergeCmSpec, meta)
 
 	if err == nil {
 		dEnv.RepoState.ClearMerge(dEnv.FS)
+		dEnv.RepoState.ClearCherryPick(dEnv.FS)
+		dEnv.RepoState.ClearRevert(dEnv.FS)
 	}
 
 	return err
 }
 
+func getOriginalCommitMeta(ctx context.Context, dEnv *env.DoltEnv, cmHashStr string) (*doltdb.CommitMeta, error) {
+	spec, err := doltdb.NewCommitSpec(cmHashStr, dEnv.RepoState.CWBHeadRef().String())
+
+	if err != nil {
+		panic("Corrupted repostate. Active cherry-pick or rebase state is not valid.")
+	}
+
+	cm, err := dEnv.DoltDB.Resolve(ctx, spec)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cm.GetCommitMeta()
+}
+
 // TimeSortedCommits returns a reverse-chronological (latest-first) list of the most recent `n` ancestors of `commit`.
 // Passing a negative value for `n` will result in all ancestors being returned.
 func TimeSortedCommits(ctx context.Context, ddb *doltdb.DoltDB, commit *doltdb.Commit, n int) ([]*doltdb.Commit, error) {
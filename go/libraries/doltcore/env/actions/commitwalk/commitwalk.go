@@ -145,17 +145,27 @@ func newQueue(ddb *doltdb.DoltDB) *q {
 // to `num` commits, in reverse topological order starting at `includedHead`,
 // with tie breaking based on the height of commit graph between
 // concurrent commits --- higher commits appear first. Remaining
-// ties are broken by timestamp; newer commits appear first.
+// ties are broken by timestamp; newer commits appear first. Passing a
+// negative value for `num` returns all of the commits.
 //
 // Roughly mimics `git log master..feature`.
 func GetDotDotRevisions(ctx context.Context, ddb *doltdb.DoltDB, includedHead hash.Hash, excludedHead hash.Hash, num int) ([]*doltdb.Commit, error) {
-	commitList := make([]*doltdb.Commit, 0, num)
-	q := newQueue(ddb)
-	if err := q.SetInvisible(ctx, excludedHead); err != nil {
-		return nil, err
+	return getDotDotRevisions(ctx, ddb, includedHead, []hash.Hash{excludedHead}, num)
+}
+
+func getDotDotRevisions(ctx context.Context, ddb *doltdb.DoltDB, includedHead hash.Hash, excludedHeads []hash.Hash, num int) ([]*doltdb.Commit, error) {
+	var commitList []*doltdb.Commit
+	if num > 0 {
+		commitList = make([]*doltdb.Commit, 0, num)
 	}
-	if err := q.AddPendingIfUnseen(ctx, excludedHead); err != nil {
-		return nil, err
+	q := newQueue(ddb)
+	for _, excludedHead := range excludedHeads {
+		if err := q.SetInvisible(ctx, excludedHead); err != nil {
+			return nil, err
+		}
+		if err := q.AddPendingIfUnseen(ctx, excludedHead); err != nil {
+			return nil, err
+		}
 	}
 	if err := q.AddPendingIfUnseen(ctx, includedHead); err != nil {
 		return nil, err
@@ -186,6 +196,77 @@ func GetDotDotRevisions(ctx context.Context, ddb *doltdb.DoltDB, includedHead ha
 	return commitList, nil
 }
 
+// GetFirstParentDotDotRevisions returns the commits in the first-parent history of the commit at hash `includedHead`
+// which are not reachable from any of the commits at `excludedHeads`, starting with `includedHead`. The first parent
+// of a commit is the parent followed by ancestor specs such as `HEAD~1`.
+//
+// Roughly mimics `git rev-list --first-parent feature ^master`.
+func GetFirstParentDotDotRevisions(ctx context.Context, ddb *doltdb.DoltDB, includedHead hash.Hash, excludedHeads []hash.Hash) ([]*doltdb.Commit, error) {
+	reachable, err := getDotDotRevisions(ctx, ddb, includedHead, excludedHeads, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	included := make(map[hash.Hash]bool, len(reachable))
+	for _, commit := range reachable {
+		h, err := commit.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		included[h] = true
+	}
+
+	var commitList []*doltdb.Commit
+	if len(reachable) == 0 {
+		return commitList, nil
+	}
+
+	commit := reachable[0]
+	for {
+		commitList = append(commitList, commit)
+
+		numParents, err := commit.NumParents()
+		if err != nil {
+			return nil, err
+		} else if numParents == 0 {
+			return commitList, nil
+		}
+
+		commit, err = ddb.ResolveParent(ctx, commit, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := commit.HashOf()
+		if err != nil {
+			return nil, err
+		}
+
+		if !included[h] {
+			return commitList, nil
+		}
+	}
+}
+
+// GetAheadBehind returns the number of commits reachable from the commit at hash `local` which are not reachable from
+// the commit at hash `upstream`, and the number of commits reachable from `upstream` which are not reachable from
+// `local`.  Neither count includes the merge base of the two commits, or any of its ancestors.
+//
+// Roughly mimics `git rev-list --left-right --count local...upstream`.
+func GetAheadBehind(ctx context.Context, ddb *doltdb.DoltDB, local, upstream hash.Hash) (ahead int, behind int, err error) {
+	aheadCommits, err := getDotDotRevisions(ctx, ddb, local, []hash.Hash{upstream}, -1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	behindCommits, err := getDotDotRevisions(ctx, ddb, upstream, []hash.Hash{local}, -1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return len(aheadCommits), len(behindCommits), nil
+}
+
 // GetTopologicalOrderCommits returns the commits reachable from the commit at hash `startCommitHash`
 // in reverse topological order, with tiebreaking done by the height of the commit graph -- higher commits
 // appear first. Remaining ties are broken by timestamp; newer commits appear first.
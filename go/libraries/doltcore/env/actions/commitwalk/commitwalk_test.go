@@ -116,6 +116,12 @@ func TestGetDotDotRevisions(t *testing.T) {
 	assert.Equal(t, featureCommits[2], res[5])
 	assert.Equal(t, featureCommits[1], res[6])
 
+	res, err = GetDotDotRevisions(context.Background(), env.DoltDB, featureHash, masterHash, -1)
+	require.NoError(t, err)
+	assert.Len(t, res, 7)
+	assert.Equal(t, featureCommits[7], res[0])
+	assert.Equal(t, featureCommits[1], res[6])
+
 	res, err = GetDotDotRevisions(context.Background(), env.DoltDB, masterHash, featureHash, 100)
 	require.NoError(t, err)
 	assert.Len(t, res, 0)
@@ -133,6 +139,52 @@ func TestGetDotDotRevisions(t *testing.T) {
 	assert.Equal(t, featureCommits[3], res[0])
 	assert.Equal(t, featureCommits[2], res[1])
 	assert.Equal(t, featureCommits[1], res[2])
+
+	// The first parent of the merge depends on the hashes of its parents.
+	mergeFirstParent, err := env.DoltDB.ResolveParent(context.Background(), featureCommits[4], 0)
+	require.NoError(t, err)
+	mergeFirstParentIsFeature := mustGetHash(t, mergeFirstParent) == featurePreMergeHash
+
+	res, err = GetFirstParentDotDotRevisions(context.Background(), env.DoltDB, featureHash, []hash.Hash{masterHash})
+	require.NoError(t, err)
+	if mergeFirstParentIsFeature {
+		assert.Len(t, res, 7)
+		assert.Equal(t, featureCommits[1], res[6])
+	} else {
+		assert.Len(t, res, 4)
+	}
+	assert.Equal(t, featureCommits[7], res[0])
+	assert.Equal(t, featureCommits[4], res[3])
+
+	res, err = GetFirstParentDotDotRevisions(context.Background(), env.DoltDB, featureHash, []hash.Hash{masterHash, mustGetHash(t, featureCommits[6])})
+	require.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, featureCommits[7], res[0])
+
+	res, err = GetFirstParentDotDotRevisions(context.Background(), env.DoltDB, masterHash, []hash.Hash{featureHash})
+	require.NoError(t, err)
+	assert.Len(t, res, 0)
+
+	res, err = GetFirstParentDotDotRevisions(context.Background(), env.DoltDB, masterHash, nil)
+	require.NoError(t, err)
+	assert.Len(t, res, 7)
+	assert.Equal(t, masterCommits[6], res[0])
+	assert.Equal(t, masterCommits[0], res[6])
+
+	ahead, behind, err := GetAheadBehind(context.Background(), env.DoltDB, featureHash, mustGetHash(t, masterCommits[9]))
+	require.NoError(t, err)
+	assert.Equal(t, 7, ahead)
+	assert.Equal(t, 3, behind)
+
+	ahead, behind, err = GetAheadBehind(context.Background(), env.DoltDB, masterHash, featureHash)
+	require.NoError(t, err)
+	assert.Equal(t, 0, ahead)
+	assert.Equal(t, 7, behind)
+
+	ahead, behind, err = GetAheadBehind(context.Background(), env.DoltDB, featureHash, featureHash)
+	require.NoError(t, err)
+	assert.Equal(t, 0, ahead)
+	assert.Equal(t, 0, behind)
 }
 
 func mustCreateCommit(t *testing.T, ddb *doltdb.DoltDB, bn string, rvh hash.Hash, parents ...*doltdb.Commit) *doltdb.Commit {
@@ -0,0 +1,347 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// The kinds of problems found by Fsck
+const (
+	FsckTableFile = "table_file"
+	FsckChunk     = "chunk"
+	FsckRef       = "ref"
+	FsckRoot      = "root"
+	FsckCommit    = "commit"
+	FsckTable     = "table"
+)
+
+// FsckProblem describes a problem found by Fsck.  Object identifies what the problem was found in: a table file id, a
+// chunk hash, a ref, the name of a root such as "working", a commit hash, or a table name.  For tables, Location is the
+// commit hash or root name the table was found in.
+type FsckProblem struct {
+	Kind     string `json:"kind"`
+	Object   string `json:"object"`
+	Location string `json:"location,omitempty"`
+	Message  string `json:"message"`
+}
+
+// FsckReport describes everything checked by Fsck, and the problems found.
+type FsckReport struct {
+	OK              bool          `json:"ok"`
+	TableFiles      int           `json:"table_files"`
+	TableFileChunks uint64        `json:"table_file_chunks"`
+	ReachableChunks uint64        `json:"reachable_chunks"`
+	RemoteChunks    uint64        `json:"remote_chunks"`
+	Unverified      int           `json:"unverified"`
+	Refs            int           `json:"refs"`
+	Commits         int           `json:"commits"`
+	Tables          int           `json:"tables"`
+	Rows            uint64        `json:"rows"`
+	Problems        []FsckProblem `json:"problems"`
+}
+
+func (r *FsckReport) addProblem(kind, object, location string, err error) {
+	r.Problems = append(r.Problems, FsckProblem{kind, object, location, err.Error()})
+}
+
+// Fsck checks the integrity of the repository of the environment.  It checks that:
+//   - every table file's index can be read, and every chunk in it has the address the index gives it
+//   - every chunk reachable from a ref, the working and staged roots, or anything else kept by garbage collection is
+//     present and can be decoded
+//   - every ref resolves to a commit, and the working and staged roots can be read
+//   - the rows of every table in the working and staged roots, and in every commit, match the schema of the table, and
+//     the data of the table's indexes agrees with its rows
+//
+// In a lazy clone only what is stored locally is checked, and nothing is fetched from the remote.  The chunks which are
+// only stored by the remote, and the refs, roots, commits and tables which can't be read without them, are counted as
+// unverified.
+//
+// If connectivityOnly is true the rows of tables are not checked.  Problems are returned in the report, and errors are
+// only returned when the checks can't be carried out.
+func Fsck(ctx context.Context, dEnv *env.DoltEnv, connectivityOnly bool) (*FsckReport, error) {
+	ddb := dEnv.DoltDB
+	report := &FsckReport{Problems: []FsckProblem{}}
+
+	tfReport, err := ddb.VerifyTableFiles(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	report.TableFiles = tfReport.TableFiles
+	report.TableFileChunks = tfReport.Chunks
+	for _, p := range tfReport.Problems {
+		err := p.Err
+		if !p.Chunk.IsEmpty() {
+			err = fmt.Errorf("chunk %s: %v", p.Chunk.String(), p.Err)
+		}
+
+		report.addProblem(FsckTableFile, p.FileID, "", err)
+	}
+
+	extraRoots, err := getGCRoots(ctx, dEnv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	chReport, err := ddb.VerifyReachableChunks(ctx, extraRoots)
+
+	if err != nil {
+		return nil, err
+	}
+
+	report.ReachableChunks = chReport.Reachable
+	report.RemoteChunks = chReport.Remote
+	for _, p := range chReport.Problems {
+		err := p.Err
+		if !p.Referrer.IsEmpty() {
+			err = fmt.Errorf("%v, referenced by %s", p.Err, p.Referrer.String())
+		}
+
+		report.addProblem(FsckChunk, p.Hash.String(), "", err)
+	}
+
+	// the refs, roots, commits and tables of a lazy clone are only checked if they are stored locally, so that checking
+	// them doesn't fetch the history of the remote.  Failing to read one of them is not a problem, as any local chunk
+	// which can't be read has already been reported.
+	localDB := ddb.LocalOnly()
+	fc := &fscker{report: report, lazy: ddb.IsLazy(), checked: hash.NewHashSet()}
+
+	namedRoots := []struct {
+		name string
+		h    hash.Hash
+	}{
+		{"working", dEnv.RepoState.WorkingHash()},
+		{"staged", dEnv.RepoState.StagedHash()},
+	}
+
+	roots := make(map[string]*doltdb.RootValue)
+	for _, nr := range namedRoots {
+		var root *doltdb.RootValue
+		err := catchPanics(func() (err error) {
+			root, err = localDB.ReadRootValue(ctx, nr.h)
+			return err
+		})
+
+		if err != nil {
+			fc.addProblem(FsckRoot, nr.name, "", fmt.Errorf("%s: %v", nr.h.String(), err))
+			continue
+		}
+
+		roots[nr.name] = root
+	}
+
+	refs, err := localDB.GetRefs(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var heads []hash.Hash
+	for _, dref := range refs {
+		report.Refs++
+
+		var h hash.Hash
+		err := catchPanics(func() error {
+			cs, err := doltdb.NewCommitSpec("HEAD", dref.String())
+
+			if err != nil {
+				return err
+			}
+
+			cm, err := localDB.Resolve(ctx, cs)
+
+			if err != nil {
+				return err
+			}
+
+			h, err = cm.HashOf()
+
+			if err != nil {
+				return err
+			}
+
+			_, err = cm.GetRootValue()
+			return err
+		})
+
+		if err != nil {
+			fc.addProblem(FsckRef, dref.String(), "", err)
+			continue
+		}
+
+		heads = append(heads, h)
+	}
+
+	if connectivityOnly {
+		report.OK = len(report.Problems) == 0
+		return report, nil
+	}
+
+	// tables are checked once, no matter how many roots they are in
+	for _, nr := range namedRoots {
+		if root, ok := roots[nr.name]; ok {
+			fc.fsckTables(ctx, root, nr.name)
+		}
+	}
+
+	// the commit graph is walked depth first from the heads of the refs.  Commits which can't be read end the walk along
+	// their branch of the graph.
+	visited := hash.NewHashSet()
+	toVisit := heads
+	for len(toVisit) > 0 {
+		h := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+
+		if visited.Has(h) {
+			continue
+		}
+
+		visited.Insert(h)
+
+		var root *doltdb.RootValue
+		var parents []hash.Hash
+		err := catchPanics(func() error {
+			cs, err := doltdb.NewCommitSpec(h.String(), "")
+
+			if err != nil {
+				return err
+			}
+
+			cm, err := localDB.Resolve(ctx, cs)
+
+			if err != nil {
+				return err
+			}
+
+			parents, err = cm.ParentHashes(ctx)
+
+			if err != nil {
+				return err
+			}
+
+			root, err = cm.GetRootValue()
+			return err
+		})
+
+		if err != nil {
+			fc.addProblem(FsckCommit, h.String(), "", err)
+			continue
+		}
+
+		report.Commits++
+		toVisit = append(toVisit, parents...)
+		fc.fsckTables(ctx, root, h.String())
+	}
+
+	report.OK = len(report.Problems) == 0
+	return report, nil
+}
+
+// fscker holds the state of the checks of the roots, commits and tables of a repository.
+type fscker struct {
+	report *FsckReport
+
+	// lazy is true if the repository is a lazy clone, in which case what can't be read is counted as unverified
+	lazy bool
+
+	// checked holds the hashes of the tables which have been checked
+	checked hash.HashSet
+}
+
+// addProblem adds a problem to the report, or counts the object as unverified for a lazy clone.
+func (fc *fscker) addProblem(kind, object, location string, err error) {
+	if fc.lazy {
+		fc.report.Unverified++
+		return
+	}
+
+	fc.report.addProblem(kind, object, location, err)
+}
+
+// fsckTables checks the tables of root which haven't been checked yet.
+func (fc *fscker) fsckTables(ctx context.Context, root *doltdb.RootValue, location string) {
+	var names []string
+	err := catchPanics(func() (err error) {
+		names, err = root.GetTableNames(ctx)
+		return err
+	})
+
+	if err != nil {
+		fc.addProblem(FsckRoot, location, "", err)
+		return
+	}
+
+	for _, name := range names {
+		var tbl *doltdb.Table
+		var h hash.Hash
+		err := catchPanics(func() (err error) {
+			tbl, _, err = root.GetTable(ctx, name)
+
+			if err != nil {
+				return err
+			}
+
+			h, err = tbl.HashOf()
+			return err
+		})
+
+		if err != nil {
+			fc.addProblem(FsckTable, name, location, err)
+			continue
+		} else if fc.checked.Has(h) {
+			continue
+		}
+
+		fc.checked.Insert(h)
+
+		rows, problems, err := doltdb.VerifyTable(ctx, tbl)
+
+		if err != nil && fc.lazy {
+			fc.report.Unverified++
+			continue
+		}
+
+		fc.report.Tables++
+		fc.report.Rows += rows
+
+		if err != nil {
+			fc.report.addProblem(FsckTable, name, location, err)
+			continue
+		}
+
+		for _, p := range problems {
+			fc.report.addProblem(FsckTable, name, location, p)
+		}
+	}
+}
+
+// catchPanics calls f, returning the panics caused by reading corrupt data as errors.
+func catchPanics(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to read: %v", r)
+		}
+	}()
+
+	return f()
+}
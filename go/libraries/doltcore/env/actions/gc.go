@@ -0,0 +1,115 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/nbs"
+)
+
+// GarbageCollect removes all the chunks from the database of the environment which are no longer needed.  Everything
+// reachable from a ref, the working and staged roots of every worktree, the state of any merge, cherry-pick or rebase
+// in progress in a worktree, and the commits and roots recorded in the reflogs is kept.
+func GarbageCollect(ctx context.Context, dEnv *env.DoltEnv, dryRun bool) (nbs.GCStats, error) {
+	roots, err := getGCRoots(ctx, dEnv)
+
+	if err != nil {
+		return nbs.GCStats{}, err
+	}
+
+	return dEnv.DoltDB.CollectGarbage(ctx, roots, dryRun)
+}
+
+// getGCRoots returns the hashes of the values which must be kept by garbage collection, but which may not be reachable
+// from any ref.  All the worktrees of the repository share its database, so the roots of each of them are kept.
+func getGCRoots(ctx context.Context, dEnv *env.DoltEnv) (hash.HashSet, error) {
+	worktrees, err := dEnv.Worktrees()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var hashStrs []string
+	for _, wt := range worktrees {
+		if wt.Missing {
+			continue
+		}
+
+		hashStrs = append(hashStrs, repoStateGCRoots(wt.RepoState)...)
+
+		// commits which refs used to point at, and past working and staged roots, are kept so that the entries of the
+		// reflogs can still be referenced using <ref>@{<n>}.
+		refLog := env.NewFileRefLog(wt.FS, nil)
+		names, err := refLog.Names(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range names {
+			entries, err := refLog.Entries(ctx, name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, entry := range entries {
+				hashStrs = append(hashStrs, entry.OldHash, entry.NewHash)
+			}
+		}
+	}
+
+	roots := hash.NewHashSet()
+	for _, hashStr := range hashStrs {
+		if h, ok := hash.MaybeParse(hashStr); ok && !h.IsEmpty() {
+			roots.Insert(h)
+		}
+	}
+
+	return roots, nil
+}
+
+// repoStateGCRoots returns the hashes of the roots and commits referenced by a repo state.
+func repoStateGCRoots(rs *env.RepoState) []string {
+	hashStrs := []string{rs.Working, rs.Staged}
+
+	if rs.Merge != nil {
+		hashStrs = append(hashStrs, rs.Merge.Commit, rs.Merge.PreMergeWorking)
+	}
+
+	if rs.CherryPick != nil {
+		hashStrs = append(hashStrs, rs.CherryPick.Commit)
+	}
+
+	if rs.Revert != nil {
+		hashStrs = append(hashStrs, rs.Revert.Commit)
+	}
+
+	if rs.Rebase != nil {
+		hashStrs = append(hashStrs, rs.Rebase.OrigHead, rs.Rebase.Onto, rs.Rebase.Current)
+		hashStrs = append(hashStrs, rs.Rebase.Remaining...)
+	}
+
+	if rs.Bisect != nil {
+		hashStrs = append(hashStrs, rs.Bisect.PreBisectWorking, rs.Bisect.PreBisectStaged, rs.Bisect.Bad, rs.Bisect.Current)
+		hashStrs = append(hashStrs, rs.Bisect.Good...)
+		hashStrs = append(hashStrs, rs.Bisect.Skipped...)
+	}
+
+	return hashStrs
+}
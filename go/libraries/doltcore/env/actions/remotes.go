@@ -20,11 +20,15 @@ import (
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions/commitwalk"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
 	"github.com/liquidata-inc/dolt/go/store/datas"
+	"github.com/liquidata-inc/dolt/go/store/hash"
 )
 
 var ErrCantFF = errors.New("can't fast forward merge")
+var ErrNotShallow = errors.New("repository is not a shallow clone")
+var ErrNotLazy = errors.New("repository is not a lazy clone")
 
 // Push will update a destination branch, in a given destination database if it can be done as a fast forward merge.
 // This is accomplished first by verifying that the remote tracking reference for the source database can be updated to
@@ -97,6 +101,304 @@ func Fetch(ctx context.Context, dEnv *env.DoltEnv, destRef ref.DoltRef, srcDB, d
 	return destDB.PullChunks(ctx, dEnv.TempTableFilesDir(), srcDB, srcDBCommit, progChan, pullerEventCh)
 }
 
+// PushTag pushes the chunks needed by tag to destDB and then sets destRef in destDB to point at it.  If destRef
+// already exists in destDB and refers to a different tag, doltdb.ErrTagExists is returned unless mode is a forced
+// update.  If destRef already refers to the tag, doltdb.ErrUpToDate is returned.
+func PushTag(ctx context.Context, dEnv *env.DoltEnv, mode ref.RefUpdateMode, destRef ref.TagRef, srcDB, destDB *doltdb.DoltDB, tag *doltdb.Tag, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
+	err := checkTagUpdate(ctx, mode, destDB, destRef, tag)
+
+	if err != nil {
+		return err
+	}
+
+	err = destDB.PushChunks(ctx, dEnv.TempTableFilesDir(), srcDB, tag.Head(), progChan, pullerEventCh)
+
+	if err != nil {
+		return err
+	}
+
+	return destDB.SetTag(ctx, destRef, tag)
+}
+
+// FetchTag pulls the chunks needed by tag from srcDB and then sets destRef in destDB to point at it.  Existing tags are
+// handled the same way as they are by PushTag.
+func FetchTag(ctx context.Context, dEnv *env.DoltEnv, mode ref.RefUpdateMode, destRef ref.TagRef, srcDB, destDB *doltdb.DoltDB, tag *doltdb.Tag, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
+	err := checkTagUpdate(ctx, mode, destDB, destRef, tag)
+
+	if err != nil {
+		return err
+	}
+
+	err = destDB.PullChunks(ctx, dEnv.TempTableFilesDir(), srcDB, tag.Head(), progChan, pullerEventCh)
+
+	if err != nil {
+		return err
+	}
+
+	return destDB.SetTag(ctx, destRef, tag)
+}
+
+func checkTagUpdate(ctx context.Context, mode ref.RefUpdateMode, db *doltdb.DoltDB, tagRef ref.TagRef, tag *doltdb.Tag) error {
+	existing, err := db.ResolveTag(ctx, tagRef)
+
+	if err == doltdb.ErrTagNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	existingHash, err := existing.Head().HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	newHash, err := tag.Head().HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	if existingHash == newHash {
+		return doltdb.ErrUpToDate
+	} else if !mode.Force {
+		return doltdb.ErrTagExists
+	}
+
+	return nil
+}
+
 func Clone(ctx context.Context, srcDB, destDB *doltdb.DoltDB, eventCh chan<- datas.TableFileEvent) error {
 	return srcDB.Clone(ctx, destDB, eventCh)
 }
+
+// ShallowClone pulls the last depth commits of each branch in srcDB into the empty database of dEnv, creates the same
+// branches, and records the commits on the shallow boundary.  Tags are not cloned.
+func ShallowClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, depth int, progChan chan datas.PullProgress) error {
+	branches, err := srcDB.GetBranches(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	heads := make([]*doltdb.Commit, len(branches))
+	headHashes := make(hash.HashSlice, len(branches))
+	for i, branch := range branches {
+		cs, _ := doltdb.NewCommitSpec("HEAD", branch.String())
+		heads[i], err = srcDB.Resolve(ctx, cs)
+
+		if err != nil {
+			return err
+		}
+
+		headHashes[i], err = heads[i].HashOf()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	shallow, err := dEnv.DoltDB.PullShallow(ctx, srcDB, headHashes, depth, progChan)
+
+	if err != nil {
+		return err
+	}
+
+	err = dEnv.UpdateShallowCommits(shallow)
+
+	if err != nil {
+		return err
+	}
+
+	for i, branch := range branches {
+		err = dEnv.DoltDB.SetHead(ctx, branch, heads[i])
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Deepen pulls depth more generations of commits from behind the shallow boundary of the database of dEnv from srcDB,
+// and records the new shallow boundary.  ErrNotShallow is returned if the database is not a shallow clone.
+func Deepen(ctx context.Context, dEnv *env.DoltEnv, srcDB *doltdb.DoltDB, depth int, progChan chan datas.PullProgress) error {
+	shallow := dEnv.DoltDB.ShallowCommits()
+
+	if len(shallow) == 0 {
+		return ErrNotShallow
+	}
+
+	heads := make(hash.HashSlice, 0, len(shallow))
+	for h := range shallow {
+		heads = append(heads, h)
+	}
+
+	// the shallow commits are already present, so their parents are one generation deeper
+	newShallow, err := dEnv.DoltDB.PullShallow(ctx, srcDB, heads, depth+1, progChan)
+
+	if err != nil {
+		return err
+	}
+
+	return dEnv.UpdateShallowCommits(newShallow)
+}
+
+// LazyClone makes the empty database of dEnv a lazy clone of srcDB, the database of the remote named remoteName.  Only
+// the root of srcDB is written locally.  Chunks are read from srcDB as they are needed, and persisted locally.  The
+// repo state of dEnv must be saved for the clone to remain lazy when it is next loaded.
+func LazyClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, remoteName string) error {
+	err := dEnv.DoltDB.LazyClone(ctx, srcDB)
+
+	if err != nil {
+		return err
+	}
+
+	dEnv.DoltDB.SetLazyRemote(srcDB)
+	dEnv.RepoState.LazyRemote = remoteName
+
+	return nil
+}
+
+// CompleteLazyClone fetches every chunk which is missing from the database of a lazy clone, and which is needed by a
+// ref, the working set, or any operation in progress.  Once complete the repository no longer reads from its remote.
+func CompleteLazyClone(ctx context.Context, dEnv *env.DoltEnv) error {
+	if !dEnv.DoltDB.IsLazy() {
+		return ErrNotLazy
+	}
+
+	roots, err := getGCRoots(ctx, dEnv)
+
+	if err != nil {
+		return err
+	}
+
+	err = dEnv.DoltDB.CompleteLazyClone(ctx, roots)
+
+	if err != nil {
+		return err
+	}
+
+	dEnv.RepoState.LazyRemote = ""
+	return dEnv.RepoState.Save(dEnv.FS)
+}
+
+// PruneRemoteRefs deletes the remote tracking branches of remote which are updated by its fetch refspecs, but whose
+// branches no longer exist in srcDB.  The deleted refs are returned.
+func PruneRemoteRefs(ctx context.Context, dEnv *env.DoltEnv, remote env.Remote, srcDB *doltdb.DoltDB) ([]ref.RemoteRef, error) {
+	refSpecs, verr := dEnv.GetRefSpecs(remote.Name)
+
+	if verr != nil {
+		return nil, verr
+	}
+
+	srcRefs, err := srcDB.GetRefsOfType(ctx, map[ref.RefType]struct{}{ref.BranchRefType: {}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var trackingSpecs []ref.BranchToTrackingBranchRefSpec
+	expected := make(map[string]struct{})
+	for _, rs := range refSpecs {
+		if trackingSpec, ok := rs.(ref.BranchToTrackingBranchRefSpec); ok {
+			trackingSpecs = append(trackingSpecs, trackingSpec)
+
+			for _, srcRef := range srcRefs {
+				if destRef := trackingSpec.DestRef(srcRef); destRef != nil {
+					expected[destRef.String()] = struct{}{}
+				}
+			}
+		}
+	}
+
+	localRefs, err := dEnv.DoltDB.GetRefsOfType(ctx, map[ref.RefType]struct{}{ref.RemoteRefType: {}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []ref.RemoteRef
+	for _, r := range localRefs {
+		if _, ok := expected[r.String()]; ok {
+			continue
+		}
+
+		for _, trackingSpec := range trackingSpecs {
+			if trackingSpec.IsDestRef(r) {
+				err = dEnv.DoltDB.DeleteBranch(ctx, r)
+
+				if err != nil {
+					return nil, err
+				}
+
+				pruned = append(pruned, r.(ref.RemoteRef))
+				break
+			}
+		}
+	}
+
+	return pruned, nil
+}
+
+// UpstreamStatus describes how a branch compares to the remote tracking branch of its upstream.  Gone is true if the
+// remote tracking branch does not exist.  Ahead is the number of commits on the branch which are not on the upstream,
+// and Behind is the number of commits on the upstream which are not on the branch.
+type UpstreamStatus struct {
+	Upstream ref.DoltRef
+	Gone     bool
+	Ahead    int
+	Behind   int
+}
+
+// GetUpstreamStatus compares branch to the remote tracking branch of its upstream.  nil is returned if the branch has
+// no upstream.
+func GetUpstreamStatus(ctx context.Context, dEnv *env.DoltEnv, branch ref.DoltRef) (*UpstreamStatus, error) {
+	upstream, ok, verr := dEnv.GetUpstream(branch)
+
+	if verr != nil {
+		return nil, verr
+	} else if !ok {
+		return nil, nil
+	}
+
+	hasRef, err := dEnv.DoltDB.HasRef(ctx, upstream)
+
+	if err != nil {
+		return nil, err
+	} else if !hasRef {
+		return &UpstreamStatus{Upstream: upstream, Gone: true}, nil
+	}
+
+	localHash, err := resolveRefHash(ctx, dEnv.DoltDB, branch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	upstreamHash, err := resolveRefHash(ctx, dEnv.DoltDB, upstream)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ahead, behind, err := commitwalk.GetAheadBehind(ctx, dEnv.DoltDB, localHash, upstreamHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpstreamStatus{Upstream: upstream, Ahead: ahead, Behind: behind}, nil
+}
+
+func resolveRefHash(ctx context.Context, ddb *doltdb.DoltDB, r ref.DoltRef) (hash.Hash, error) {
+	cs, _ := doltdb.NewCommitSpec("HEAD", r.String())
+	cm, err := ddb.Resolve(ctx, cs)
+
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	return cm.HashOf()
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"time"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+)
+
+// ReplayCommit three-way merges the changes made by cm onto HEAD, using the first parent of cm as the common ancestor,
+// and updates the working root to the result.  If revert is true the inverse of the changes is applied instead, using
+// cm as the common ancestor and merging in its parent.  If the merge is free of conflicts the result is staged and
+// committed with the message and date given, otherwise the conflicts are left in the working set to be resolved.
+// Returns the MergeStats for the merge keyed by table name.  If committing fails the MergeStats are returned along
+// with the error from CommitStaged, so that callers can tell it apart from errors which occurred before the merge.
+func ReplayCommit(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit, revert bool, msg string, date time.Time) (map[string]*merge.MergeStats, error) {
+	parent, err := dEnv.DoltDB.ResolveParent(ctx, cm, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	headCm, err := dEnv.DoltDB.Resolve(ctx, dEnv.RepoState.CWBHeadSpec())
+
+	if err != nil {
+		return nil, err
+	}
+
+	mergeCm, ancCm := cm, parent
+	if revert {
+		mergeCm, ancCm = parent, cm
+	}
+
+	mergedRoot, tblToStats, err := merge.MergeCommits(ctx, dEnv.DoltDB, headCm, mergeCm, ancCm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	unstagedDocs, err := GetUnstagedDocs(ctx, dEnv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = dEnv.UpdateWorkingRoot(ctx, mergedRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hasMergeConflicts(tblToStats) {
+		return tblToStats, nil
+	}
+
+	err = SaveDocsFromWorkingExcludingFSChanges(ctx, dEnv, unstagedDocs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = dEnv.UpdateStagedRoot(ctx, mergedRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tblToStats, CommitStaged(ctx, dEnv, msg, date, false, nil)
+}
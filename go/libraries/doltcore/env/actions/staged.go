@@ -94,32 +94,62 @@ func StageAllTables(ctx context.Context, dEnv *env.DoltEnv, allowConflicts bool)
 }
 
 func stageTables(ctx context.Context, dEnv *env.DoltEnv, tbls []string, staged *doltdb.RootValue, working *doltdb.RootValue, allowConflicts bool) error {
-	err := ValidateTables(ctx, tbls, staged, working)
+	staged, working, err := StageTablesInRoots(ctx, tbls, staged, working, allowConflicts)
 
 	if err != nil {
 		return err
 	}
 
+	if wh, err := dEnv.DoltDB.WriteRootValue(ctx, working); err == nil {
+		if sh, err := dEnv.DoltDB.WriteRootValue(ctx, staged); err == nil {
+			dEnv.RepoState.Staged = sh.String()
+			dEnv.RepoState.Working = wh.String()
+
+			if err = dEnv.RepoState.Save(dEnv.FS); err != nil {
+				return env.ErrStateUpdate
+			}
+
+			return nil
+		}
+	}
+
+	return doltdb.ErrNomsIO
+}
+
+// StageTablesInRoots copies the tables given from the working root to the staged root, and returns the updated staged
+// and working roots. Resolved conflicts are cleared from the working tables as they are staged. Unless allowConflicts
+// is true, a table in conflict causes an error which can be checked with IsTblInConflict.
+func StageTablesInRoots(ctx context.Context, tbls []string, staged, working *doltdb.RootValue, allowConflicts bool) (*doltdb.RootValue, *doltdb.RootValue, error) {
+	err := ValidateTables(ctx, tbls, staged, working)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if !allowConflicts {
 		var inConflict []string
 		for _, tblName := range tbls {
 			tbl, _, err := working.GetTable(ctx, tblName)
 
 			if err != nil {
-				return err
+				return nil, nil, err
 			}
 
 			if num, err := tbl.NumRowsInConflict(ctx); err != nil {
-				return err
+				return nil, nil, err
 			} else if num > 0 {
 				if !allowConflicts {
 					inConflict = append(inConflict, tblName)
 				}
+			} else if hasSchCnf, err := tbl.HasSchemaConflict(); err != nil {
+				return nil, nil, err
+			} else if hasSchCnf {
+				inConflict = append(inConflict, tblName)
 			}
 		}
 
 		if len(inConflict) > 0 {
-			return NewTblInConflictError(inConflict)
+			return nil, nil, NewTblInConflictError(inConflict)
 		}
 	}
 
@@ -127,25 +157,25 @@ func stageTables(ctx context.Context, dEnv *env.DoltEnv, tbls []string, staged *
 		tbl, _, err := working.GetTable(ctx, tblName)
 
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 
 		has, err := tbl.HasConflicts()
 
 		if has {
 			if num, err := tbl.NumRowsInConflict(ctx); err != nil {
-				return err
+				return nil, nil, err
 			} else if num == 0 {
 				clrTbl, err := tbl.ClearConflicts()
 
 				if err != nil {
-					return err
+					return nil, nil, err
 				}
 
 				working, err = working.PutTable(ctx, tblName, clrTbl)
 
 				if err != nil {
-					return err
+					return nil, nil, err
 				}
 			}
 		}
@@ -154,23 +184,10 @@ func stageTables(ctx context.Context, dEnv *env.DoltEnv, tbls []string, staged *
 	staged, err = staged.UpdateTablesFromOther(ctx, tbls, working)
 
 	if err != nil {
-		return err
-	}
-
-	if wh, err := dEnv.DoltDB.WriteRootValue(ctx, working); err == nil {
-		if sh, err := dEnv.DoltDB.WriteRootValue(ctx, staged); err == nil {
-			dEnv.RepoState.Staged = sh.String()
-			dEnv.RepoState.Working = wh.String()
-
-			if err = dEnv.RepoState.Save(dEnv.FS); err != nil {
-				return env.ErrStateUpdate
-			}
-
-			return nil
-		}
+		return nil, nil, err
 	}
 
-	return doltdb.ErrNomsIO
+	return staged, working, nil
 }
 
 func ValidateTables(ctx context.Context, tbls []string, roots ...*doltdb.RootValue) error {
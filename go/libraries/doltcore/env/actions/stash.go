@@ -0,0 +1,238 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+)
+
+var ErrNothingToStash = errors.New("no local changes to save")
+var ErrStashStagedConflicts = errors.New("the staged changes of the stash entry conflict with the staged tables")
+
+// StashChanges saves the working and staged roots as a new stash entry and then resets both of them to HEAD.  If msg
+// is empty a message describing the current branch and HEAD commit is used.  Changes to docs on the filesystem which
+// have not been added to the working root are left in place.
+func StashChanges(ctx context.Context, dEnv *env.DoltEnv, msg string) (*doltdb.Stash, error) {
+	headCm, err := dEnv.DoltDB.Resolve(ctx, dEnv.RepoState.CWBHeadSpec())
+
+	if err != nil {
+		return nil, err
+	}
+
+	headRoot, err := headCm.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	working, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	staged, err := dEnv.StagedRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	headHash, err := headRoot.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	workingHash, err := working.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	stagedHash, err := staged.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if workingHash == headHash && stagedHash == headHash {
+		return nil, ErrNothingToStash
+	}
+
+	branch := dEnv.RepoState.CWBHeadRef().GetPath()
+	if msg == "" {
+		h, err := headCm.HashOf()
+
+		if err != nil {
+			return nil, err
+		}
+
+		headMeta, err := headCm.GetCommitMeta()
+
+		if err != nil {
+			return nil, err
+		}
+
+		msg = fmt.Sprintf("WIP on %s: %s %s", branch, h.String(), headMeta.Description)
+	} else {
+		msg = fmt.Sprintf("On %s: %s", branch, msg)
+	}
+
+	name, email, err := GetNameAndEmail(dEnv.Config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := doltdb.NewCommitMeta(name, email, msg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stash, err := dEnv.DoltDB.NewStash(ctx, headCm, working, staged, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	unstagedDocs, err := GetUnstagedDocs(ctx, dEnv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = dEnv.UpdateWorkingRoot(ctx, headRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = dEnv.UpdateStagedRoot(ctx, headRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = SaveDocsFromWorkingExcludingFSChanges(ctx, dEnv, unstagedDocs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return stash, nil
+}
+
+// ApplyStash three-way merges the stashed working root onto the current working root, using the commit which was HEAD
+// when the changes were stashed as the common ancestor.  If that merge is free of conflicts the stashed staged root is
+// merged onto the current staged root in the same way, and the result is staged.  If the merge of the staged roots has
+// conflicts ErrStashStagedConflicts is returned and neither root is changed.  Returns the MergeStats for the merge into
+// the working root keyed by table name.
+func ApplyStash(ctx context.Context, dEnv *env.DoltEnv, stash *doltdb.Stash) (map[string]*merge.MergeStats, error) {
+	baseRoot, err := stash.Base.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	stashWorking, err := stash.WorkingRoot()
+
+	if err != nil {
+		return nil, err
+	}
+
+	stashStaged, err := stash.StagedRoot()
+
+	if err != nil {
+		return nil, err
+	}
+
+	working, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	staged, err := dEnv.StagedRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vrw := dEnv.DoltDB.ValueReadWriter()
+	mergedWorking, tblToStats, err := merge.MergeRoots(ctx, vrw, working, stashWorking, baseRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var mergedStaged *doltdb.RootValue
+	if !hasMergeConflicts(tblToStats) {
+		var stagedStats map[string]*merge.MergeStats
+		mergedStaged, stagedStats, err = merge.MergeRoots(ctx, vrw, staged, stashStaged, baseRoot)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if hasMergeConflicts(stagedStats) {
+			return nil, ErrStashStagedConflicts
+		}
+	}
+
+	unstagedDocs, err := GetUnstagedDocs(ctx, dEnv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = dEnv.UpdateWorkingRoot(ctx, mergedWorking)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if mergedStaged != nil {
+		_, err = dEnv.UpdateStagedRoot(ctx, mergedStaged)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = SaveDocsFromWorkingExcludingFSChanges(ctx, dEnv, unstagedDocs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tblToStats, nil
+}
+
+func hasMergeConflicts(tblToStats map[string]*merge.MergeStats) bool {
+	for _, stats := range tblToStats {
+		if stats.Conflicts > 0 || stats.SchemaConflicts > 0 {
+			return true
+		}
+	}
+
+	return false
+}
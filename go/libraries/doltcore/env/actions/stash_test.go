@@ -0,0 +1,73 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+var stashTestSch = dtestutils.CreateSchema(
+	schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+	schema.NewColumn("v", 1, types.IntKind, false),
+)
+
+func setStashTestVal(t *testing.T, dEnv *env.DoltEnv, v int64) {
+	ctx := context.Background()
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	_, err = dtestutils.AddRowToRoot(dEnv, ctx, root, "test", dtestutils.NewRow(stashTestSch, types.Int(1), types.Int(v)))
+	require.NoError(t, err)
+}
+
+func TestApplyStashStagedConflicts(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	dtestutils.CreateTestTable(t, dEnv, "test", stashTestSch, dtestutils.NewRow(stashTestSch, types.Int(1), types.Int(1)))
+	require.NoError(t, StageAllTables(ctx, dEnv, false))
+	require.NoError(t, CommitStaged(ctx, dEnv, "initial", time.Now(), false, nil))
+
+	// the stash stages v = 2 and leaves v = 5 in the working set
+	setStashTestVal(t, dEnv, 2)
+	require.NoError(t, StageAllTables(ctx, dEnv, false))
+	setStashTestVal(t, dEnv, 5)
+	stash, err := StashChanges(ctx, dEnv, "")
+	require.NoError(t, err)
+
+	// the working sets agree on v = 5, but the staged tables disagree
+	setStashTestVal(t, dEnv, 3)
+	require.NoError(t, StageAllTables(ctx, dEnv, false))
+	setStashTestVal(t, dEnv, 5)
+
+	working, staged := dEnv.RepoState.Working, dEnv.RepoState.Staged
+
+	_, err = ApplyStash(ctx, dEnv, stash)
+	assert.Equal(t, ErrStashStagedConflicts, err)
+	assert.Equal(t, working, dEnv.RepoState.Working)
+	assert.Equal(t, staged, dEnv.RepoState.Staged)
+
+	stashes, err := dEnv.DoltDB.GetStashes(ctx)
+	require.NoError(t, err)
+	assert.Len(t, stashes, 1)
+}
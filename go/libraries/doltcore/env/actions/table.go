@@ -51,8 +51,6 @@ func CheckoutTablesAndDocs(ctx context.Context, dEnv *env.DoltEnv, tbls []string
 }
 
 func checkoutTablesAndDocs(ctx context.Context, dEnv *env.DoltEnv, roots map[RootType]*doltdb.RootValue, tbls []string, docs []doltdb.DocDetails) error {
-	unknownTbls := []string{}
-
 	currRoot := roots[WorkingRoot]
 	staged := roots[StagedRoot]
 	head := roots[HeadRoot]
@@ -66,6 +64,24 @@ func checkoutTablesAndDocs(ctx context.Context, dEnv *env.DoltEnv, roots map[Roo
 		staged = stagedWithDocs
 	}
 
+	currRoot, err := CheckoutTablesInRoots(ctx, tbls, currRoot, staged, head)
+	if err != nil {
+		return err
+	}
+
+	err = dEnv.UpdateWorkingRoot(ctx, currRoot)
+	if err != nil {
+		return err
+	}
+
+	return SaveDocsFromDocDetails(dEnv, docs)
+}
+
+// CheckoutTablesInRoots replaces the tables given in the working root with their staged versions, falling back to
+// their versions at head, and returns the updated working root. Tables which are only in the working root are removed.
+func CheckoutTablesInRoots(ctx context.Context, tbls []string, working, staged, head *doltdb.RootValue) (*doltdb.RootValue, error) {
+	unknownTbls := []string{}
+
 	for _, tblName := range tbls {
 		if tblName == doltdb.DocTableName {
 			continue
@@ -73,14 +89,14 @@ func checkoutTablesAndDocs(ctx context.Context, dEnv *env.DoltEnv, roots map[Roo
 		tbl, ok, err := staged.GetTable(ctx, tblName)
 
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if !ok {
 			tbl, ok, err = head.GetTable(ctx, tblName)
 
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			if !ok {
@@ -89,33 +105,28 @@ func checkoutTablesAndDocs(ctx context.Context, dEnv *env.DoltEnv, roots map[Roo
 			}
 		}
 
-		currRoot, err = currRoot.PutTable(ctx, tblName, tbl)
+		working, err = working.PutTable(ctx, tblName, tbl)
 
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	if len(unknownTbls) > 0 {
 		// Return table not exist error before RemoveTables, which fails silently if the table is not on the root.
-		err := validateTablesExist(ctx, currRoot, unknownTbls)
+		err := validateTablesExist(ctx, working, unknownTbls)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		currRoot, err = currRoot.RemoveTables(ctx, unknownTbls...)
+		working, err = working.RemoveTables(ctx, unknownTbls...)
 
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	err := dEnv.UpdateWorkingRoot(ctx, currRoot)
-	if err != nil {
-		return err
-	}
-
-	return SaveDocsFromDocDetails(dEnv, docs)
+	return working, nil
 }
 
 func validateTablesExist(ctx context.Context, currRoot *doltdb.RootValue, unknown []string) error {
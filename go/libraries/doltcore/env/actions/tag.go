@@ -0,0 +1,97 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+)
+
+// CreateTag creates a tag named tagName pointing at the commit startingPoint resolves to.  If msg is non-empty an
+// annotated tag is created using the user configured in dEnv as the tagger.
+func CreateTag(ctx context.Context, dEnv *env.DoltEnv, tagName, startingPoint, msg string, force bool) error {
+	if !ref.IsValidTagName(tagName) {
+		return doltdb.ErrInvTagName
+	}
+
+	tagRef := ref.NewTagRef(tagName)
+
+	hasRef, err := dEnv.DoltDB.HasRef(ctx, tagRef)
+
+	if err != nil {
+		return err
+	}
+
+	if hasRef && !force {
+		return ErrAlreadyExists
+	}
+
+	cs, err := doltdb.NewCommitSpec(startingPoint, dEnv.RepoState.CWBHeadRef().String())
+
+	if err != nil {
+		return err
+	}
+
+	cm, err := dEnv.DoltDB.Resolve(ctx, cs)
+
+	if err != nil {
+		return err
+	}
+
+	var meta *doltdb.TagMeta
+	if msg != "" {
+		name, email, err := GetNameAndEmail(dEnv.Config)
+
+		if err != nil {
+			return err
+		}
+
+		meta, err = doltdb.NewTagMeta(name, email, msg)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if hasRef {
+		err = dEnv.DoltDB.DeleteTag(ctx, tagRef)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return dEnv.DoltDB.NewTagAtCommit(ctx, tagRef, cm, meta)
+}
+
+// DeleteTags deletes the tags with the given names
+func DeleteTags(ctx context.Context, dEnv *env.DoltEnv, tagNames ...string) error {
+	for _, tagName := range tagNames {
+		if !ref.IsValidTagName(tagName) {
+			return doltdb.ErrInvTagName
+		}
+
+		err := dEnv.DoltDB.DeleteTag(ctx, ref.NewTagRef(tagName))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
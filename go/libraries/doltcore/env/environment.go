@@ -79,14 +79,35 @@ type DoltEnv struct {
 	FS     filesys.Filesys
 	urlStr string
 	hdp    HomeDirProvider
+
+	// mainFS is the filesystem of the main worktree of the repository when the environment is a linked worktree, and
+	// nil otherwise.
+	mainFS filesys.Filesys
 }
 
-// Load loads the DoltEnv for the current directory of the cli
+// Load loads the DoltEnv for the current directory of the cli.  If the directory is a linked worktree, the database and
+// local config of the repository it belongs to are loaded.
 func Load(ctx context.Context, hdp HomeDirProvider, fs filesys.Filesys, urlStr, version string) *DoltEnv {
-	config, cfgErr := loadDoltCliConfig(hdp, fs)
+	mainDir, mainFS, wtErr := loadWorktreeMain(fs)
+	cfgFS := fs
+	if mainFS != nil {
+		cfgFS = mainFS
+		urlStr = worktreeDBUrl(mainDir)
+	}
+
+	config, cfgErr := loadDoltCliConfig(hdp, cfgFS)
 	repoState, rsErr := LoadRepoState(fs)
+	if rsErr == nil && mainFS != nil {
+		rsErr = repoState.loadShared(mainFS)
+	}
+
 	docs, docsErr := LoadDocs(fs)
-	ddb, dbLoadErr := doltdb.LoadDoltDB(ctx, types.Format_Default, urlStr)
+
+	var ddb *doltdb.DoltDB
+	dbLoadErr := wtErr
+	if dbLoadErr == nil {
+		ddb, dbLoadErr = doltdb.LoadDoltDB(ctx, types.Format_Default, urlStr)
+	}
 
 	dEnv := &DoltEnv{
 		version,
@@ -101,6 +122,7 @@ func Load(ctx context.Context, hdp HomeDirProvider, fs filesys.Filesys, urlStr,
 		fs,
 		urlStr,
 		hdp,
+		mainFS,
 	}
 
 	if dbLoadErr == nil && dEnv.HasDoltDir() {
@@ -126,18 +148,62 @@ func Load(ctx context.Context, hdp HomeDirProvider, fs filesys.Filesys, urlStr,
 		}
 	}
 
+	dEnv.initRefLog()
+	dEnv.initShallowCommits()
 	dbfactory.InitializeFactories(dEnv)
+	dEnv.initLazyClone(ctx)
 
 	return dEnv
 }
 
+// initRefLog sets up recording of updates to refs and to the working and staged roots in the reflog stored in the
+// .dolt directory.
+func (dEnv *DoltEnv) initRefLog() {
+	if dEnv.DoltDB == nil || dEnv.Config == nil || !dEnv.HasDoltDir() {
+		return
+	}
+
+	refLog := NewFileRefLog(dEnv.FS, dEnv.Config)
+	dEnv.DoltDB.SetRefLog(refLog)
+
+	if dEnv.RepoState != nil {
+		dEnv.RepoState.refLog = refLog
+	}
+}
+
+// initLazyClone makes chunks which are missing from the database of a lazy clone be read from the database of the
+// remote it was cloned from.
+func (dEnv *DoltEnv) initLazyClone(ctx context.Context) {
+	if dEnv.DoltDB == nil || dEnv.RepoState == nil || dEnv.RepoState.LazyRemote == "" {
+		return
+	}
+
+	remote, ok := dEnv.RepoState.Remotes[dEnv.RepoState.LazyRemote]
+
+	if !ok {
+		dEnv.DBLoadError = fmt.Errorf("the remote '%s' that this lazy clone reads from does not exist", dEnv.RepoState.LazyRemote)
+		return
+	}
+
+	srcDB, err := remote.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+
+	if err != nil {
+		dEnv.DBLoadError = err
+		return
+	}
+
+	dEnv.DoltDB.SetLazyRemote(srcDB)
+}
+
 // HasDoltDir returns true if the .dolt directory exists and is a valid directory
 func (dEnv *DoltEnv) HasDoltDir() bool {
 	return dEnv.hasDoltDir("./")
 }
 
+// HasDoltDataDir returns true if the directory holding the database exists.  For a linked worktree this is the
+// directory of the repository it belongs to.
 func (dEnv *DoltEnv) HasDoltDataDir() bool {
-	exists, isDir := dEnv.FS.Exists(dbfactory.DoltDataDir)
+	exists, isDir := dEnv.sharedFS().Exists(dbfactory.DoltDataDir)
 	return exists && isDir
 }
 
@@ -253,7 +319,12 @@ func (dEnv *DoltEnv) InitRepoWithNoData(ctx context.Context, nbf *types.NomsBinF
 
 	dEnv.DoltDB, err = doltdb.LoadDoltDB(ctx, nbf, dEnv.urlStr)
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	dEnv.initRefLog()
+	return nil
 }
 
 func (dEnv *DoltEnv) createDirectories(dir string) (string, error) {
@@ -316,6 +387,8 @@ func (dEnv *DoltEnv) InitDBWithTime(ctx context.Context, nbf *types.NomsBinForma
 		return err
 	}
 
+	dEnv.initRefLog()
+
 	err = dEnv.DoltDB.WriteEmptyRepoWithCommitTime(ctx, name, email, t)
 	if err != nil {
 		return doltdb.ErrNomsIO
@@ -344,6 +417,7 @@ func (dEnv *DoltEnv) initializeRepoState(ctx context.Context) error {
 		return ErrStateUpdate
 	}
 
+	dEnv.initRefLog()
 	dEnv.RSLoadErr = nil
 	return nil
 }
@@ -377,6 +451,29 @@ func (r *repoStateWriter) SetWorkingHash(ctx context.Context, h hash.Hash) error
 	return nil
 }
 
+func (r *repoStateWriter) SetStagedHash(ctx context.Context, h hash.Hash) error {
+	r.dEnv.RepoState.Staged = h.String()
+	err := r.dEnv.RepoState.Save(r.dEnv.FS)
+
+	if err != nil {
+		return ErrStateUpdate
+	}
+
+	return nil
+}
+
+// LockWorktrees implements BranchChecker.
+func (r *repoStateWriter) LockWorktrees() (func() error, error) {
+	return r.dEnv.LockWorktrees()
+}
+
+// CheckBranchAvailable implements BranchChecker.
+func (r *repoStateWriter) CheckBranchAvailable(dref ref.DoltRef) error {
+	return r.dEnv.CheckBranchAvailable(dref)
+}
+
+var _ BranchChecker = &repoStateWriter{}
+
 func (dEnv *DoltEnv) RepoStateWriter() RepoStateWriter {
 	return &repoStateWriter{dEnv}
 }
@@ -463,6 +560,22 @@ func (dEnv *DoltEnv) IsMergeActive() bool {
 	return dEnv.RepoState.Merge != nil
 }
 
+func (dEnv *DoltEnv) IsCherryPickActive() bool {
+	return dEnv.RepoState.CherryPick != nil
+}
+
+func (dEnv *DoltEnv) IsRevertActive() bool {
+	return dEnv.RepoState.Revert != nil
+}
+
+func (dEnv *DoltEnv) IsRebaseActive() bool {
+	return dEnv.RepoState.Rebase != nil
+}
+
+func (dEnv *DoltEnv) IsBisectActive() bool {
+	return dEnv.RepoState.Bisect != nil
+}
+
 func (dEnv *DoltEnv) GetTablesWithConflicts(ctx context.Context) ([]string, error) {
 	root, err := dEnv.WorkingRoot(ctx)
 
@@ -492,6 +605,12 @@ func (dEnv *DoltEnv) MergeWouldStompChanges(ctx context.Context, mergeCommit *do
 		return nil, nil, err
 	}
 
+	return MergeWouldStompChanges(ctx, headRoot, workingRoot, mergeRoot)
+}
+
+// MergeWouldStompChanges returns the names of the tables with working changes which would be overwritten by merging
+// mergeRoot, along with the hashes of all the tables changed in workingRoot relative to headRoot.
+func MergeWouldStompChanges(ctx context.Context, headRoot, workingRoot, mergeRoot *doltdb.RootValue) ([]string, map[string]hash.Hash, error) {
 	headTableHashes, err := mapTableHashes(ctx, headRoot)
 
 	if err != nil {
@@ -575,6 +694,11 @@ func (dEnv *DoltEnv) CredsDir() (string, error) {
 	return getCredsDir(dEnv.hdp)
 }
 
+// TrustedKeysPath returns the path of the file listing the public keys whose commit signatures are trusted.
+func (dEnv *DoltEnv) TrustedKeysPath() (string, error) {
+	return getTrustedKeysPath(dEnv.hdp)
+}
+
 func (dEnv *DoltEnv) UserRPCCreds() (creds.DoltCreds, bool, error) {
 	kid, err := dEnv.Config.GetString(UserCreds)
 
@@ -703,21 +827,20 @@ func (dEnv *DoltEnv) FindRef(ctx context.Context, refStr string) (ref.DoltRef, e
 	} else if hasRef {
 		return localRef, nil
 	} else {
+		// remote-tracking branches are found even if their remote has been removed, or was never added, as is the case
+		// for branches fetched from a bundle
 		slashIdx := strings.IndexRune(refStr, '/')
 		if slashIdx > 0 {
-			remoteName := refStr[:slashIdx]
-			if _, ok := dEnv.RepoState.Remotes[remoteName]; ok {
-				remoteRef, err := ref.NewRemoteRefFromPathStr(refStr)
-
-				if err != nil {
-					return nil, err
-				}
-
-				if hasRef, err = dEnv.DoltDB.HasRef(ctx, remoteRef); err != nil {
-					return nil, err
-				} else if hasRef {
-					return remoteRef, nil
-				}
+			remoteRef, err := ref.NewRemoteRefFromPathStr(refStr)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if hasRef, err = dEnv.DoltDB.HasRef(ctx, remoteRef); err != nil {
+				return nil, err
+			} else if hasRef {
+				return remoteRef, nil
 			}
 		}
 	}
@@ -725,8 +848,35 @@ func (dEnv *DoltEnv) FindRef(ctx context.Context, refStr string) (ref.DoltRef, e
 	return nil, doltdb.ErrBranchNotFound
 }
 
+// GetUpstream returns the remote tracking ref which the upstream of branch, as recorded by dolt push --set-upstream, is
+// fetched into.  false is returned if branch has no upstream, or if the remote of its upstream no longer exists.
+func (dEnv *DoltEnv) GetUpstream(branch ref.DoltRef) (ref.DoltRef, bool, errhand.VerboseError) {
+	upstream, ok := dEnv.RepoState.Branches[branch.GetPath()]
+
+	if !ok {
+		return nil, false, nil
+	} else if _, ok := dEnv.RepoState.Remotes[upstream.Remote]; !ok {
+		return nil, false, nil
+	}
+
+	refSpecs, verr := dEnv.GetRefSpecs(upstream.Remote)
+
+	if verr != nil {
+		return nil, false, verr
+	}
+
+	for _, rs := range refSpecs {
+		if trackingRef := rs.DestRef(upstream.Merge.Ref); trackingRef != nil && trackingRef.GetType() == ref.RemoteRefType {
+			return trackingRef, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
 // GetRefSpecs takes an optional remoteName and returns all refspecs associated with that remote.  If "" is passed as
-// the remoteName then the default remote is used.
+// the remoteName then the default remote is used.  Tags are always fetched, so if the remote doesn't have a fetch spec
+// for tags then DefaultTagRefSpec is included.
 func (dEnv *DoltEnv) GetRefSpecs(remoteName string) ([]ref.RemoteRefSpec, errhand.VerboseError) {
 	var remote Remote
 	var verr errhand.VerboseError
@@ -744,6 +894,7 @@ func (dEnv *DoltEnv) GetRefSpecs(remoteName string) ([]ref.RemoteRefSpec, errhan
 	}
 
 	var refSpecs []ref.RemoteRefSpec
+	hasTagSpec := false
 	for _, fs := range remote.FetchSpecs {
 		rs, err := ref.ParseRefSpecForRemote(remote.Name, fs)
 
@@ -756,10 +907,22 @@ func (dEnv *DoltEnv) GetRefSpecs(remoteName string) ([]ref.RemoteRefSpec, errhan
 		} else if rrs.GetRemote() != remote.Name {
 			return nil, errhand.BuildDError("error: remote '%s' refers to remote '%s'", remote.Name, rrs.GetRemote()).Build()
 		} else {
+			_, isTagSpec := rrs.(ref.TagToTagRefSpec)
+			hasTagSpec = hasTagSpec || isTagSpec
 			refSpecs = append(refSpecs, rrs)
 		}
 	}
 
+	if !hasTagSpec {
+		rs, err := ref.ParseRefSpecForRemote(remote.Name, DefaultTagRefSpec)
+
+		if err != nil {
+			return nil, errhand.BuildDError("error: '%s' is not a valid refspec.", DefaultTagRefSpec).Build()
+		}
+
+		refSpecs = append(refSpecs, rs.(ref.RemoteRefSpec))
+	}
+
 	return refSpecs, nil
 }
 
@@ -793,7 +956,13 @@ func (dEnv *DoltEnv) GetUserHomeDir() (string, error) {
 }
 
 func (dEnv *DoltEnv) TempTableFilesDir() string {
-	return mustAbs(dEnv, dEnv.GetDoltDir(), tempTablesDir)
+	doltDir := dEnv.GetDoltDir()
+	if dEnv.mainFS != nil {
+		// linked worktrees use the directory of the main worktree, beside the database the files are added to
+		doltDir, _ = dEnv.mainFS.Abs(dbfactory.DoltDir)
+	}
+
+	return mustAbs(dEnv, doltDir, tempTablesDir)
 }
 
 func (dEnv *DoltEnv) GetAllValidDocDetails() (docs []doltdb.DocDetails, err error) {
@@ -52,7 +52,7 @@ func createTestEnv(isInitialized bool, hasLocalConfig bool) *DoltEnv {
 
 		hashStr := hash.Hash{}.String()
 		masterRef := ref.NewBranchRef("master")
-		repoState := &RepoState{ref.MarshalableRef{Ref: masterRef}, hashStr, hashStr, nil, nil, nil}
+		repoState := &RepoState{Head: ref.MarshalableRef{Ref: masterRef}, Staged: hashStr, Working: hashStr}
 		repoStateData, err := json.Marshal(repoState)
 
 		if err != nil {
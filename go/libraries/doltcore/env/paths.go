@@ -26,11 +26,17 @@ const (
 	homeEnvVar         = "HOME"
 	doltRootPathEnvVar = "DOLT_ROOT_PATH"
 	credsDir           = "creds"
+	trustedKeysFile    = "trusted_keys"
 
 	configFile   = "config.json"
 	globalConfig = "config_global.json"
 
 	repoStateFile = "repo_state.json"
+	refLogsDir    = "logs"
+	shallowFile   = "shallow"
+	worktreeFile  = "worktree"
+	worktreesDir  = "worktrees"
+	worktreesLock = "worktrees.lock"
 
 	ReadmeFile  = "../README.md"
 	LicenseFile = "../LICENSE.md"
@@ -66,6 +72,15 @@ func getCredsDir(hdp HomeDirProvider) (string, error) {
 	return filepath.Join(homeDir, dbfactory.DoltDir, credsDir), nil
 }
 
+func getTrustedKeysPath(hdp HomeDirProvider) (string, error) {
+	homeDir, err := hdp()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, dbfactory.DoltDir, trustedKeysFile), nil
+}
+
 func getGlobalCfgPath(hdp HomeDirProvider) (string, error) {
 	homeDir, err := hdp()
 	if err != nil {
@@ -91,6 +106,26 @@ func getHomeDir(hdp HomeDirProvider) (string, error) {
 	return homeDir, nil
 }
 
+func getRefLogFile(name string) string {
+	return filepath.Join(dbfactory.DoltDir, refLogsDir, filepath.FromSlash(name))
+}
+
+func getShallowFile() string {
+	return filepath.Join(dbfactory.DoltDir, shallowFile)
+}
+
+func getWorktreeFile() string {
+	return filepath.Join(dbfactory.DoltDir, worktreeFile)
+}
+
+func getWorktreesDir() string {
+	return filepath.Join(dbfactory.DoltDir, worktreesDir)
+}
+
+func getWorktreesLockFile() string {
+	return filepath.Join(dbfactory.DoltDir, worktreesLock)
+}
+
 func getDocFile(filename string) string {
 	return filepath.Join(dbfactory.DoltDir, filename)
 }
@@ -0,0 +1,180 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/config"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+const (
+	// WorkingRefLogName is the name of the reflog recording changes to the working root
+	WorkingRefLogName = "WORKING"
+
+	// StagedRefLogName is the name of the reflog recording changes to the staged root
+	StagedRefLogName = "STAGED"
+)
+
+// FileRefLog is a doltdb.RefLog which stores the log for each ref in a file inside the .dolt/logs directory. Each line
+// of a file is a json encoded doltdb.RefLogEntry, and new entries are appended to the end of the file.
+type FileRefLog struct {
+	fs  filesys.Filesys
+	cfg config.ReadableConfig
+}
+
+var _ doltdb.RefLog = (*FileRefLog)(nil)
+
+// NewFileRefLog returns a FileRefLog which stores logs using the filesystem given, and records the user name and email
+// found in the config given with each entry.
+func NewFileRefLog(fs filesys.Filesys, cfg config.ReadableConfig) *FileRefLog {
+	return &FileRefLog{fs, cfg}
+}
+
+// Append records that the ref with the given name was updated from oldHash to newHash.
+func (rl *FileRefLog) Append(ctx context.Context, name string, oldHash, newHash hash.Hash, msg string) error {
+	var userName, email string
+	if rl.cfg != nil {
+		userName, _ = rl.cfg.GetString(UserNameKey)
+		email, _ = rl.cfg.GetString(UserEmailKey)
+	}
+
+	entry := doltdb.RefLogEntry{
+		OldHash:   oldHash.String(),
+		NewHash:   newHash.String(),
+		Name:      userName,
+		Email:     email,
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+		Message:   strings.Replace(msg, "\n", " ", -1),
+	}
+
+	line, err := json.Marshal(entry)
+
+	if err != nil {
+		return err
+	}
+
+	path := getRefLogFile(name)
+	err = rl.fs.MkDirs(filepath.Dir(path))
+
+	if err != nil {
+		return err
+	}
+
+	wr, err := rl.fs.OpenForAppend(path, os.ModePerm)
+
+	if err != nil {
+		return err
+	}
+
+	// the entry is written with a single write so that entries appended by concurrent processes are not interleaved
+	_, err = wr.Write(append(line, '\n'))
+
+	if err != nil {
+		wr.Close()
+		return err
+	}
+
+	return wr.Close()
+}
+
+// Entries returns the entries recorded for the ref with the given name, most recent first.
+func (rl *FileRefLog) Entries(ctx context.Context, name string) ([]doltdb.RefLogEntry, error) {
+	path := getRefLogFile(name)
+
+	if exists, _ := rl.fs.Exists(path); !exists {
+		return nil, nil
+	}
+
+	data, err := rl.fs.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []doltdb.RefLogEntry
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var entry doltdb.RefLogEntry
+		err = json.Unmarshal(line, &entry)
+
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	return entries, nil
+}
+
+// Names returns the names of all the refs which have entries in the log.
+func (rl *FileRefLog) Names(ctx context.Context) ([]string, error) {
+	logsDir, err := rl.fs.Abs(getRefLogFile(""))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if exists, isDir := rl.fs.Exists(logsDir); !exists || !isDir {
+		return nil, nil
+	}
+
+	var names []string
+	var relErr error
+	err = rl.fs.Iter(logsDir, true, func(path string, size int64, isDir bool) (stop bool) {
+		if isDir {
+			return false
+		}
+
+		var rel string
+		rel, relErr = filepath.Rel(logsDir, path)
+
+		if relErr != nil {
+			return true
+		}
+
+		names = append(names, filepath.ToSlash(rel))
+		return false
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if relErr != nil {
+		return nil, relErr
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
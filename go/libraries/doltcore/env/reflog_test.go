@@ -0,0 +1,72 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestFileRefLog(t *testing.T) {
+	ctx := context.Background()
+	dEnv := createTestEnv(false, false)
+	err := dEnv.InitRepo(ctx, types.Format_7_18, "aoeu aoeu", "aoeu@aoeu.org")
+	require.NoError(t, err)
+
+	refLog := dEnv.DoltDB.RefLog()
+	require.NotNil(t, refLog)
+
+	entries, err := refLog.Entries(ctx, ref.NewBranchRef("master").String())
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "commit (initial): Initialize data repository", entries[0].Message)
+
+	workingHash := dEnv.RepoState.WorkingHash()
+	newWorkingHash := hash.Of([]byte("new working root"))
+	err = dEnv.RepoStateWriter().SetWorkingHash(ctx, newWorkingHash)
+	require.NoError(t, err)
+
+	entries, err = refLog.Entries(ctx, WorkingRefLogName)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, workingHash.String(), entries[0].OldHash)
+	assert.Equal(t, newWorkingHash.String(), entries[0].NewHash)
+
+	entries, err = refLog.Entries(ctx, StagedRefLogName)
+	require.NoError(t, err)
+	assert.Len(t, entries, 0)
+
+	err = refLog.Append(ctx, "refs/heads/other", hash.Hash{}, workingHash, "first\nline")
+	require.NoError(t, err)
+	err = refLog.Append(ctx, "refs/heads/other", workingHash, hash.Hash{}, "second")
+	require.NoError(t, err)
+
+	entries, err = refLog.Entries(ctx, "refs/heads/other")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "second", entries[0].Message)
+	assert.Equal(t, "first line", entries[1].Message)
+
+	names, err := refLog.Names(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"WORKING", "refs/heads/master", "refs/heads/other"}, names)
+}
@@ -23,6 +23,9 @@ import (
 
 var NoRemote = Remote{}
 
+// DefaultTagRefSpec is the refspec used to fetch tags from a remote.  Tags are fetched to the tag of the same name.
+const DefaultTagRefSpec = "refs/tags/*:refs/tags/*"
+
 type Remote struct {
 	Name       string            `json:"name"`
 	Url        string            `json:"url"`
@@ -15,8 +15,10 @@
 package env
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
@@ -35,7 +37,15 @@ type RepoStateWriter interface {
 	// SetCWBHeadRef(context.Context, ref.DoltRef) error
 	// SetCWBHeadSpec(context.Context, *doltdb.CommitSpec) error
 	SetWorkingHash(context.Context, hash.Hash) error
-	//	SetStagedHash(context.Context, hash.Hash) error
+	SetStagedHash(context.Context, hash.Hash) error
+}
+
+// BranchChecker is implemented by the RepoStateWriter of an environment to check that a branch is not checked out in
+// another worktree of the repository before the branch is moved or deleted.  CheckBranchAvailable must be called with
+// the lock taken by LockWorktrees held.
+type BranchChecker interface {
+	LockWorktrees() (func() error, error)
+	CheckBranchAvailable(dref ref.DoltRef) error
 }
 
 type BranchConfig struct {
@@ -49,13 +59,75 @@ type MergeState struct {
 	PreMergeWorking string             `json:"working_pre_merge"`
 }
 
+type CherryPickState struct {
+	Commit string `json:"commit"`
+}
+
+// RevertState is the state of a revert which stopped because of conflicts.  Commit is the commit being reverted.
+type RevertState struct {
+	Commit string `json:"commit"`
+}
+
+// RebaseState is the state of a rebase which is in progress.  Branch is the branch being rebased, OrigHead is the
+// commit it pointed at before the rebase began, and Onto is the commit the branch is being rebased onto.  Current is
+// the commit which was being replayed when the rebase stopped, if any, and Remaining lists the commits which are yet
+// to be replayed in the order they will be applied.
+type RebaseState struct {
+	Branch    ref.MarshalableRef `json:"branch"`
+	OrigHead  string             `json:"orig_head"`
+	Onto      string             `json:"onto"`
+	Current   string             `json:"current"`
+	Remaining []string           `json:"remaining"`
+}
+
+// BisectState is the state of a bisect which is in progress.  Branch is the branch which was checked out when the bisect
+// began, and PreBisectWorking and PreBisectStaged are the working and staged roots at that time.  Bad, Good and Skipped
+// are the hashes of the commits which have been marked, and Current is the commit whose root is currently in the
+// working set.
+type BisectState struct {
+	Branch           ref.MarshalableRef `json:"branch"`
+	PreBisectWorking string             `json:"working_pre_bisect"`
+	PreBisectStaged  string             `json:"staged_pre_bisect"`
+	Bad              string             `json:"bad"`
+	Good             []string           `json:"good"`
+	Skipped          []string           `json:"skipped"`
+	Current          string             `json:"current"`
+}
+
 type RepoState struct {
-	Head     ref.MarshalableRef      `json:"head"`
-	Staged   string                  `json:"staged"`
-	Working  string                  `json:"working"`
-	Merge    *MergeState             `json:"merge"`
-	Remotes  map[string]Remote       `json:"remotes"`
-	Branches map[string]BranchConfig `json:"branches"`
+	Head       ref.MarshalableRef      `json:"head"`
+	Staged     string                  `json:"staged"`
+	Working    string                  `json:"working"`
+	Merge      *MergeState             `json:"merge"`
+	Remotes    map[string]Remote       `json:"remotes"`
+	Branches   map[string]BranchConfig `json:"branches"`
+	CherryPick *CherryPickState        `json:"cherry_pick,omitempty"`
+	Revert     *RevertState            `json:"revert,omitempty"`
+	Rebase     *RebaseState            `json:"rebase,omitempty"`
+	Bisect     *BisectState            `json:"bisect,omitempty"`
+
+	// LazyRemote is the name of the remote which a lazy clone was cloned from.  Chunks which are missing from the
+	// database are read from it.
+	LazyRemote string `json:"lazy_remote,omitempty"`
+
+	// refLog is where changes to the working and staged root hashes are recorded when the repo state is saved.
+	// savedWorking and savedStaged are the hashes as of the last time the repo state was loaded or saved.
+	refLog       doltdb.RefLog
+	savedWorking string
+	savedStaged  string
+
+	// mainFS is the filesystem of the main worktree when this is the repo state of a linked worktree.  The remotes,
+	// branch configuration and lazy remote of the repository are only stored in the repo state of the main worktree.
+	// savedShared is their encoding as of the last time they were loaded or saved.
+	mainFS      filesys.ReadWriteFS
+	savedShared []byte
+}
+
+// sharedRepoState is the part of the repo state which is shared by all the worktrees of a repository.
+type sharedRepoState struct {
+	Remotes    map[string]Remote       `json:"remotes"`
+	Branches   map[string]BranchConfig `json:"branches"`
+	LazyRemote string                  `json:"lazy_remote"`
 }
 
 func LoadRepoState(fs filesys.ReadWriteFS) (*RepoState, error) {
@@ -73,19 +145,21 @@ func LoadRepoState(fs filesys.ReadWriteFS) (*RepoState, error) {
 		return nil, err
 	}
 
+	repoState.savedWorking = repoState.Working
+	repoState.savedStaged = repoState.Staged
+
 	return &repoState, nil
 }
 
 func CloneRepoState(fs filesys.ReadWriteFS, r Remote) (*RepoState, error) {
 	h := hash.Hash{}
 	hashStr := h.String()
-	rs := &RepoState{ref.MarshalableRef{
-		Ref: ref.NewBranchRef("master")},
-		hashStr,
-		hashStr,
-		nil,
-		map[string]Remote{r.Name: r},
-		make(map[string]BranchConfig),
+	rs := &RepoState{
+		Head:     ref.MarshalableRef{Ref: ref.NewBranchRef("master")},
+		Staged:   hashStr,
+		Working:  hashStr,
+		Remotes:  map[string]Remote{r.Name: r},
+		Branches: make(map[string]BranchConfig),
 	}
 
 	err := rs.Save(fs)
@@ -106,12 +180,11 @@ func CreateRepoState(fs filesys.ReadWriteFS, br string, rootHash hash.Hash) (*Re
 	}
 
 	rs := &RepoState{
-		ref.MarshalableRef{Ref: headRef},
-		hashStr,
-		hashStr,
-		nil,
-		make(map[string]Remote),
-		make(map[string]BranchConfig),
+		Head:     ref.MarshalableRef{Ref: headRef},
+		Staged:   hashStr,
+		Working:  hashStr,
+		Remotes:  make(map[string]Remote),
+		Branches: make(map[string]BranchConfig),
 	}
 
 	err = rs.Save(fs)
@@ -123,16 +196,122 @@ func CreateRepoState(fs filesys.ReadWriteFS, br string, rootHash hash.Hash) (*Re
 	return rs, nil
 }
 
+// loadShared reads the remotes, branch configuration and lazy remote of the repo state of a linked worktree from the
+// repo state of the main worktree in mainFS, which is where they are saved to.
+func (rs *RepoState) loadShared(mainFS filesys.ReadWriteFS) error {
+	mainRS, err := LoadRepoState(mainFS)
+
+	if err != nil {
+		return err
+	}
+
+	rs.Remotes, rs.Branches, rs.LazyRemote = mainRS.Remotes, mainRS.Branches, mainRS.LazyRemote
+	rs.mainFS = mainFS
+	rs.savedShared, err = rs.encodeShared()
+
+	return err
+}
+
+func (rs *RepoState) encodeShared() ([]byte, error) {
+	return json.Marshal(sharedRepoState{rs.Remotes, rs.Branches, rs.LazyRemote})
+}
+
+// saveShared writes the remotes, branch configuration and lazy remote of the repo state of a linked worktree to the
+// repo state of the main worktree if they have changed.
+func (rs *RepoState) saveShared() error {
+	data, err := rs.encodeShared()
+
+	if err != nil || bytes.Equal(data, rs.savedShared) {
+		return err
+	}
+
+	mainRS, err := LoadRepoState(rs.mainFS)
+
+	if err != nil {
+		return err
+	}
+
+	mainRS.Remotes, mainRS.Branches, mainRS.LazyRemote = rs.Remotes, rs.Branches, rs.LazyRemote
+	err = mainRS.Save(rs.mainFS)
+
+	if err != nil {
+		return err
+	}
+
+	rs.savedShared = data
+	return nil
+}
+
 func (rs *RepoState) Save(fs filesys.ReadWriteFS) error {
-	data, err := json.MarshalIndent(rs, "", "  ")
+	toSave := rs
+	if rs.mainFS != nil {
+		err := rs.saveShared()
+
+		if err != nil {
+			return err
+		}
+
+		wtRS := *rs
+		wtRS.Remotes = make(map[string]Remote)
+		wtRS.Branches = make(map[string]BranchConfig)
+		wtRS.LazyRemote = ""
+		toSave = &wtRS
+	}
+
+	data, err := json.MarshalIndent(toSave, "", "  ")
 
 	if err != nil {
 		return err
 	}
 
 	path := getRepoStateFile()
+	err = fs.WriteFile(path, data)
+
+	if err != nil {
+		return err
+	}
+
+	return rs.logRootUpdates()
+}
+
+// logRootUpdates records any changes made to the working and staged root hashes since the repo state was last loaded
+// or saved in the reflog.
+func (rs *RepoState) logRootUpdates() error {
+	if rs.refLog == nil {
+		rs.savedWorking, rs.savedStaged = rs.Working, rs.Staged
+		return nil
+	}
+
+	ctx := context.Background()
+	branch := rs.CWBHeadRef().GetPath()
+
+	if rs.Working != rs.savedWorking {
+		msg := fmt.Sprintf("working set updated on %s", branch)
+		oldHash, _ := hash.MaybeParse(rs.savedWorking)
+		newHash, _ := hash.MaybeParse(rs.Working)
+		err := rs.refLog.Append(ctx, WorkingRefLogName, oldHash, newHash, msg)
+
+		if err != nil {
+			return err
+		}
+
+		rs.savedWorking = rs.Working
+	}
 
-	return fs.WriteFile(path, data)
+	if rs.Staged != rs.savedStaged {
+		msg := fmt.Sprintf("staged tables updated on %s", branch)
+		oldHash, _ := hash.MaybeParse(rs.savedStaged)
+		newHash, _ := hash.MaybeParse(rs.Staged)
+		err := rs.refLog.Append(ctx, StagedRefLogName, oldHash, newHash, msg)
+
+		if err != nil {
+			return err
+		}
+
+		rs.savedStaged = rs.Staged
+	}
+
+	return nil
 }
 
 func (rs *RepoState) CWBHeadRef() ref.DoltRef {
@@ -160,6 +339,68 @@ func (rs *RepoState) ClearMerge(fs filesys.Filesys) error {
 	return rs.Save(fs)
 }
 
+// StartCherryPick records that the commit with the given hash is being cherry-picked onto the current working set.
+func (rs *RepoState) StartCherryPick(commit string, fs filesys.Filesys) error {
+	rs.CherryPick = &CherryPickState{commit}
+	return rs.Save(fs)
+}
+
+func (rs *RepoState) ClearCherryPick(fs filesys.Filesys) error {
+	rs.CherryPick = nil
+	return rs.Save(fs)
+}
+
+// StartRevert records that the commit with the given hash is being reverted in the current working set.
+func (rs *RepoState) StartRevert(commit string, fs filesys.Filesys) error {
+	rs.Revert = &RevertState{commit}
+	return rs.Save(fs)
+}
+
+func (rs *RepoState) ClearRevert(fs filesys.Filesys) error {
+	rs.Revert = nil
+	return rs.Save(fs)
+}
+
+// StartRebase records that the branch given, which was at the commit origHead, is being rebased onto the commit onto
+// by replaying the commits in remaining in order.
+func (rs *RepoState) StartRebase(branch ref.DoltRef, origHead, onto string, remaining []string, fs filesys.Filesys) error {
+	rs.Rebase = &RebaseState{ref.MarshalableRef{Ref: branch}, origHead, onto, "", remaining}
+	return rs.Save(fs)
+}
+
+// NextRebaseCommit pops the next commit to be replayed off the list of remaining commits and records it as the
+// current commit of the rebase.  Returns false if there are no commits remaining.
+func (rs *RepoState) NextRebaseCommit(fs filesys.Filesys) (string, bool, error) {
+	if len(rs.Rebase.Remaining) == 0 {
+		rs.Rebase.Current = ""
+		return "", false, rs.Save(fs)
+	}
+
+	rs.Rebase.Current = rs.Rebase.Remaining[0]
+	rs.Rebase.Remaining = rs.Rebase.Remaining[1:]
+
+	return rs.Rebase.Current, true, rs.Save(fs)
+}
+
+func (rs *RepoState) ClearRebase(fs filesys.Filesys) error {
+	rs.Rebase = nil
+	return rs.Save(fs)
+}
+
+// StartBisect records that a bisect has begun on the current branch.
+func (rs *RepoState) StartBisect(fs filesys.Filesys) error {
+	rs.Bisect = &BisectState{Branch: rs.Head, PreBisectWorking: rs.Working, PreBisectStaged: rs.Staged}
+	return rs.Save(fs)
+}
+
+// ResetBisect restores the working and staged roots from before the bisect began, and clears the bisect state.
+func (rs *RepoState) ResetBisect(fs filesys.Filesys) error {
+	rs.Working = rs.Bisect.PreBisectWorking
+	rs.Staged = rs.Bisect.PreBisectStaged
+	rs.Bisect = nil
+	return rs.Save(fs)
+}
+
 func (rs *RepoState) AddRemote(r Remote) {
 	rs.Remotes[r.Name] = r
 }
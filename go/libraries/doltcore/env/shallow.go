@@ -0,0 +1,111 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// LoadShallowCommits reads the hashes of the commits on the boundary of a shallow clone from the .dolt/shallow file.
+// An empty set is returned if the repository is not a shallow clone.
+func LoadShallowCommits(fs filesys.ReadableFS) (hash.HashSet, error) {
+	shallow := hash.HashSet{}
+	path := getShallowFile()
+
+	if exists, _ := fs.Exists(path); !exists {
+		return shallow, nil
+	}
+
+	data, err := fs.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		hashStr := string(bytes.TrimSpace(line))
+
+		if hashStr == "" {
+			continue
+		}
+
+		h, ok := hash.MaybeParse(hashStr)
+
+		if !ok {
+			return nil, fmt.Errorf("invalid commit hash '%s' in %s", hashStr, path)
+		}
+
+		shallow.Insert(h)
+	}
+
+	return shallow, nil
+}
+
+// SaveShallowCommits writes the hashes of the commits on the boundary of a shallow clone to the .dolt/shallow file,
+// one per line.  The file is removed if shallow is empty.
+func SaveShallowCommits(fs filesys.ReadWriteFS, shallow hash.HashSet) error {
+	path := getShallowFile()
+
+	if len(shallow) == 0 {
+		if exists, _ := fs.Exists(path); exists {
+			return fs.DeleteFile(path)
+		}
+
+		return nil
+	}
+
+	hashStrs := make([]string, 0, len(shallow))
+	for h := range shallow {
+		hashStrs = append(hashStrs, h.String())
+	}
+
+	sort.Strings(hashStrs)
+	return fs.WriteFile(path, []byte(strings.Join(hashStrs, "\n")+"\n"))
+}
+
+// initShallowCommits tells the DoltDB which commits are on the boundary of a shallow clone, so that their missing
+// parents are never read.
+func (dEnv *DoltEnv) initShallowCommits() {
+	if dEnv.DoltDB == nil || !dEnv.HasDoltDir() {
+		return
+	}
+
+	shallow, err := LoadShallowCommits(dEnv.sharedFS())
+
+	if err != nil {
+		dEnv.DBLoadError = err
+		return
+	}
+
+	dEnv.DoltDB.SetShallowCommits(shallow)
+}
+
+// UpdateShallowCommits records the commits on the boundary of a shallow clone.
+func (dEnv *DoltEnv) UpdateShallowCommits(shallow hash.HashSet) error {
+	err := SaveShallowCommits(dEnv.sharedFS(), shallow)
+
+	if err != nil {
+		return err
+	}
+
+	dEnv.DoltDB.SetShallowCommits(shallow)
+	return nil
+}
@@ -0,0 +1,608 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/earl"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+)
+
+var ErrWorktreeDirExists = errors.New("directory already exists and is not empty")
+var ErrWorktreeNotFound = errors.New("not a worktree of this repository")
+var ErrRemoveMainWorktree = errors.New("the main worktree can't be removed")
+var ErrRemoveCurrentWorktree = errors.New("the current worktree can't be removed")
+var ErrWorktreeHasChanges = errors.New("worktree has uncommitted changes")
+var ErrWorktreesLocked = errors.New("another command is changing the branches checked out in the worktrees of this repository")
+
+// worktreesLockTimeout is how long LockWorktrees waits for the lock to be released by another command.
+var worktreesLockTimeout = 10 * time.Second
+
+// BranchCheckedOutError is returned when a branch can't be checked out, moved or deleted because it is checked out in
+// another worktree of the repository.
+type BranchCheckedOutError struct {
+	Branch string
+	Dir    string
+}
+
+func (e BranchCheckedOutError) Error() string {
+	return fmt.Sprintf("'%s' is already checked out at '%s'", e.Branch, e.Dir)
+}
+
+// IsBranchCheckedOut returns true if err is a BranchCheckedOutError
+func IsBranchCheckedOut(err error) bool {
+	_, ok := err.(BranchCheckedOutError)
+	return ok
+}
+
+// Worktree is a directory with its own checked out branch and working and staged roots.  Every repository has a main
+// worktree which holds the database, and may have linked worktrees, created by AddWorktree, which use the database of
+// the main worktree.
+type Worktree struct {
+	// Name is the name a linked worktree is registered with in the main worktree.  It is empty for the main worktree.
+	Name string
+
+	// Dir is the absolute path of the worktree's directory, and FS is a filesystem whose working directory is Dir.
+	Dir string
+	FS  filesys.Filesys
+
+	// RepoState is the repo state of the worktree, which is nil if the worktree is missing.
+	RepoState *RepoState
+
+	// Missing is true if the directory of a linked worktree no longer exists.
+	Missing bool
+}
+
+// IsMain returns true if this is the main worktree of the repository
+func (wt Worktree) IsMain() bool {
+	return wt.Name == ""
+}
+
+// loadWorktreeMain returns the directory and a filesystem for the main worktree of the repository if the working
+// directory of fs is a linked worktree.  An empty directory and nil filesystem are returned if it isn't.
+func loadWorktreeMain(fs filesys.ReadableFS) (string, filesys.Filesys, error) {
+	path := getWorktreeFile()
+
+	if exists, _ := fs.Exists(path); !exists {
+		return "", nil, nil
+	}
+
+	data, err := fs.ReadFile(path)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	mainDir := strings.TrimSpace(string(data))
+	mainFS, err := filesys.LocalFilesysWithWorkingDir(mainDir)
+
+	if err == nil {
+		if exists, isDir := mainFS.Exists(dbfactory.DoltDataDir); !exists || !isDir {
+			err = os.ErrNotExist
+		}
+	}
+
+	if err != nil {
+		return "", nil, fmt.Errorf("the repository this worktree belongs to was not found at '%s'", mainDir)
+	}
+
+	return mainDir, mainFS, nil
+}
+
+// IsWorktree returns true if the environment is a linked worktree of a repository
+func (dEnv *DoltEnv) IsWorktree() bool {
+	return dEnv.mainFS != nil
+}
+
+// sharedFS returns the filesystem of the main worktree, where the data shared by all the worktrees of the repository
+// is kept.
+func (dEnv *DoltEnv) sharedFS() filesys.Filesys {
+	if dEnv.mainFS != nil {
+		return dEnv.mainFS
+	}
+
+	return dEnv.FS
+}
+
+// Worktrees returns the main worktree of the repository followed by its linked worktrees ordered by name.  The repo
+// state of the worktree the environment was loaded from is the one held by the environment.
+func (dEnv *DoltEnv) Worktrees() ([]Worktree, error) {
+	currDir, err := dEnv.FS.Abs(".")
+
+	if err != nil {
+		return nil, err
+	}
+
+	load := func(name, dir string, fs filesys.Filesys) (Worktree, error) {
+		wt := Worktree{Name: name, Dir: dir, FS: fs}
+
+		if dir == currDir {
+			wt.RepoState = dEnv.RepoState
+
+			if wt.RepoState == nil {
+				return Worktree{}, dEnv.RSLoadErr
+			}
+
+			return wt, nil
+		}
+
+		rs, err := LoadRepoState(fs)
+
+		if err != nil {
+			return Worktree{}, fmt.Errorf("failed to read the repo state of the worktree at '%s': %v", dir, err)
+		}
+
+		wt.RepoState = rs
+		return wt, nil
+	}
+
+	mainFS := dEnv.sharedFS()
+	mainDir, err := mainFS.Abs(".")
+
+	if err != nil {
+		return nil, err
+	}
+
+	mainWT, err := load("", mainDir, mainFS)
+
+	if err != nil {
+		return nil, err
+	}
+
+	worktrees := []Worktree{mainWT}
+	names, err := worktreeNames(mainFS)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		data, err := mainFS.ReadFile(filepath.Join(getWorktreesDir(), name))
+
+		if err != nil {
+			return nil, err
+		}
+
+		dir := strings.TrimSpace(string(data))
+
+		if exists, _ := filesys.LocalFS.Exists(filepath.Join(dir, getWorktreeFile())); !exists {
+			worktrees = append(worktrees, Worktree{Name: name, Dir: dir, Missing: true})
+			continue
+		}
+
+		fs, err := filesys.LocalFilesysWithWorkingDir(dir)
+
+		if err != nil {
+			return nil, err
+		}
+
+		wt, err := load(name, dir, fs)
+
+		if err != nil {
+			return nil, err
+		}
+
+		worktrees = append(worktrees, wt)
+	}
+
+	return worktrees, nil
+}
+
+// worktreeNames returns the sorted names of the linked worktrees registered in the main worktree.
+func worktreeNames(mainFS filesys.Filesys) ([]string, error) {
+	var names []string
+	if exists, _ := mainFS.Exists(getWorktreesDir()); !exists {
+		return names, nil
+	}
+
+	err := mainFS.Iter(getWorktreesDir(), false, func(path string, size int64, isDir bool) (stop bool) {
+		if !isDir {
+			names = append(names, filepath.Base(path))
+		}
+
+		return false
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// LockWorktrees takes the lock which serializes the commands that check out, move or delete branches in the worktrees
+// of the repository, waiting for another command to release it if necessary.  CheckBranchAvailable only gives a
+// reliable answer while the lock is held, so it must be held from the check until the repo state recording the new
+// branch is saved.  The returned function releases the lock.
+func (dEnv *DoltEnv) LockWorktrees() (func() error, error) {
+	mainFS := dEnv.sharedFS()
+	path, err := mainFS.Abs(getWorktreesLockFile())
+
+	if err != nil {
+		return nil, err
+	}
+
+	lck := filesys.CreateFilesysLock(mainFS, path)
+	deadline := time.Now().Add(worktreesLockTimeout)
+	for {
+		// a lock held by another process is reported as an error
+		locked, err := lck.TryLock()
+
+		if err == nil && locked {
+			return lck.Unlock, nil
+		} else if time.Now().After(deadline) {
+			return nil, ErrWorktreesLocked
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// CheckBranchAvailable returns a BranchCheckedOutError if the branch given is checked out in a worktree of the
+// repository other than the one the environment was loaded from.  Missing worktrees are ignored.  Callers which go on
+// to check out, move or delete the branch must hold the lock taken by LockWorktrees.
+func (dEnv *DoltEnv) CheckBranchAvailable(dref ref.DoltRef) error {
+	return dEnv.checkBranchAvailable(dref, false)
+}
+
+func (dEnv *DoltEnv) checkBranchAvailable(dref ref.DoltRef, includeCurrent bool) error {
+	worktrees, err := dEnv.Worktrees()
+
+	if err != nil {
+		return err
+	}
+
+	currDir, err := dEnv.FS.Abs(".")
+
+	if err != nil {
+		return err
+	}
+
+	for _, wt := range worktrees {
+		if wt.Missing || (!includeCurrent && wt.Dir == currDir) {
+			continue
+		}
+
+		if ref.Equals(wt.RepoState.CWBHeadRef(), dref) {
+			return BranchCheckedOutError{dref.GetPath(), wt.Dir}
+		}
+	}
+
+	return nil
+}
+
+// AddWorktree creates a linked worktree of the repository in dir, which must not exist or be empty, with the branch
+// given checked out.  The branch must not be checked out in any other worktree.  The new worktree's working and staged
+// roots are the root of the branch's head commit, and its docs are written from that root.  The remotes and branch
+// configuration of the repository are shared with the main worktree.
+func (dEnv *DoltEnv) AddWorktree(ctx context.Context, dir string, dref ref.DoltRef) (Worktree, error) {
+	hasRef, err := dEnv.DoltDB.HasRef(ctx, dref)
+
+	if err != nil {
+		return Worktree{}, err
+	} else if !hasRef {
+		return Worktree{}, doltdb.ErrBranchNotFound
+	}
+
+	unlock, err := dEnv.LockWorktrees()
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	defer unlock()
+
+	err = dEnv.checkBranchAvailable(dref, true)
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	absDir, err := dEnv.FS.Abs(dir)
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	absDir = filepath.Clean(absDir)
+	if exists, isDir := dEnv.FS.Exists(absDir); exists {
+		empty := isDir
+		if isDir {
+			err = dEnv.FS.Iter(absDir, false, func(path string, size int64, isDir bool) (stop bool) {
+				empty = false
+				return true
+			})
+
+			if err != nil {
+				return Worktree{}, err
+			}
+		}
+
+		if !empty {
+			return Worktree{}, ErrWorktreeDirExists
+		}
+	}
+
+	cs, err := doltdb.NewCommitSpec("HEAD", dref.String())
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	cm, err := dEnv.DoltDB.Resolve(ctx, cs)
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	rootHash, err := root.HashOf()
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	docs, err := dEnv.GetDocsWithNewerTextFromRoot(ctx, root, nil)
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	mainFS := dEnv.sharedFS()
+	mainDir, err := mainFS.Abs(".")
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	name, err := newWorktreeName(mainFS, filepath.Base(absDir))
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	err = dEnv.FS.MkDirs(filepath.Join(absDir, dbfactory.DoltDir))
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	wt, err := createWorktree(absDir, mainDir, dref, rootHash.String(), docs)
+
+	if err != nil {
+		_ = dEnv.FS.Delete(filepath.Join(absDir, dbfactory.DoltDir), true)
+		return Worktree{}, err
+	}
+
+	err = mainFS.MkDirs(getWorktreesDir())
+
+	if err == nil {
+		err = mainFS.WriteFile(filepath.Join(getWorktreesDir(), name), []byte(absDir+"\n"))
+	}
+
+	if err != nil {
+		_ = dEnv.FS.Delete(filepath.Join(absDir, dbfactory.DoltDir), true)
+		return Worktree{}, err
+	}
+
+	wt.Name = name
+	return wt, nil
+}
+
+// createWorktree writes the files of a linked worktree into its .dolt directory, and its docs.
+func createWorktree(dir, mainDir string, dref ref.DoltRef, rootHash string, docs Docs) (Worktree, error) {
+	fs, err := filesys.LocalFilesysWithWorkingDir(dir)
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	err = fs.WriteFile(getWorktreeFile(), []byte(mainDir+"\n"))
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	// the remotes, branch configuration and lazy remote are read from the repo state of the main worktree when the
+	// worktree is loaded
+	wtRS := &RepoState{
+		Head:     ref.MarshalableRef{Ref: dref},
+		Staged:   rootHash,
+		Working:  rootHash,
+		Remotes:  make(map[string]Remote),
+		Branches: make(map[string]BranchConfig),
+	}
+
+	err = wtRS.Save(fs)
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	err = docs.Save(fs)
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	return Worktree{Dir: dir, FS: fs, RepoState: wtRS}, nil
+}
+
+// newWorktreeName returns a name for a new linked worktree, based on the name of its directory, which isn't used by
+// any other worktree.
+func newWorktreeName(mainFS filesys.Filesys, base string) (string, error) {
+	names, err := worktreeNames(mainFS)
+
+	if err != nil {
+		return "", err
+	}
+
+	used := make(map[string]bool)
+	for _, name := range names {
+		used[name] = true
+	}
+
+	name := base
+	for i := 1; used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+
+	return name, nil
+}
+
+// RemoveWorktree deletes the linked worktree whose directory or name is given.  Unless force is true, a worktree whose
+// working or staged roots differ from the head of its branch is not removed.
+func (dEnv *DoltEnv) RemoveWorktree(ctx context.Context, dirOrName string, force bool) error {
+	wt, err := dEnv.findWorktree(dirOrName)
+
+	if err != nil {
+		return err
+	}
+
+	if wt.IsMain() {
+		return ErrRemoveMainWorktree
+	}
+
+	currDir, err := dEnv.FS.Abs(".")
+
+	if err != nil {
+		return err
+	} else if wt.Dir == currDir {
+		return ErrRemoveCurrentWorktree
+	}
+
+	if !wt.Missing {
+		if !force {
+			changed, err := worktreeHasChanges(ctx, dEnv.DoltDB, wt.RepoState)
+
+			if err != nil {
+				return err
+			} else if changed {
+				return ErrWorktreeHasChanges
+			}
+		}
+
+		err = filesys.LocalFS.Delete(wt.Dir, true)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return dEnv.sharedFS().DeleteFile(filepath.Join(getWorktreesDir(), wt.Name))
+}
+
+// findWorktree returns the worktree whose directory or name is given.
+func (dEnv *DoltEnv) findWorktree(dirOrName string) (Worktree, error) {
+	worktrees, err := dEnv.Worktrees()
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	absDir, err := dEnv.FS.Abs(dirOrName)
+
+	if err != nil {
+		return Worktree{}, err
+	}
+
+	absDir = filepath.Clean(absDir)
+	for _, wt := range worktrees {
+		if wt.Dir == absDir {
+			return wt, nil
+		}
+	}
+
+	for _, wt := range worktrees {
+		if !wt.IsMain() && wt.Name == dirOrName {
+			return wt, nil
+		}
+	}
+
+	return Worktree{}, ErrWorktreeNotFound
+}
+
+// worktreeHasChanges returns true if the working or staged root of a worktree differs from the root of its head
+// commit.
+func worktreeHasChanges(ctx context.Context, ddb *doltdb.DoltDB, rs *RepoState) (bool, error) {
+	cm, err := ddb.Resolve(ctx, rs.CWBHeadSpec())
+
+	if err != nil {
+		return false, err
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return false, err
+	}
+
+	h, err := root.HashOf()
+
+	if err != nil {
+		return false, err
+	}
+
+	return rs.Working != h.String() || rs.Staged != h.String(), nil
+}
+
+// PruneWorktrees forgets the linked worktrees whose directories no longer exist, and returns them.
+func (dEnv *DoltEnv) PruneWorktrees() ([]Worktree, error) {
+	worktrees, err := dEnv.Worktrees()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []Worktree
+	for _, wt := range worktrees {
+		if !wt.Missing {
+			continue
+		}
+
+		err = dEnv.sharedFS().DeleteFile(filepath.Join(getWorktreesDir(), wt.Name))
+
+		if err != nil {
+			return nil, err
+		}
+
+		pruned = append(pruned, wt)
+	}
+
+	return pruned, nil
+}
+
+// worktreeDBUrl returns the url of the database of the main worktree in mainDir.
+func worktreeDBUrl(mainDir string) string {
+	return earl.FileUrlFromPath(filepath.Join(mainDir, dbfactory.DoltDataDir), os.PathSeparator)
+}
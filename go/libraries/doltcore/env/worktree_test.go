@@ -0,0 +1,149 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/filesys"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/test"
+)
+
+func TestWorktrees(t *testing.T) {
+	ctx := context.Background()
+	rootPath, err := test.ChangeToTestDir("TestWorktrees")
+	require.NoError(t, err)
+
+	hdp := func() (string, error) { return rootPath, nil }
+	mainPath := filepath.Join(rootPath, "main")
+	mainEnv := initRepoWithRelativePath(t, mainPath, hdp)
+
+	master := ref.NewBranchRef("master")
+	feature := ref.NewBranchRef("feature")
+	cs, err := doltdb.NewCommitSpec("HEAD", "master")
+	require.NoError(t, err)
+	cm, err := mainEnv.DoltDB.Resolve(ctx, cs)
+	require.NoError(t, err)
+	err = mainEnv.DoltDB.NewBranchAtCommit(ctx, feature, cm)
+	require.NoError(t, err)
+
+	wtPath := filepath.Join(rootPath, "feature")
+	_, err = mainEnv.AddWorktree(ctx, wtPath, master)
+	assert.Equal(t, BranchCheckedOutError{"master", mainPath}, err)
+	_, err = mainEnv.AddWorktree(ctx, wtPath, ref.NewBranchRef("missing"))
+	assert.Equal(t, doltdb.ErrBranchNotFound, err)
+
+	wt, err := mainEnv.AddWorktree(ctx, wtPath, feature)
+	require.NoError(t, err)
+	assert.Equal(t, "feature", wt.Name)
+	assert.Equal(t, wtPath, wt.Dir)
+
+	// the worktree uses the database of the main worktree, whatever url it is loaded with
+	wtFS, err := filesys.LocalFilesysWithWorkingDir(wtPath)
+	require.NoError(t, err)
+	wtEnv := Load(ctx, hdp, wtFS, doltdb.LocalDirDoltDB, "test")
+	require.NoError(t, wtEnv.RSLoadErr)
+	require.NoError(t, wtEnv.DBLoadError)
+	assert.True(t, wtEnv.IsWorktree())
+	assert.False(t, mainEnv.IsWorktree())
+	assert.Equal(t, feature, wtEnv.RepoState.CWBHeadRef())
+	assert.Equal(t, mainEnv.RepoState.Working, wtEnv.RepoState.Working)
+
+	err = wtEnv.DoltDB.NewBranchAtCommit(ctx, ref.NewBranchRef("other"), cm)
+	require.NoError(t, err)
+	mainFS, err := filesys.LocalFilesysWithWorkingDir(mainPath)
+	require.NoError(t, err)
+	mainEnv = Load(ctx, hdp, mainFS, worktreeDBUrl(mainPath), "test")
+	require.NoError(t, mainEnv.DBLoadError)
+	has, err := mainEnv.DoltDB.HasRef(ctx, ref.NewBranchRef("other"))
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	assert.NoError(t, mainEnv.CheckBranchAvailable(master))
+	assert.Equal(t, BranchCheckedOutError{"feature", wtPath}, mainEnv.CheckBranchAvailable(feature))
+	assert.Equal(t, BranchCheckedOutError{"master", mainPath}, wtEnv.CheckBranchAvailable(master))
+	assert.NoError(t, wtEnv.CheckBranchAvailable(feature))
+
+	// only one worktree can change which branches are checked out at a time
+	unlock, err := mainEnv.LockWorktrees()
+	require.NoError(t, err)
+	timeout := worktreesLockTimeout
+	worktreesLockTimeout = 50 * time.Millisecond
+	_, err = wtEnv.LockWorktrees()
+	assert.Equal(t, ErrWorktreesLocked, err)
+	require.NoError(t, unlock())
+	unlock, err = wtEnv.LockWorktrees()
+	require.NoError(t, err)
+	require.NoError(t, unlock())
+	worktreesLockTimeout = timeout
+
+	// remotes are shared by the worktrees, and are only stored in the repo state of the main worktree
+	mainEnv.RepoState.AddRemote(NewRemote("origin", "file:///origin", nil))
+	require.NoError(t, mainEnv.RepoState.Save(mainEnv.FS))
+	wtEnv = Load(ctx, hdp, wtFS, doltdb.LocalDirDoltDB, "test")
+	require.NoError(t, wtEnv.RSLoadErr)
+	assert.Contains(t, wtEnv.RepoState.Remotes, "origin")
+
+	wtEnv.RepoState.AddRemote(NewRemote("upstream", "file:///upstream", nil))
+	require.NoError(t, wtEnv.RepoState.Save(wtEnv.FS))
+	mainRS, err := LoadRepoState(mainEnv.FS)
+	require.NoError(t, err)
+	assert.Contains(t, mainRS.Remotes, "upstream")
+	wtRS, err := LoadRepoState(wtEnv.FS)
+	require.NoError(t, err)
+	assert.Empty(t, wtRS.Remotes)
+
+	worktrees, err := wtEnv.Worktrees()
+	require.NoError(t, err)
+	require.Len(t, worktrees, 2)
+	assert.True(t, worktrees[0].IsMain())
+	assert.Equal(t, mainPath, worktrees[0].Dir)
+	assert.Equal(t, "feature", worktrees[1].Name)
+	assert.Same(t, wtEnv.RepoState, worktrees[1].RepoState)
+
+	// a second worktree in a directory with the same name gets a different name
+	otherPath := filepath.Join(rootPath, "other", "feature")
+	wt, err = wtEnv.AddWorktree(ctx, otherPath, ref.NewBranchRef("other"))
+	require.NoError(t, err)
+	assert.Equal(t, "feature1", wt.Name)
+
+	err = wtEnv.RemoveWorktree(ctx, wtPath, false)
+	assert.Equal(t, ErrRemoveCurrentWorktree, err)
+	err = wtEnv.RemoveWorktree(ctx, mainPath, false)
+	assert.Equal(t, ErrRemoveMainWorktree, err)
+
+	err = os.RemoveAll(otherPath)
+	require.NoError(t, err)
+	pruned, err := mainEnv.PruneWorktrees()
+	require.NoError(t, err)
+	require.Len(t, pruned, 1)
+	assert.Equal(t, "feature1", pruned[0].Name)
+
+	err = mainEnv.RemoveWorktree(ctx, "feature", false)
+	require.NoError(t, err)
+	worktrees, err = mainEnv.Worktrees()
+	require.NoError(t, err)
+	assert.Len(t, worktrees, 1)
+	assert.NoError(t, mainEnv.CheckBranchAvailable(feature))
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"math/big"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/valutil"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// resolveCell applies the merge strategy of a column to a cell which was changed differently on both sides of a merge.
+// rowVals and mergeVals are the values of the whole row on each side, which are needed by latest-by-column. If the
+// strategy can't pick a value, or the column doesn't have one, the cell is a conflict.
+func resolveCell(nbf *types.NomsBinFormat, sch schema.Schema, col schema.Column, val, mergeVal, baseVal types.Value, rowVals, mergeVals row.TaggedValues) (resultVal types.Value, isConflict bool, err error) {
+	switch col.MergeStrategy.Type {
+	case schema.OursMergeStrategy:
+		return val, false, nil
+
+	case schema.TheirsMergeStrategy:
+		return mergeVal, false, nil
+
+	case schema.MaxMergeStrategy, schema.MinMergeStrategy:
+		if types.IsNull(val) || types.IsNull(mergeVal) {
+			return nil, true, nil
+		}
+
+		less, err := val.Less(nbf, mergeVal)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		if less == (col.MergeStrategy.Type == schema.MaxMergeStrategy) {
+			return mergeVal, false, nil
+		}
+
+		return val, false, nil
+
+	case schema.SumOfDeltasMergeStrategy:
+		sum, ok := sumOfDeltas(val, mergeVal, baseVal)
+
+		if !ok || !col.TypeInfo.IsValid(sum) {
+			return nil, true, nil
+		}
+
+		return sum, false, nil
+
+	case schema.LatestByColumnMergeStrategy:
+		byCol, ok := sch.GetAllCols().GetByNameCaseInsensitive(col.MergeStrategy.Column)
+
+		if !ok {
+			return nil, true, nil
+		}
+
+		byVal, _ := rowVals.Get(byCol.Tag)
+		mergeByVal, _ := mergeVals.Get(byCol.Tag)
+
+		if types.IsNull(byVal) || types.IsNull(mergeByVal) || valutil.NilSafeEqCheck(byVal, mergeByVal) {
+			return nil, true, nil
+		}
+
+		less, err := byVal.Less(nbf, mergeByVal)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		if less {
+			return mergeVal, false, nil
+		}
+
+		return val, false, nil
+	}
+
+	return nil, true, nil
+}
+
+// sumOfDeltas returns val + mergeVal - baseVal. A NULL baseVal counts as zero, so that a counter added on both sides of
+// a merge is the sum of the values added. It returns false if val or mergeVal is NULL, if the values aren't numbers of
+// the same kind, or if the result can't be represented by that kind.
+func sumOfDeltas(val, mergeVal, baseVal types.Value) (types.Value, bool) {
+	if types.IsNull(val) || types.IsNull(mergeVal) {
+		return nil, false
+	}
+
+	switch v := val.(type) {
+	case types.Int:
+		mv, ok := mergeVal.(types.Int)
+		bv, baseOk := baseVal.(types.Int)
+
+		if !ok || (!baseOk && !types.IsNull(baseVal)) {
+			return nil, false
+		}
+
+		sum := new(big.Int).Add(big.NewInt(int64(v)), big.NewInt(int64(mv)))
+		sum.Sub(sum, big.NewInt(int64(bv)))
+
+		if !sum.IsInt64() {
+			return nil, false
+		}
+
+		return types.Int(sum.Int64()), true
+
+	case types.Uint:
+		mv, ok := mergeVal.(types.Uint)
+		bv, baseOk := baseVal.(types.Uint)
+
+		if !ok || (!baseOk && !types.IsNull(baseVal)) {
+			return nil, false
+		}
+
+		sum := new(big.Int).Add(new(big.Int).SetUint64(uint64(v)), new(big.Int).SetUint64(uint64(mv)))
+		sum.Sub(sum, new(big.Int).SetUint64(uint64(bv)))
+
+		if !sum.IsUint64() {
+			return nil, false
+		}
+
+		return types.Uint(sum.Uint64()), true
+
+	case types.Float:
+		mv, ok := mergeVal.(types.Float)
+		bv, baseOk := baseVal.(types.Float)
+
+		if !ok || (!baseOk && !types.IsNull(baseVal)) {
+			return nil, false
+		}
+
+		return v + mv - bv, true
+	}
+
+	return nil, false
+}
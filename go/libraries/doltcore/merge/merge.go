@@ -17,8 +17,6 @@ package merge
 import (
 	"context"
 	"errors"
-	"fmt"
-	"strings"
 
 	"github.com/liquidata-inc/dolt/go/store/atomicerr"
 	"github.com/liquidata-inc/dolt/go/store/hash"
@@ -29,7 +27,6 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
-	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table/typed"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/valutil"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
@@ -134,14 +131,28 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string) (*doltdb.T
 		return nil, nil, err
 	}
 
-	postMergeSchema, err := mergeTableSchema(tblSchema, mergeTblSchema, ancTblSchema)
+	postMergeSchema, tagMapping, schConflicts, err := mergeTableSchema(tblSchema, mergeTblSchema, ancTblSchema)
 
 	if err != nil {
 		return nil, nil, err
 	}
 
-	postMergeSchema.Indexes().AddIndex(tblSchema.Indexes().AllIndexes()...)
+	if len(schConflicts) > 0 {
+		cnfTbl, err := tbl.SetSchemaConflict(ctx, ancTbl, tbl, mergeTbl)
 
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return cnfTbl, &MergeStats{Operation: TableModified, SchemaConflicts: len(schConflicts)}, nil
+	}
+
+	return mergeTableRows(ctx, merger.vrw, postMergeSchema, tbl, mergeTbl, ancTbl, tagMapping)
+}
+
+// mergeTableRows merges the rows of both sides of a merge of a table into a table with the merged schema given. Values
+// of the columns of mergeTbl whose tags are in tagMapping are moved to the mapped tags.
+func mergeTableRows(ctx context.Context, vrw types.ValueReadWriter, postMergeSchema schema.Schema, tbl, mergeTbl, ancTbl *doltdb.Table, tagMapping map[uint64]uint64) (*doltdb.Table, *MergeStats, error) {
 	rows, err := tbl.GetRowData(ctx)
 
 	if err != nil {
@@ -160,19 +171,33 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string) (*doltdb.T
 		return nil, nil, err
 	}
 
-	mergedRowData, conflicts, stats, err := mergeTableData(ctx, postMergeSchema, rows, mergeRows, ancRows, merger.vrw)
+	msr, err := mergeTbl.GetSchemaRef()
 
 	if err != nil {
 		return nil, nil, err
 	}
 
-	schUnionVal, err := encoding.MarshalSchemaAsNomsValue(ctx, merger.vrw, postMergeSchema)
+	if len(tagMapping) > 0 {
+		mergeRows, msr, err = remapTags(ctx, vrw, mergeTbl, mergeRows, tagMapping)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	mergedRowData, conflicts, stats, err := mergeTableData(ctx, postMergeSchema, rows, mergeRows, ancRows, vrw)
 
 	if err != nil {
 		return nil, nil, err
 	}
 
-	mergedTable, err := doltdb.NewTable(ctx, merger.vrw, schUnionVal, mergedRowData, nil)
+	schUnionVal, err := encoding.MarshalSchemaAsNomsValue(ctx, vrw, postMergeSchema)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mergedTable, err := doltdb.NewTable(ctx, vrw, schUnionVal, mergedRowData, nil)
 
 	if err != nil {
 		return nil, nil, err
@@ -198,74 +223,91 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string) (*doltdb.T
 			return nil, nil, err
 		}
 
-		msr, err := mergeTbl.GetSchemaRef()
+		schemas := doltdb.NewConflict(asr, sr, msr)
+		mergedTable, err = mergedTable.SetConflicts(ctx, schemas, conflicts)
 
 		if err != nil {
 			return nil, nil, err
 		}
-
-		schemas := doltdb.NewConflict(asr, sr, msr)
-		mergedTable, err = mergedTable.SetConflicts(ctx, schemas, conflicts)
 	}
 
 	return mergedTable, stats, nil
 }
 
-func stopAndDrain(stop chan<- struct{}, drain <-chan types.ValueChanged) {
-	close(stop)
-	for range drain {
+// remapTags moves the values of the columns of a table whose tags are in tagMapping to the mapped tags. The rows of the
+// table and a ref to its schema with the mapped tags are returned.
+func remapTags(ctx context.Context, vrw types.ValueReadWriter, tbl *doltdb.Table, rows types.Map, tagMapping map[uint64]uint64) (types.Map, types.Ref, error) {
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return types.EmptyMap, types.Ref{}, err
 	}
-}
 
-func mergeTableSchema(sch, mergeSch, ancSch schema.Schema) (schema.Schema, error) {
-	// (sch - ancSch) ∪ (mergeSch - ancSch) ∪ (sch ∩ mergeSch)
+	var cols []schema.Column
+	_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if mapped, ok := tagMapping[tag]; ok {
+			col.Tag = mapped
+		}
+		cols = append(cols, col)
+		return false, nil
+	})
 
-	// columns remaining on both branches since the common ancestor
-	intersection, err := typed.TypedColCollectionIntersection(sch, mergeSch)
+	colColl, err := schema.NewColCollection(cols...)
 
 	if err != nil {
-		return nil, err
+		return types.EmptyMap, types.Ref{}, err
 	}
 
-	// columns added on the main branch since the common ancestor
-	sub, err := typed.TypedColCollectionSubtraction(sch, ancSch)
+	mappedSch := schema.SchemaFromCols(colColl)
+	schVal, err := encoding.MarshalSchemaAsNomsValue(ctx, vrw, mappedSch)
 
 	if err != nil {
-		return nil, err
+		return types.EmptyMap, types.Ref{}, err
 	}
 
-	// columns added on the merge branch since the common ancestor
-	mergeSub, err := typed.TypedColCollectionSubtraction(mergeSch, ancSch)
+	schRef, err := vrw.WriteValue(ctx, schVal)
 
 	if err != nil {
-		return nil, err
+		return types.EmptyMap, types.Ref{}, err
 	}
 
-	// check for name collisions
-	err = sub.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
-		ln := strings.ToLower(col.Name)
-		if mergeCol, found := mergeSub.LowerNameToCol[ln]; found {
-			if !col.Equals(mergeCol) {
-				return true, fmt.Errorf("name collision during merge for colummn %s, %v %v", ln, col, mergeCol)
+	me := rows.Edit()
+	err = rows.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		taggedVals, err := row.ParseTaggedValues(value.(types.Tuple))
+
+		if err != nil {
+			return true, err
+		}
+
+		mappedVals := make(row.TaggedValues, len(taggedVals))
+		for tag, val := range taggedVals {
+			if mapped, ok := tagMapping[tag]; ok {
+				tag = mapped
 			}
+			mappedVals[tag] = val
 		}
+
+		me.Set(key, mappedVals.NomsTupleForNonPKCols(vrw.Format(), mappedSch.GetNonPKCols()))
 		return false, nil
 	})
 
 	if err != nil {
-		return nil, err
+		return types.EmptyMap, types.Ref{}, err
 	}
 
-	// order of args here is important for correct column ordering in merged schema
-	// to be before any column in the intersection
-	// TODO: column ordering will break if a column added on sub or merge was reordered
-	union, err := typed.TypedColCollUnion(intersection, sub, mergeSub)
+	mappedRows, err := me.Map(ctx)
 
 	if err != nil {
-		return nil, err
+		return types.EmptyMap, types.Ref{}, err
 	}
 
-	return schema.SchemaFromCols(union), nil
+	return mappedRows, schRef, nil
+}
+
+func stopAndDrain(stop chan<- struct{}, drain <-chan types.ValueChanged) {
+	close(stop)
+	for range drain {
+	}
 }
 
 func mergeTableData(ctx context.Context, sch schema.Schema, rows, mergeRows, ancRows types.Map, vrw types.ValueReadWriter) (types.Map, types.Map, *MergeStats, error) {
@@ -356,7 +398,7 @@ func mergeTableData(ctx context.Context, sch schema.Schema, rows, mergeRows, anc
 
 			if !processed {
 				r, mergeRow, ancRow := change.NewValue, mergeChange.NewValue, change.OldValue
-				mergedRow, isConflict, err := rowMerge(ctx, vrw.Format(), sch, r, mergeRow, ancRow)
+				mergedRow, isConflict, resolved, err := rowMerge(ctx, vrw.Format(), sch, r, mergeRow, ancRow)
 
 				if err != nil {
 					return err
@@ -372,6 +414,7 @@ func mergeTableData(ctx context.Context, sch schema.Schema, rows, mergeRows, anc
 
 					addConflict(conflictValChan, key, conflictTuple)
 				} else {
+					stats.AutoResolved += resolved
 					applyChange(mapEditor, stats, types.ValueChanged{ChangeType: change.ChangeType, Key: key, OldValue: r, NewValue: mergedRow})
 				}
 
@@ -422,57 +465,67 @@ func applyChange(me *types.MapEditor, stats *MergeStats, change types.ValueChang
 	}
 }
 
-func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, error) {
+// rowMerge merges the changes made to a row on both sides of a merge. Cells changed differently on both sides are
+// resolved with the merge strategy of their column, and the number of cells resolved this way is returned along with
+// the merged row. If any cell can't be resolved the row is a conflict.
+func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, int, error) {
 	var baseVals row.TaggedValues
 	if baseRow == nil {
 		if r.Equals(mergeRow) {
 			// same row added to both
-			return r, false, nil
+			return r, false, 0, nil
 		}
 	} else if r == nil && mergeRow == nil {
 		// same row removed from both
-		return nil, false, nil
+		return nil, false, 0, nil
 	} else if r == nil || mergeRow == nil {
 		// removed from one and modified in another
-		return nil, true, nil
+		return nil, true, 0, nil
 	} else {
 		var err error
 		baseVals, err = row.ParseTaggedValues(baseRow.(types.Tuple))
 
 		if err != nil {
-			return nil, false, err
+			return nil, false, 0, err
 		}
 	}
 
 	rowVals, err := row.ParseTaggedValues(r.(types.Tuple))
 
 	if err != nil {
-		return nil, false, err
+		return nil, false, 0, err
 	}
 
 	mergeVals, err := row.ParseTaggedValues(mergeRow.(types.Tuple))
 
 	if err != nil {
-		return nil, false, err
+		return nil, false, 0, err
 	}
 
-	processTagFunc := func(tag uint64) (resultVal types.Value, isConflict bool) {
+	resolved := 0
+	processTagFunc := func(tag uint64, col schema.Column) (resultVal types.Value, isConflict bool, err error) {
 		baseVal, _ := baseVals.Get(tag)
 		val, _ := rowVals.Get(tag)
 		mergeVal, _ := mergeVals.Get(tag)
 
 		if valutil.NilSafeEqCheck(val, mergeVal) {
-			return val, false
+			return val, false, nil
 		} else {
 			modified := !valutil.NilSafeEqCheck(val, baseVal)
 			mergeModified := !valutil.NilSafeEqCheck(mergeVal, baseVal)
 			switch {
 			case modified && mergeModified:
-				return nil, true
+				resultVal, isConflict, err = resolveCell(nbf, sch, col, val, mergeVal, baseVal, rowVals, mergeVals)
+
+				if err == nil && !isConflict {
+					resolved++
+				}
+
+				return resultVal, isConflict, err
 			case modified:
-				return val, false
+				return val, false, nil
 			default:
-				return mergeVal, false
+				return mergeVal, false, nil
 			}
 		}
 
@@ -481,37 +534,42 @@ func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema,
 	resultVals := make(row.TaggedValues)
 
 	var isConflict bool
-	err = sch.GetNonPKCols().Iter(func(tag uint64, _ schema.Column) (stop bool, err error) {
+	err = sch.GetNonPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		var val types.Value
-		val, isConflict = processTagFunc(tag)
+		val, isConflict, err = processTagFunc(tag, col)
 		resultVals[tag] = val
 
-		return isConflict, nil
+		return isConflict, err
 	})
 
 	if err != nil {
-		return nil, false, err
+		return nil, false, 0, err
 	}
 
 	if isConflict {
-		return nil, true, nil
+		return nil, true, 0, nil
 	}
 
 	tpl := resultVals.NomsTupleForNonPKCols(nbf, sch.GetNonPKCols())
 	v, err := tpl.Value(ctx)
 
 	if err != nil {
-		return nil, false, err
+		return nil, false, 0, err
 	}
 
-	return v, false, nil
+	return v, false, resolved, nil
 }
 
-func MergeCommits(ctx context.Context, ddb *doltdb.DoltDB, commit, mergeCommit *doltdb.Commit) (*doltdb.RootValue, map[string]*MergeStats, error) {
-	ancCommit, err := doltdb.GetCommitAncestor(ctx, commit, mergeCommit)
+// MergeCommits performs a three-way merge of the roots of commit and mergeCommit.  If ancCommit is nil the merge base of
+// the two commits is used as the common ancestor, otherwise the root of ancCommit is used.
+func MergeCommits(ctx context.Context, ddb *doltdb.DoltDB, commit, mergeCommit, ancCommit *doltdb.Commit) (*doltdb.RootValue, map[string]*MergeStats, error) {
+	if ancCommit == nil {
+		var err error
+		ancCommit, err = doltdb.GetCommitAncestor(ctx, commit, mergeCommit)
 
-	if err != nil {
-		return nil, nil, err
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	root, err := commit.GetRootValue()
@@ -532,7 +590,14 @@ func MergeCommits(ctx context.Context, ddb *doltdb.DoltDB, commit, mergeCommit *
 		return nil, nil, err
 	}
 
-	merger := NewMerger(ctx, root, mergeRoot, ancRoot, ddb.ValueReadWriter())
+	return MergeRoots(ctx, ddb.ValueReadWriter(), root, mergeRoot, ancRoot)
+}
+
+// MergeRoots performs a three-way merge of every table in root and mergeRoot using the tables in ancRoot as the base
+// for the merge.  Tables with conflicts are written to the returned root with their conflicts set, and the
+// MergeStats for each table are returned keyed by table name.
+func MergeRoots(ctx context.Context, vrw types.ValueReadWriter, root, mergeRoot, ancRoot *doltdb.RootValue) (*doltdb.RootValue, map[string]*MergeStats, error) {
+	merger := NewMerger(ctx, root, mergeRoot, ancRoot, vrw)
 
 	tblNames, err := doltdb.UnionTableNames(ctx, root, mergeRoot)
 
@@ -574,8 +639,6 @@ func MergeCommits(ctx context.Context, ddb *doltdb.DoltDB, commit, mergeCommit *
 			if err != nil {
 				return nil, nil, err
 			}
-		} else {
-			panic("?")
 		}
 	}
 
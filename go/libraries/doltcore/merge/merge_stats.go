@@ -29,4 +29,12 @@ type MergeStats struct {
 	Deletes       int
 	Modifications int
 	Conflicts     int
+
+	// SchemaConflicts is the number of changes to the schema of the table which couldn't be merged. A table with
+	// schema conflicts isn't merged until they are resolved.
+	SchemaConflicts int
+
+	// AutoResolved is the number of cells changed on both sides of the merge which were resolved by the merge
+	// strategy of their column instead of being a conflict
+	AutoResolved int
 }
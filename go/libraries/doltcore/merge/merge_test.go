@@ -206,10 +206,102 @@ func TestRowMerge(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			actualResult, isConflict, err := rowMerge(context.Background(), types.Format_7_18, test.sch, test.row, test.mergeRow, test.ancRow)
+			actualResult, isConflict, resolved, err := rowMerge(context.Background(), types.Format_7_18, test.sch, test.row, test.mergeRow, test.ancRow)
 			assert.NoError(t, err)
 			assert.Equal(t, test.expectedResult, actualResult, "expected "+mustString(types.EncodedValue(context.Background(), test.expectedResult))+"got "+mustString(types.EncodedValue(context.Background(), actualResult)))
 			assert.Equal(t, test.expectConflict, isConflict)
+			assert.Equal(t, 0, resolved)
+		})
+	}
+}
+
+func TestRowMergeWithMergeStrategies(t *testing.T) {
+	withStrategy := func(col schema.Column, msType schema.MergeStrategyType, msCol string) schema.Column {
+		col.MergeStrategy = schema.MergeStrategy{Type: msType, Column: msCol}
+		return col
+	}
+
+	cols, err := schema.NewColCollection(
+		schema.NewColumn("pk", 0, types.IntKind, true),
+		withStrategy(schema.NewColumn("ours", 1, types.StringKind, false), schema.OursMergeStrategy, ""),
+		withStrategy(schema.NewColumn("theirs", 2, types.StringKind, false), schema.TheirsMergeStrategy, ""),
+		withStrategy(schema.NewColumn("max", 3, types.IntKind, false), schema.MaxMergeStrategy, ""),
+		withStrategy(schema.NewColumn("min", 4, types.IntKind, false), schema.MinMergeStrategy, ""),
+		withStrategy(schema.NewColumn("count", 5, types.UintKind, false), schema.SumOfDeltasMergeStrategy, ""),
+		withStrategy(schema.NewColumn("latest", 6, types.StringKind, false), schema.LatestByColumnMergeStrategy, "max"),
+		schema.NewColumn("none", 7, types.StringKind, false),
+	)
+	require.NoError(t, err)
+	sch := schema.SchemaFromCols(cols)
+
+	tests := []struct {
+		name                  string
+		row, mergeRow, ancRow []types.Value
+		expected              []types.Value
+		expectedResolved      int
+	}{
+		{
+			"all cells resolved",
+			[]types.Value{types.String("a"), types.String("a"), types.Int(5), types.Int(5), types.Uint(12), types.String("a"), types.String("x")},
+			[]types.Value{types.String("b"), types.String("b"), types.Int(7), types.Int(3), types.Uint(15), types.String("b"), types.String("x")},
+			[]types.Value{types.String("c"), types.String("c"), types.Int(1), types.Int(9), types.Uint(10), types.String("c"), types.String("x")},
+			[]types.Value{types.String("a"), types.String("b"), types.Int(7), types.Int(3), types.Uint(17), types.String("b"), types.String("x")},
+			6,
+		},
+		{
+			"row added on both sides",
+			[]types.Value{types.String("a"), types.String("a"), types.Int(5), types.Int(5), types.Uint(2), types.String("a"), types.NullValue},
+			[]types.Value{types.String("b"), types.String("b"), types.Int(7), types.Int(3), types.Uint(3), types.String("b"), types.NullValue},
+			nil,
+			[]types.Value{types.String("a"), types.String("b"), types.Int(7), types.Int(3), types.Uint(5), types.String("b"), types.NullValue},
+			6,
+		},
+		{
+			"column without a strategy conflicts",
+			[]types.Value{types.String("a"), types.String("c"), types.Int(5), types.Int(9), types.Uint(10), types.String("c"), types.String("y")},
+			[]types.Value{types.String("b"), types.String("c"), types.Int(7), types.Int(9), types.Uint(10), types.String("c"), types.String("z")},
+			[]types.Value{types.String("c"), types.String("c"), types.Int(1), types.Int(9), types.Uint(10), types.String("c"), types.String("x")},
+			nil,
+			0,
+		},
+		{
+			"sum-of-deltas which would be negative conflicts",
+			[]types.Value{types.String("c"), types.String("c"), types.Int(1), types.Int(9), types.Uint(0), types.String("c"), types.String("x")},
+			[]types.Value{types.String("c"), types.String("c"), types.Int(1), types.Int(9), types.Uint(1), types.String("c"), types.String("x")},
+			[]types.Value{types.String("c"), types.String("c"), types.Int(1), types.Int(9), types.Uint(2), types.String("c"), types.String("x")},
+			nil,
+			0,
+		},
+		{
+			"latest-by-column with equal values conflicts",
+			[]types.Value{types.String("c"), types.String("c"), types.Int(1), types.Int(9), types.Uint(10), types.String("a"), types.String("x")},
+			[]types.Value{types.String("c"), types.String("c"), types.Int(1), types.Int(9), types.Uint(10), types.String("b"), types.String("x")},
+			[]types.Value{types.String("c"), types.String("c"), types.Int(1), types.Int(9), types.Uint(10), types.String("c"), types.String("x")},
+			nil,
+			0,
+		},
+		{
+			"max with a NULL conflicts",
+			[]types.Value{types.String("c"), types.String("c"), types.NullValue, types.Int(9), types.Uint(10), types.String("c"), types.String("x")},
+			[]types.Value{types.String("c"), types.String("c"), types.Int(2), types.Int(9), types.Uint(10), types.String("c"), types.String("x")},
+			[]types.Value{types.String("c"), types.String("c"), types.Int(1), types.Int(9), types.Uint(10), types.String("c"), types.String("x")},
+			nil,
+			0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := valsToTestTupleWithPks(test.row)
+			mergeRow := valsToTestTupleWithPks(test.mergeRow)
+			ancRow := valsToTestTupleWithPks(test.ancRow)
+			expected := valsToTestTupleWithPks(test.expected)
+
+			actualResult, isConflict, resolved, err := rowMerge(context.Background(), types.Format_7_18, sch, r, mergeRow, ancRow)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actualResult)
+			assert.Equal(t, test.expected == nil, isConflict)
+			assert.Equal(t, test.expectedResolved, resolved)
 		})
 	}
 }
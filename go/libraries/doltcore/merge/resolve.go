@@ -16,14 +16,19 @@ package merge
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/table"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
+var ErrSchemaConflictNotResolved = errors.New("schema conflicts must be resolved by picking our or their schema")
+
 type AutoResolver func(key types.Value, conflict doltdb.Conflict) (types.Value, error)
 
 func Ours(key types.Value, cnf doltdb.Conflict) (types.Value, error) {
@@ -41,6 +46,22 @@ func ResolveTable(ctx context.Context, vrw types.ValueReadWriter, tbl *doltdb.Ta
 		return nil, doltdb.ErrNoConflicts
 	}
 
+	if has, err := tbl.HasSchemaConflict(); err != nil {
+		return nil, err
+	} else if has {
+		tbl, err = resolveSchemaConflict(ctx, vrw, tbl, autoResFunc)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if has, err := tbl.HasConflicts(); err != nil {
+			return nil, err
+		} else if !has {
+			return tbl, nil
+		}
+	}
+
 	tblSchRef, err := tbl.GetSchemaRef()
 	if err != nil {
 		return nil, err
@@ -179,3 +200,171 @@ func ResolveTable(ctx context.Context, vrw types.ValueReadWriter, tbl *doltdb.Ta
 
 	return newTbl, nil
 }
+
+// resolveSchemaConflict resolves the schema conflict of a table by passing the refs of the ancestor's, our and their
+// schemas to the AutoResolver, and then finishes the merge of the table's rows using the schema it picked. The values
+// of the other versions of the table are converted to the types of the picked schema. If the primary keys of the
+// versions differ the rows can't be merged, and the picked version of the table is used as is. Our version of the
+// table is the current one, so the schema conflict can also be resolved by altering the table before picking ours.
+func resolveSchemaConflict(ctx context.Context, vrw types.ValueReadWriter, tbl *doltdb.Table, autoResFunc AutoResolver) (*doltdb.Table, error) {
+	ancTbl, _, theirTbl, err := tbl.GetSchemaConflict(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ourTbl, err := tbl.ClearSchemaConflict()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var schRefs []types.Ref
+	for _, t := range []*doltdb.Table{ancTbl, ourTbl, theirTbl} {
+		schRef, err := t.GetSchemaRef()
+
+		if err != nil {
+			return nil, err
+		}
+
+		schRefs = append(schRefs, schRef)
+	}
+
+	picked, err := autoResFunc(types.NullValue, doltdb.NewConflict(schRefs[0], schRefs[1], schRefs[2]))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var pickedTbl *doltdb.Table
+	if picked != nil && picked.Equals(schRefs[1]) {
+		pickedTbl = ourTbl
+	} else if picked != nil && picked.Equals(schRefs[2]) {
+		pickedTbl = theirTbl
+	} else {
+		return nil, ErrSchemaConflictNotResolved
+	}
+
+	sch, err := pickedTbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var conformed []*doltdb.Table
+	for _, t := range []*doltdb.Table{ancTbl, ourTbl, theirTbl} {
+		conformedTbl, ok, err := conformTable(ctx, vrw, t, sch)
+
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return pickedTbl, nil
+		}
+
+		conformed = append(conformed, conformedTbl)
+	}
+
+	mergedTbl, _, err := mergeTableRows(ctx, vrw, sch, conformed[1], conformed[2], conformed[0], nil)
+	return mergedTbl, err
+}
+
+// conformTable returns a table with the schema given, and with the rows of tbl converted to it. Values of columns which
+// aren't in the schema are dropped. It returns false if the primary keys of the table and the schema differ.
+func conformTable(ctx context.Context, vrw types.ValueReadWriter, tbl *doltdb.Table, sch schema.Schema) (*doltdb.Table, bool, error) {
+	tblSch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if eq, err := schema.SchemasAreEqual(tblSch, sch); err != nil {
+		return nil, false, err
+	} else if eq {
+		return tbl, true, nil
+	}
+
+	pkCols, tblPkCols := sch.GetPKCols(), tblSch.GetPKCols()
+	if pkCols.Size() != tblPkCols.Size() {
+		return nil, false, nil
+	}
+
+	for i, col := range pkCols.GetColumns() {
+		tblCol := tblPkCols.GetByIndex(i)
+		if col.Tag != tblCol.Tag || !col.TypeInfo.Equals(tblCol.TypeInfo) {
+			return nil, false, nil
+		}
+	}
+
+	rows, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	me := rows.Edit()
+	err = rows.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		taggedVals, err := row.ParseTaggedValues(value.(types.Tuple))
+
+		if err != nil {
+			return true, err
+		}
+
+		conformedVals := make(row.TaggedValues)
+		err = sch.GetNonPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			val, ok := taggedVals.Get(tag)
+			tblCol, inTbl := tblSch.GetAllCols().GetByTag(tag)
+
+			if !ok || !inTbl || types.IsNull(val) {
+				return false, nil
+			}
+
+			if !col.TypeInfo.Equals(tblCol.TypeInfo) {
+				goVal, err := tblCol.TypeInfo.ConvertNomsValueToValue(val)
+
+				if err != nil {
+					return true, err
+				}
+
+				val, err = col.TypeInfo.ConvertValueToNomsValue(goVal)
+
+				if err != nil {
+					return true, fmt.Errorf("a value of column '%s' can't be converted to %s: %v", col.Name, sqlTypeString(col), err)
+				}
+			}
+
+			conformedVals[tag] = val
+			return false, nil
+		})
+
+		if err != nil {
+			return true, err
+		}
+
+		me.Set(key, conformedVals.NomsTupleForNonPKCols(vrw.Format(), sch.GetNonPKCols()))
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	conformedRows, err := me.Map(ctx)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	schVal, err := encoding.MarshalSchemaAsNomsValue(ctx, vrw, sch)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	conformedTbl, err := doltdb.NewTable(ctx, vrw, schVal, conformedRows, nil)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return conformedTbl, true, nil
+}
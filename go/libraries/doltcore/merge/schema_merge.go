@@ -0,0 +1,421 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+// SchemaConflictKind is the kind of schema element which was changed differently on both sides of a merge
+type SchemaConflictKind string
+
+const (
+	ColumnSchemaConflict SchemaConflictKind = "column"
+	IndexSchemaConflict  SchemaConflictKind = "index"
+)
+
+// SchemaConflict is a change made to the schema of a table on both sides of a merge which can't be merged
+type SchemaConflict struct {
+	Kind SchemaConflictKind
+
+	// Name is the name of the column or index in our version of the table, or in theirs if it isn't in ours
+	Name string
+
+	// Reason says how the two sides of the merge changed the column or index
+	Reason string
+}
+
+func (sc SchemaConflict) String() string {
+	if sc.Name == "" {
+		return fmt.Sprintf("%s: %s", sc.Kind, sc.Reason)
+	}
+
+	return fmt.Sprintf("%s '%s': %s", sc.Kind, sc.Name, sc.Reason)
+}
+
+// mergeTableSchema merges the schemas of a table from both sides of a merge with the schema of their common ancestor.
+// Columns are matched by tag, and each property of a column (its name, type, constraints and merge strategy) is merged
+// separately, so a column renamed on one side and changed in another way on the other keeps both changes. Type changes
+// are only merged if the resulting type can hold all values of the other versions of the column. Columns added on both
+// sides with the same name are merged into one, and the returned map gives the tags of columns in mergeSch which must
+// be changed to the tag of the matching column in sch. Indexes are matched by name.
+//
+// Any changes which can't be merged are returned as schema conflicts, in which case the merged schema is nil.
+func mergeTableSchema(sch, mergeSch, ancSch schema.Schema) (schema.Schema, map[uint64]uint64, []SchemaConflict, error) {
+	cols, mergeCols, ancCols := sch.GetAllCols(), mergeSch.GetAllCols(), ancSch.GetAllCols()
+
+	var conflicts []SchemaConflict
+	addConflict := func(name, reason string, args ...interface{}) {
+		conflicts = append(conflicts, SchemaConflict{ColumnSchemaConflict, name, fmt.Sprintf(reason, args...)})
+	}
+
+	// our columns keep their order, and the columns only added on their side follow them
+	var tags []uint64
+	mergedCols := make(map[uint64]schema.Column)
+	tagMapping := make(map[uint64]uint64)
+
+	_ = cols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		ancCol, inAnc := ancCols.GetByTag(tag)
+		mergeCol, inMerge := mergeCols.GetByTag(tag)
+
+		switch {
+		case inAnc && inMerge:
+			if merged, reason := mergeColumn(ancCol, col, mergeCol); reason != "" {
+				addConflict(col.Name, reason)
+			} else {
+				tags = append(tags, tag)
+				mergedCols[tag] = merged
+			}
+		case inAnc:
+			if !col.Equals(ancCol) {
+				addConflict(col.Name, "modified on our side and dropped on their side")
+			}
+		case inMerge:
+			if merged, reason := mergeAddedColumn(col, mergeCol); reason != "" {
+				addConflict(col.Name, reason)
+			} else {
+				tags = append(tags, tag)
+				mergedCols[tag] = merged
+			}
+		default:
+			tags = append(tags, tag)
+			mergedCols[tag] = col
+		}
+
+		return false, nil
+	})
+
+	_ = mergeCols.Iter(func(tag uint64, mergeCol schema.Column) (stop bool, err error) {
+		if _, ok := cols.GetByTag(tag); ok {
+			return false, nil
+		}
+
+		if ancCol, inAnc := ancCols.GetByTag(tag); inAnc {
+			if !mergeCol.Equals(ancCol) {
+				addConflict(mergeCol.Name, "dropped on our side and modified on their side")
+			}
+
+			return false, nil
+		}
+
+		// a column added on both sides with different tags is merged into our column
+		col, ok := cols.GetByNameCaseInsensitive(mergeCol.Name)
+		if ok && !hasTag(ancCols, col.Tag) && !hasTag(mergeCols, col.Tag) {
+			mergeCol.Tag = col.Tag
+			if merged, reason := mergeAddedColumn(col, mergeCol); reason != "" {
+				addConflict(col.Name, reason)
+			} else {
+				mergedCols[col.Tag] = merged
+				tagMapping[tag] = col.Tag
+			}
+
+			return false, nil
+		}
+
+		tags = append(tags, tag)
+		mergedCols[tag] = mergeCol
+		return false, nil
+	})
+
+	var merged []schema.Column
+	colsByName := make(map[string]schema.Column)
+	for _, tag := range tags {
+		col, ok := mergedCols[tag]
+		if !ok {
+			continue
+		}
+
+		lwrName := strings.ToLower(col.Name)
+		if other, ok := colsByName[lwrName]; ok {
+			addConflict(col.Name, "the name is used by another column after the merge (tags %d and %d)", other.Tag, col.Tag)
+			continue
+		}
+
+		colsByName[lwrName] = col
+		merged = append(merged, col)
+	}
+
+	colColl, err := schema.NewColCollection(merged...)
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	mergedSch := schema.SchemaFromCols(colColl)
+
+	if len(conflicts) == 0 {
+		if err := schema.ValidateMergeStrategies(mergedSch); err != nil {
+			addConflict("", err.Error())
+		}
+	}
+
+	// indexes are merged even if some columns conflict, so that all conflicts are reported at once
+	conflicts = append(conflicts, mergeIndexes(sch, mergeSch, ancSch, mergedSch, tagMapping)...)
+
+	if len(conflicts) > 0 {
+		return nil, nil, conflicts, nil
+	}
+
+	return mergedSch, tagMapping, nil, nil
+}
+
+// mergeColumn merges the changes made on both sides of a merge to a column which existed in their common ancestor. If
+// the column can't be merged the reason is returned.
+func mergeColumn(anc, ours, theirs schema.Column) (schema.Column, string) {
+	merged := ours
+
+	if ours.IsPartOfPK != theirs.IsPartOfPK {
+		return schema.Column{}, "changed to be part of the primary key on one side only"
+	}
+
+	switch pick(anc.Name == ours.Name, anc.Name == theirs.Name, ours.Name == theirs.Name) {
+	case pickTheirs:
+		merged.Name = theirs.Name
+	case pickNone:
+		return schema.Column{}, fmt.Sprintf("renamed to '%s' on our side and '%s' on their side", ours.Name, theirs.Name)
+	}
+
+	// the merged type must be able to hold the values of the column from both sides and from the ancestor
+	if !ours.TypeInfo.Equals(theirs.TypeInfo) {
+		var widest schema.Column
+		switch {
+		case ours.TypeInfo.Equals(anc.TypeInfo):
+			widest = theirs
+		case theirs.TypeInfo.Equals(anc.TypeInfo):
+			widest = ours
+		case typeinfo.IsWidening(ours.TypeInfo, theirs.TypeInfo):
+			widest = theirs
+		default:
+			widest = ours
+		}
+
+		for _, col := range []schema.Column{anc, ours, theirs} {
+			if !typeinfo.IsWidening(col.TypeInfo, widest.TypeInfo) {
+				return schema.Column{}, fmt.Sprintf("type is %s on our side and %s on their side, which can't be merged", sqlTypeString(ours), sqlTypeString(theirs))
+			}
+		}
+
+		merged.TypeInfo = widest.TypeInfo
+		merged.Kind = widest.Kind
+	}
+
+	switch pick(schema.ColConstraintsAreEqual(anc.Constraints, ours.Constraints), schema.ColConstraintsAreEqual(anc.Constraints, theirs.Constraints), schema.ColConstraintsAreEqual(ours.Constraints, theirs.Constraints)) {
+	case pickTheirs:
+		merged.Constraints = theirs.Constraints
+	case pickNone:
+		return schema.Column{}, "constraints changed differently on both sides"
+	}
+
+	switch pick(anc.MergeStrategy == ours.MergeStrategy, anc.MergeStrategy == theirs.MergeStrategy, ours.MergeStrategy == theirs.MergeStrategy) {
+	case pickTheirs:
+		merged.MergeStrategy = theirs.MergeStrategy
+	case pickNone:
+		return schema.Column{}, fmt.Sprintf("merge strategy changed to '%s' on our side and '%s' on their side", ours.MergeStrategy.String(), theirs.MergeStrategy.String())
+	}
+
+	return merged, ""
+}
+
+// mergeAddedColumn merges two columns with the same tag which were added on each side of a merge. The merged column has
+// the wider of the two types, and is nullable if either column is. If the columns can't be merged the reason is
+// returned.
+func mergeAddedColumn(ours, theirs schema.Column) (schema.Column, string) {
+	merged := ours
+
+	if !strings.EqualFold(ours.Name, theirs.Name) {
+		return schema.Column{}, fmt.Sprintf("added as '%s' on our side and '%s' on their side", ours.Name, theirs.Name)
+	} else if ours.IsPartOfPK != theirs.IsPartOfPK {
+		return schema.Column{}, "added as part of the primary key on one side only"
+	}
+
+	if typeinfo.IsWidening(ours.TypeInfo, theirs.TypeInfo) {
+		merged.TypeInfo = theirs.TypeInfo
+		merged.Kind = theirs.Kind
+	} else if !typeinfo.IsWidening(theirs.TypeInfo, ours.TypeInfo) {
+		return schema.Column{}, fmt.Sprintf("added with type %s on our side and %s on their side", sqlTypeString(ours), sqlTypeString(theirs))
+	}
+
+	if theirs.IsNullable() {
+		merged.Constraints = theirs.Constraints
+	}
+
+	if ours.MergeStrategy.Type == schema.NoMergeStrategy {
+		merged.MergeStrategy = theirs.MergeStrategy
+	} else if theirs.MergeStrategy.Type != schema.NoMergeStrategy && ours.MergeStrategy != theirs.MergeStrategy {
+		return schema.Column{}, fmt.Sprintf("added with merge strategy '%s' on our side and '%s' on their side", ours.MergeStrategy.String(), theirs.MergeStrategy.String())
+	}
+
+	return merged, ""
+}
+
+// indexDef is the definition of an index which is compared during a merge
+type indexDef struct {
+	tags     []uint64
+	isUnique bool
+	comment  string
+}
+
+func newIndexDef(index schema.Index, tagMapping map[uint64]uint64) *indexDef {
+	if index == nil {
+		return nil
+	}
+
+	var tags []uint64
+	for _, tag := range index.IndexedColumnTags() {
+		if mapped, ok := tagMapping[tag]; ok {
+			tag = mapped
+		}
+		tags = append(tags, tag)
+	}
+
+	return &indexDef{tags, index.IsUnique(), index.Comment()}
+}
+
+func (def *indexDef) equals(other *indexDef) bool {
+	if def == nil || other == nil {
+		return def == other
+	} else if def.isUnique != other.isUnique || def.comment != other.comment || len(def.tags) != len(other.tags) {
+		return false
+	}
+
+	for i := range def.tags {
+		if def.tags[i] != other.tags[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// mergeIndexes adds the indexes of the merged schema, matching the indexes of each version of the table by name.
+// Indexes added or changed on their side are merged in, but an index dropped on their side is kept.  Indexes on columns
+// which were dropped are dropped as well.
+func mergeIndexes(sch, mergeSch, ancSch, mergedSch schema.Schema, tagMapping map[uint64]uint64) []SchemaConflict {
+	names := make(map[string]struct{})
+	for _, s := range []schema.Schema{sch, mergeSch, ancSch} {
+		for _, index := range s.Indexes().AllIndexes() {
+			names[index.Name()] = struct{}{}
+		}
+	}
+
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	var conflicts []SchemaConflict
+	for _, name := range sortedNames {
+		anc := newIndexDef(ancSch.Indexes().Get(name), nil)
+		ours := newIndexDef(sch.Indexes().Get(name), nil)
+		theirs := newIndexDef(mergeSch.Indexes().Get(name), tagMapping)
+
+		merged := ours
+		switch pick(anc.equals(ours), anc.equals(theirs), ours.equals(theirs)) {
+		case pickTheirs:
+			// an index dropped on their side is kept, as a merge never removes an index from our side of the table
+			if theirs != nil {
+				merged = theirs
+			}
+		case pickNone:
+			conflicts = append(conflicts, SchemaConflict{IndexSchemaConflict, name, "changed differently on both sides"})
+			continue
+		}
+
+		if merged == nil {
+			continue
+		}
+
+		allColsExist := true
+		for _, tag := range merged.tags {
+			if !hasTag(mergedSch.GetAllCols(), tag) {
+				allColsExist = false
+			}
+		}
+
+		if !allColsExist {
+			continue
+		}
+
+		_, err := mergedSch.Indexes().AddIndexByColTags(name, merged.tags, merged.isUnique, merged.comment)
+
+		if err != nil {
+			conflicts = append(conflicts, SchemaConflict{IndexSchemaConflict, name, err.Error()})
+		}
+	}
+
+	return conflicts
+}
+
+type pickResult int
+
+const (
+	pickOurs pickResult = iota
+	pickTheirs
+	pickNone
+)
+
+// pick chooses which side's version of a value is kept by a three way merge, given which of the ancestor's, our and
+// their versions are equal
+func pick(ancEqOurs, ancEqTheirs, oursEqTheirs bool) pickResult {
+	switch {
+	case oursEqTheirs || ancEqTheirs:
+		return pickOurs
+	case ancEqOurs:
+		return pickTheirs
+	default:
+		return pickNone
+	}
+}
+
+func hasTag(cols *schema.ColCollection, tag uint64) bool {
+	_, ok := cols.GetByTag(tag)
+	return ok
+}
+
+func sqlTypeString(col schema.Column) string {
+	return col.TypeInfo.ToSqlType().String()
+}
+
+// GetSchemaConflicts returns the changes to the schema of a table which couldn't be merged, or doltdb.ErrNoConflicts if
+// the table doesn't have a schema conflict.
+func GetSchemaConflicts(ctx context.Context, tbl *doltdb.Table) ([]SchemaConflict, error) {
+	ancTbl, ourTbl, theirTbl, err := tbl.GetSchemaConflict(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []schema.Schema
+	for _, t := range []*doltdb.Table{ourTbl, theirTbl, ancTbl} {
+		sch, err := t.GetSchema(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		schemas = append(schemas, sch)
+	}
+
+	_, _, conflicts, err := mergeTableSchema(schemas[0], schemas[1], schemas[2])
+	return conflicts, err
+}
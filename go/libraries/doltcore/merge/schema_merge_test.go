@@ -0,0 +1,177 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+type testIndex struct {
+	name     string
+	tags     []uint64
+	isUnique bool
+}
+
+func mustMergeSchema(t *testing.T, cols []schema.Column, indexes ...testIndex) schema.Schema {
+	colColl, err := schema.NewColCollection(cols...)
+	require.NoError(t, err)
+	sch := schema.SchemaFromCols(colColl)
+
+	for _, index := range indexes {
+		_, err = sch.Indexes().AddIndexByColTags(index.name, index.tags, index.isUnique, "")
+		require.NoError(t, err)
+	}
+
+	return sch
+}
+
+func indexDefs(sch schema.Schema) map[string]*indexDef {
+	defs := make(map[string]*indexDef)
+	for _, index := range sch.Indexes().AllIndexes() {
+		defs[index.Name()] = newIndexDef(index, nil)
+	}
+
+	return defs
+}
+
+func intCol(t *testing.T, name string, tag uint64, ti typeinfo.TypeInfo) schema.Column {
+	col, err := schema.NewColumnWithTypeInfo(name, tag, ti, false)
+	require.NoError(t, err)
+	return col
+}
+
+func TestMergeTableSchema(t *testing.T) {
+	pk := schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{})
+	anc := mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)})
+
+	ancWithIndex := mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}, testIndex{"idx_a", []uint64{1}, true})
+
+	tests := []struct {
+		name              string
+		anc               schema.Schema
+		sch, mergeSch     schema.Schema
+		expected          schema.Schema
+		expectedMapping   map[uint64]uint64
+		expectedConflicts []SchemaConflict
+	}{
+		{
+			name:     "rename on one side and type widening on the other",
+			sch:      mustMergeSchema(t, []schema.Column{pk, intCol(t, "aa", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}),
+			mergeSch: mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int64Type), intCol(t, "b", 2, typeinfo.Int32Type)}),
+			expected: mustMergeSchema(t, []schema.Column{pk, intCol(t, "aa", 1, typeinfo.Int64Type), intCol(t, "b", 2, typeinfo.Int32Type)}),
+		},
+		{
+			name:            "column added on both sides with different tags",
+			sch:             mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type), intCol(t, "c", 3, typeinfo.Int16Type)}),
+			mergeSch:        mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type), intCol(t, "C", 4, typeinfo.Int32Type)}),
+			expected:        mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type), intCol(t, "c", 3, typeinfo.Int32Type)}),
+			expectedMapping: map[uint64]uint64{4: 3},
+		},
+		{
+			name:     "column dropped on one side and index added on the other",
+			sch:      mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type)}),
+			mergeSch: mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}, testIndex{"idx_a", []uint64{1}, true}),
+			expected: mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type)}, testIndex{"idx_a", []uint64{1}, true}),
+		},
+		{
+			name:     "index added on their side",
+			sch:      mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}),
+			mergeSch: mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}, testIndex{"idx_b", []uint64{2}, false}),
+			expected: mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}, testIndex{"idx_b", []uint64{2}, false}),
+		},
+		{
+			name:     "index dropped on our side",
+			anc:      ancWithIndex,
+			sch:      mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}),
+			mergeSch: mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}, testIndex{"idx_a", []uint64{1}, true}),
+			expected: mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}),
+		},
+		{
+			name:     "index dropped on their side",
+			anc:      ancWithIndex,
+			sch:      mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}, testIndex{"idx_a", []uint64{1}, true}),
+			mergeSch: mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}),
+			expected: mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}, testIndex{"idx_a", []uint64{1}, true}),
+		},
+		{
+			name:     "renamed differently on both sides",
+			sch:      mustMergeSchema(t, []schema.Column{pk, intCol(t, "x", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}),
+			mergeSch: mustMergeSchema(t, []schema.Column{pk, intCol(t, "y", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}),
+			expectedConflicts: []SchemaConflict{
+				{ColumnSchemaConflict, "x", "renamed to 'x' on our side and 'y' on their side"},
+			},
+		},
+		{
+			name:     "modified on one side and dropped on the other",
+			sch:      mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int64Type)}),
+			mergeSch: mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type)}),
+			expectedConflicts: []SchemaConflict{
+				{ColumnSchemaConflict, "b", "modified on our side and dropped on their side"},
+			},
+		},
+		{
+			name:     "added on both sides with incompatible types",
+			sch:      mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type), intCol(t, "c", 3, typeinfo.Int32Type)}),
+			mergeSch: mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type), intCol(t, "c", 3, typeinfo.Uint32Type)}),
+			expectedConflicts: []SchemaConflict{
+				{ColumnSchemaConflict, "c", "added with type INT on our side and INT UNSIGNED on their side"},
+			},
+		},
+		{
+			name:     "index changed differently on both sides",
+			sch:      mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}, testIndex{"idx", []uint64{1}, false}),
+			mergeSch: mustMergeSchema(t, []schema.Column{pk, intCol(t, "a", 1, typeinfo.Int32Type), intCol(t, "b", 2, typeinfo.Int32Type)}, testIndex{"idx", []uint64{2}, false}),
+			expectedConflicts: []SchemaConflict{
+				{IndexSchemaConflict, "idx", "changed differently on both sides"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ancSch := anc
+			if test.anc != nil {
+				ancSch = test.anc
+			}
+
+			merged, tagMapping, conflicts, err := mergeTableSchema(test.sch, test.mergeSch, ancSch)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedConflicts, conflicts)
+
+			if test.expected == nil {
+				assert.Nil(t, merged)
+				return
+			}
+
+			eq, err := schema.SchemasAreEqual(test.expected, merged)
+			require.NoError(t, err)
+			assert.True(t, eq)
+			assert.Equal(t, indexDefs(test.expected), indexDefs(merged))
+
+			if test.expectedMapping == nil {
+				assert.Empty(t, tagMapping)
+			} else {
+				assert.Equal(t, test.expectedMapping, tagMapping)
+			}
+		})
+	}
+}
@@ -84,3 +84,14 @@ func TestBranchName(t *testing.T) {
 	assert.Equal(t, false, IsValidBranchName("HEAD"))
 	assert.Equal(t, false, IsValidBranchName("-"))
 }
+
+func TestTagName(t *testing.T) {
+	assert.Equal(t, true, IsValidTagName("v1"))
+	assert.Equal(t, true, IsValidTagName("release/v1"))
+	assert.Equal(t, true, IsValidTagName("refs/tags/v1"))
+
+	assert.Equal(t, false, IsValidTagName(""))
+	assert.Equal(t, false, IsValidTagName("refs/heads/v1"))
+	assert.Equal(t, false, IsValidTagName("refs/tags/"))
+	assert.Equal(t, false, IsValidTagName("this-is-a-..-test"))
+}
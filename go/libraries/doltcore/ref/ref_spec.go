@@ -101,6 +101,8 @@ func ParseRefSpecForRemote(remote, refSpecStr string) (RefSpec, error) {
 		return newLocalToRemoteTrackingRef(remote, fromRef.(BranchRef), toRef.(RemoteRef))
 	} else if fromRef.GetType() == BranchRefType && toRef.GetType() == BranchRefType {
 		return NewBranchToBranchRefSpec(fromRef.(BranchRef), toRef.(BranchRef))
+	} else if fromRef.GetType() == TagRefType && toRef.GetType() == TagRefType {
+		return newTagToTagRefSpec(remote, fromRef.(TagRef), toRef.(TagRef))
 	}
 
 	return nil, ErrUnsupportedMapping
@@ -244,7 +246,83 @@ func (rs BranchToTrackingBranchRefSpec) DestRef(branchRef DoltRef) DoltRef {
 	return nil
 }
 
+// IsDestRef returns whether r is a remote tracking branch which matches the refspec's remote pattern, meaning that it
+// is updated by fetching using the refspec.
+func (rs BranchToTrackingBranchRefSpec) IsDestRef(r DoltRef) bool {
+	if r.GetType() == RemoteRefType {
+		_, matches := rs.remPattern.matches(r.GetPath())
+		return matches
+	}
+
+	return false
+}
+
 // GetRemote returns the name of the remote being operated on.
 func (rs BranchToTrackingBranchRefSpec) GetRemote() string {
 	return rs.remote
 }
+
+// TagToTagRefSpec maps tags in one database to tags in another.  Unlike branches, tags are not mapped to remote
+// tracking refs, so a tag named v1 on the remote is stored as the tag v1 locally.
+type TagToTagRefSpec struct {
+	srcPattern pattern
+	destMapper branchMapper
+	remote     string
+}
+
+func newTagToTagRefSpec(remote string, srcRef, destRef TagRef) (RefSpec, error) {
+	srcWCs := strings.Count(srcRef.GetPath(), "*")
+	destWCs := strings.Count(destRef.GetPath(), "*")
+
+	if srcWCs != destWCs || srcWCs > 1 {
+		return nil, ErrInvalidRefSpec
+	}
+
+	if srcWCs == 0 {
+		return TagToTagRefSpec{
+			srcPattern: strPattern(srcRef.GetPath()),
+			destMapper: identityBranchMapper(destRef.GetPath()),
+			remote:     remote,
+		}, nil
+	}
+
+	return TagToTagRefSpec{
+		srcPattern: newWildcardPattern(srcRef.GetPath()),
+		destMapper: newWildcardBranchMapper(destRef.GetPath()),
+		remote:     remote,
+	}, nil
+}
+
+// SrcRef returns the source tag if the ref spec names a single tag.  Otherwise it returns the ref passed in if it is a
+// tag matching the source pattern of the ref spec, and nil if it is not.
+func (rs TagToTagRefSpec) SrcRef(cwbRef DoltRef) DoltRef {
+	if sp, ok := rs.srcPattern.(strPattern); ok {
+		return NewTagRef(string(sp))
+	}
+
+	if cwbRef != nil && cwbRef.GetType() == TagRefType {
+		if _, matches := rs.srcPattern.matches(cwbRef.GetPath()); matches {
+			return cwbRef
+		}
+	}
+
+	return nil
+}
+
+// DestRef verifies the tagRef matches the refspec's source pattern, and then maps it to the destination tag, or to
+// nil if it does not match the pattern.
+func (rs TagToTagRefSpec) DestRef(tagRef DoltRef) DoltRef {
+	if tagRef.GetType() == TagRefType {
+		captured, matches := rs.srcPattern.matches(tagRef.GetPath())
+		if matches {
+			return NewTagRef(rs.destMapper.mapBranch(captured))
+		}
+	}
+
+	return nil
+}
+
+// GetRemote returns the name of the remote being operated on.
+func (rs TagToTagRefSpec) GetRemote() string {
+	return rs.remote
+}
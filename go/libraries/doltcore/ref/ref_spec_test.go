@@ -114,3 +114,115 @@ func TestRefSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestTrackingRefSpecIsDestRef(t *testing.T) {
+	tests := []struct {
+		refSpecStr  string
+		refToIsDest map[string]bool
+	}{
+		{
+			"refs/heads/*:refs/remotes/origin/*",
+			map[string]bool{
+				"refs/remotes/origin/master":    true,
+				"refs/remotes/origin/feature/a": true,
+				"refs/remotes/borigin/master":   false,
+				"refs/heads/master":             false,
+			},
+		}, {
+			"refs/heads/master:refs/remotes/origin/mymaster",
+			map[string]bool{
+				"refs/remotes/origin/mymaster": true,
+				"refs/remotes/origin/master":   false,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		refSpec, err := ParseRefSpecForRemote("origin", test.refSpecStr)
+
+		if err != nil {
+			t.Error(test.refSpecStr, err)
+			continue
+		}
+
+		trackingSpec := refSpec.(BranchToTrackingBranchRefSpec)
+		for refStr, expected := range test.refToIsDest {
+			r, _ := Parse(refStr)
+
+			if actual := trackingSpec.IsDestRef(r); actual != expected {
+				t.Error(test.refSpecStr, "IsDestRef", refStr, "is", actual, "expected", expected)
+			}
+		}
+	}
+}
+
+func TestTagRefSpec(t *testing.T) {
+	tests := []struct {
+		refSpecStr string
+		isValid    bool
+		inToExpOut map[string]string
+	}{
+		{
+			"refs/tags/*:refs/tags/*",
+			true,
+			map[string]string{
+				"refs/tags/v1":               "refs/tags/v1",
+				"refs/tags/releases/2020-05": "refs/tags/releases/2020-05",
+				"refs/heads/master":          "refs/nil/",
+				"refs/remotes/origin/master": "refs/nil/",
+			},
+		}, {
+			"refs/tags/v1:refs/tags/v1",
+			true,
+			map[string]string{
+				"refs/tags/v1": "refs/tags/v1",
+				"refs/tags/v2": "refs/nil/",
+			},
+		}, {
+			"refs/tags/*:refs/tags/upstream/*",
+			true,
+			map[string]string{
+				"refs/tags/v1": "refs/tags/upstream/v1",
+			},
+		}, {
+			"refs/tags/*:refs/tags/v1",
+			false,
+			nil,
+		}, {
+			"refs/tags/*:refs/heads/*",
+			false,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		refSpec, err := ParseRefSpecForRemote("origin", test.refSpecStr)
+
+		if (err == nil) != test.isValid {
+			t.Error(test.refSpecStr, "is valid:", err == nil)
+		} else if err == nil {
+			if rrs, ok := refSpec.(RemoteRefSpec); !ok || rrs.GetRemote() != "origin" {
+				t.Error(test.refSpecStr, "is not a remote ref spec for origin")
+			}
+
+			for in, out := range test.inToExpOut {
+				inRef, _ := Parse(in)
+				outRef, _ := Parse(out)
+
+				actual := refSpec.DestRef(inRef)
+
+				if !Equals(actual, outRef) {
+					t.Error(test.refSpecStr, "mapped", in, "to", actual, "expected", outRef)
+				}
+			}
+		}
+	}
+
+	refSpec, err := ParseRefSpecForRemote("origin", "refs/tags/v1")
+
+	if err != nil {
+		t.Error(err)
+	} else if srcRef := refSpec.SrcRef(NewBranchRef("master")); !Equals(srcRef, NewTagRef("v1")) {
+		t.Error("refs/tags/v1 has source ref", srcRef)
+	}
+}
@@ -40,6 +40,14 @@ func TestJsonMarshalAndUnmarshal(t *testing.T) {
 			NewInternalRef("create"),
 			`{"test":"refs/internal/create"}`,
 		},
+		{
+			NewTagRef("v1"),
+			`{"test":"refs/tags/v1"}`,
+		},
+		{
+			NewStashRef("1"),
+			`{"test":"refs/stashes/1"}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -134,6 +142,21 @@ func TestEqualsStr(t *testing.T) {
 			"refs/internal/create",
 			true,
 		},
+		{
+			NewTagRef("v1"),
+			"refs/tags/v1",
+			true,
+		},
+		{
+			NewTagRef("refs/tags/v1"),
+			"refs/heads/v1",
+			false,
+		},
+		{
+			NewStashRef("refs/stashes/1"),
+			"refs/stashes/1",
+			true,
+		},
 	}
 
 	for _, test := range tests {
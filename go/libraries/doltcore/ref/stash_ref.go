@@ -0,0 +1,55 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ref
+
+import "strings"
+
+// StashRef is a reference to an entry in the stash of uncommitted changes
+type StashRef struct {
+	id string
+}
+
+// GetType will return StashRefType
+func (sr StashRef) GetType() RefType {
+	return StashRefType
+}
+
+// GetPath returns the id of the stash entry
+func (sr StashRef) GetPath() string {
+	return sr.id
+}
+
+// String returns the fully qualified reference name e.g. refs/stashes/1
+func (sr StashRef) String() string {
+	return String(sr)
+}
+
+func (sr StashRef) MarshalJSON() ([]byte, error) {
+	return MarshalJSON(sr)
+}
+
+// NewStashRef creates a reference to a stash entry from a stash id or a stash ref e.g. 1, or refs/stashes/1
+func NewStashRef(id string) StashRef {
+	if IsRef(id) {
+		prefix := PrefixForType(StashRefType)
+		if strings.HasPrefix(id, prefix) {
+			id = id[len(prefix):]
+		} else {
+			panic(id + " is a ref that is not of type " + prefix)
+		}
+	}
+
+	return StashRef{id}
+}
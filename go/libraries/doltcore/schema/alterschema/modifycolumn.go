@@ -23,6 +23,7 @@ import (
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
 
@@ -85,7 +86,8 @@ func validateModifyColumn(ctx context.Context, tbl *doltdb.Table, existingCol sc
 		return err
 	}
 
-	if existingCol.Kind != modifiedCol.Kind || !existingCol.TypeInfo.Equals(modifiedCol.TypeInfo) {
+	// widening a type doesn't change how values are stored, so the rows of the table stay valid
+	if existingCol.Kind != modifiedCol.Kind || !typeinfo.IsWidening(existingCol.TypeInfo, modifiedCol.TypeInfo) {
 		return errors.New("unsupported feature: column types cannot be changed")
 	}
 
@@ -43,6 +43,7 @@ var (
 		false,
 		typeinfo.UnknownType,
 		nil,
+		MergeStrategy{},
 	}
 )
 
@@ -72,6 +73,10 @@ type Column struct {
 
 	// Constraints are rules that can be checked on each column to say if the columns value is valid
 	Constraints []ColConstraint
+
+	// MergeStrategy says how a merge resolves a value of this column which was changed differently on both sides of
+	// the merge
+	MergeStrategy MergeStrategy
 }
 
 // NewColumn creates a Column instance with the default type info for the NomsKind
@@ -103,6 +108,7 @@ func NewColumnWithTypeInfo(name string, tag uint64, typeInfo typeinfo.TypeInfo,
 		partOfPK,
 		typeInfo,
 		constraints,
+		MergeStrategy{},
 	}, nil
 }
 
@@ -123,7 +129,8 @@ func (c Column) Equals(other Column) bool {
 		c.Kind == other.Kind &&
 		c.IsPartOfPK == other.IsPartOfPK &&
 		c.TypeInfo.Equals(other.TypeInfo) &&
-		ColConstraintsAreEqual(c.Constraints, other.Constraints)
+		ColConstraintsAreEqual(c.Constraints, other.Constraints) &&
+		c.MergeStrategy == other.MergeStrategy
 }
 
 // KindString returns the string representation of the NomsKind stored in the column.
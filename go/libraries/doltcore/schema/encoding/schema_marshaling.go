@@ -47,6 +47,12 @@ type encodedColumn struct {
 
 	Constraints []encodedConstraint `noms:"col_constraints" json:"col_constraints"`
 
+	// MergeStrategy and MergeStrategyColumn are only written for columns with a merge strategy, so that the
+	// schemas of all other columns are unchanged
+	MergeStrategy string `noms:"merge_strategy,omitempty" json:"merge_strategy,omitempty"`
+
+	MergeStrategyColumn string `noms:"merge_strategy_column,omitempty" json:"merge_strategy_column,omitempty"`
+
 	// NB: all new fields must have the 'omitempty' annotation. See comment above
 }
 
@@ -83,6 +89,8 @@ func encodeColumn(col schema.Column) encodedColumn {
 		col.IsPartOfPK,
 		encodeTypeInfo(col.TypeInfo),
 		encodeAllColConstraints(col.Constraints),
+		string(col.MergeStrategy.Type),
+		col.MergeStrategy.Column,
 	}
 }
 
@@ -100,7 +108,13 @@ func (nfd encodedColumn) decodeColumn() (schema.Column, error) {
 		return schema.Column{}, errors.New("cannot decode column due to unknown schema format")
 	}
 	colConstraints := decodeAllColConstraint(nfd.Constraints)
-	return schema.NewColumnWithTypeInfo(nfd.Name, nfd.Tag, typeInfo, nfd.IsPartOfPK, colConstraints...)
+	col, err := schema.NewColumnWithTypeInfo(nfd.Name, nfd.Tag, typeInfo, nfd.IsPartOfPK, colConstraints...)
+	if err != nil {
+		return schema.Column{}, err
+	}
+
+	col.MergeStrategy = schema.MergeStrategy{Type: schema.MergeStrategyType(nfd.MergeStrategy), Column: nfd.MergeStrategyColumn}
+	return col, nil
 }
 
 type encodedConstraint struct {
@@ -41,6 +41,8 @@ func createTestSchema() schema.Schema {
 		schema.NewColumn("age", 3, types.UintKind, false),
 	}
 
+	columns[3].MergeStrategy = schema.MergeStrategy{Type: schema.LatestByColumnMergeStrategy, Column: "last"}
+
 	colColl, _ := schema.NewColCollection(columns...)
 	sch := schema.SchemaFromCols(colColl)
 	_, _ = sch.Indexes().AddIndexByColTags("idx_age", []uint64{3}, false, "")
@@ -224,6 +226,12 @@ type testEncodedColumn struct {
 	TypeInfo encodedTypeInfo `noms:"typeinfo" json:"typeinfo"`
 
 	Constraints []encodedConstraint `noms:"col_constraints" json:"col_constraints"`
+
+	// columns without a merge strategy are written exactly as they were before merge strategies existed, so that
+	// their schemas keep the same hash
+	MergeStrategy string `noms:"merge_strategy,omitempty" json:"merge_strategy,omitempty"`
+
+	MergeStrategyColumn string `noms:"merge_strategy_column,omitempty" json:"merge_strategy_column,omitempty"`
 }
 
 type testEncodedIndex struct {
@@ -252,7 +260,13 @@ func (tec testEncodedColumn) decodeColumn() (schema.Column, error) {
 		return schema.Column{}, errors.New("cannot decode column due to unknown schema format")
 	}
 	colConstraints := decodeAllColConstraint(tec.Constraints)
-	return schema.NewColumnWithTypeInfo(tec.Name, tec.Tag, typeInfo, tec.IsPartOfPK, colConstraints...)
+	col, err := schema.NewColumnWithTypeInfo(tec.Name, tec.Tag, typeInfo, tec.IsPartOfPK, colConstraints...)
+	if err != nil {
+		return schema.Column{}, err
+	}
+
+	col.MergeStrategy = schema.MergeStrategy{Type: schema.MergeStrategyType(tec.MergeStrategy), Column: tec.MergeStrategyColumn}
+	return col, nil
 }
 
 func (tsd testSchemaData) decodeSchema() (schema.Schema, error) {
@@ -0,0 +1,149 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// MergeStrategyType is the name of a way of resolving a cell which was changed differently on both sides of a merge.
+type MergeStrategyType string
+
+const (
+	// NoMergeStrategy means that a cell changed differently on both sides of a merge is a conflict
+	NoMergeStrategy MergeStrategyType = ""
+
+	// OursMergeStrategy keeps the value from the branch being merged into
+	OursMergeStrategy MergeStrategyType = "ours"
+
+	// TheirsMergeStrategy keeps the value from the branch being merged
+	TheirsMergeStrategy MergeStrategyType = "theirs"
+
+	// MaxMergeStrategy keeps the larger of the two values
+	MaxMergeStrategy MergeStrategyType = "max"
+
+	// MinMergeStrategy keeps the smaller of the two values
+	MinMergeStrategy MergeStrategyType = "min"
+
+	// SumOfDeltasMergeStrategy applies the changes made on both sides to the value in the common ancestor, so that
+	// increments made on both sides to a counter are both kept.
+	SumOfDeltasMergeStrategy MergeStrategyType = "sum-of-deltas"
+
+	// LatestByColumnMergeStrategy keeps the value from the side whose row has the larger value in another column,
+	// such as a last modified timestamp.
+	LatestByColumnMergeStrategy MergeStrategyType = "latest-by-column"
+)
+
+var mergeStrategyTypes = []MergeStrategyType{
+	OursMergeStrategy,
+	TheirsMergeStrategy,
+	MaxMergeStrategy,
+	MinMergeStrategy,
+	SumOfDeltasMergeStrategy,
+	LatestByColumnMergeStrategy,
+}
+
+// MergeStrategy says how a merge resolves a cell of a column which was changed differently on both sides of the merge.
+// The zero value is NoMergeStrategy.
+type MergeStrategy struct {
+	Type MergeStrategyType
+
+	// Column is the name of the column compared by LatestByColumnMergeStrategy
+	Column string
+}
+
+// ParseMergeStrategy parses a merge strategy written as its type, followed by the name of the column compared for
+// latest-by-column.
+func ParseMergeStrategy(str string) (MergeStrategy, error) {
+	fields := strings.Fields(str)
+
+	if len(fields) == 0 {
+		return MergeStrategy{}, fmt.Errorf("missing merge strategy, expected one of %s", mergeStrategyList())
+	}
+
+	msType := MergeStrategyType(strings.ToLower(fields[0]))
+	for _, t := range mergeStrategyTypes {
+		if t != msType {
+			continue
+		}
+
+		if t == LatestByColumnMergeStrategy {
+			if len(fields) != 2 {
+				return MergeStrategy{}, fmt.Errorf("merge strategy %s takes the name of a column", t)
+			}
+
+			return MergeStrategy{t, fields[1]}, nil
+		} else if len(fields) != 1 {
+			return MergeStrategy{}, fmt.Errorf("merge strategy %s doesn't take a column", t)
+		}
+
+		return MergeStrategy{Type: t}, nil
+	}
+
+	return MergeStrategy{}, fmt.Errorf("unknown merge strategy '%s', expected one of %s", fields[0], mergeStrategyList())
+}
+
+func mergeStrategyList() string {
+	strs := make([]string, len(mergeStrategyTypes))
+	for i, t := range mergeStrategyTypes {
+		strs[i] = string(t)
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+// String returns the merge strategy in the form read by ParseMergeStrategy
+func (ms MergeStrategy) String() string {
+	if ms.Type == LatestByColumnMergeStrategy {
+		return string(ms.Type) + " " + ms.Column
+	}
+
+	return string(ms.Type)
+}
+
+// ValidateMergeStrategies checks that the merge strategy of each column of a schema can be used with it.  Primary key
+// columns can't have a merge strategy, sum-of-deltas can only be used with integer and floating point columns, and
+// latest-by-column must name another non primary key column of the schema.
+func ValidateMergeStrategies(sch Schema) error {
+	return sch.GetAllCols().Iter(func(tag uint64, col Column) (stop bool, err error) {
+		ms := col.MergeStrategy
+
+		if ms.Type == NoMergeStrategy {
+			return false, nil
+		} else if col.IsPartOfPK {
+			return true, fmt.Errorf("primary key column '%s' can't have a merge strategy", col.Name)
+		}
+
+		switch ms.Type {
+		case SumOfDeltasMergeStrategy:
+			if col.Kind != types.IntKind && col.Kind != types.UintKind && col.Kind != types.FloatKind {
+				return true, fmt.Errorf("merge strategy %s can't be used with the %s column '%s'", ms.Type, col.TypeInfo.ToSqlType().String(), col.Name)
+			}
+		case LatestByColumnMergeStrategy:
+			byCol, ok := sch.GetAllCols().GetByNameCaseInsensitive(ms.Column)
+
+			if !ok {
+				return true, fmt.Errorf("column '%s' compared by the merge strategy of '%s' does not exist", ms.Column, col.Name)
+			} else if byCol.IsPartOfPK || byCol.Tag == col.Tag {
+				return true, fmt.Errorf("merge strategy of '%s' must compare another non primary key column", col.Name)
+			}
+		}
+
+		return false, nil
+	})
+}
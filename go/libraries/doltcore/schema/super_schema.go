@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/liquidata-inc/dolt/go/libraries/utils/set"
 )
 
@@ -64,11 +65,22 @@ func (ss *SuperSchema) AddColumn(col Column) (err error) {
 	if found {
 		if col.IsPartOfPK != existingCol.IsPartOfPK ||
 			col.Kind != existingCol.Kind ||
-			!col.TypeInfo.Equals(existingCol.TypeInfo) {
+			!(typeinfo.IsWidening(col.TypeInfo, existingCol.TypeInfo) || typeinfo.IsWidening(existingCol.TypeInfo, col.TypeInfo)) {
 			ecName := ss.tagNames[col.Tag][0]
 			return fmt.Errorf("tag collision for columns %s and %s, different definitions (tag: %d)",
 				ecName, col.Name, col.Tag)
 		}
+
+		// a column whose type was widened keeps the widest of its types
+		if !typeinfo.IsWidening(col.TypeInfo, existingCol.TypeInfo) {
+			widened := existingCol
+			widened.TypeInfo = col.TypeInfo
+			ss.allCols, err = ss.allCols.Replace(existingCol, widened)
+
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	names, found := ss.tagNames[col.Tag]
@@ -318,7 +330,8 @@ func SuperSchemaUnion(superSchemas ...*SuperSchema) (*SuperSchema, error) {
 func stripColNameAndConstraints(col Column) Column {
 	// track column names in SuperSchema.tagNames
 	col.Name = ""
-	// don't track constraints
+	// don't track constraints or merge strategies
 	col.Constraints = []ColConstraint(nil)
+	col.MergeStrategy = MergeStrategy{}
 	return col
 }
@@ -54,7 +54,17 @@ var nameCollisionWithSch1 = mustSchema([]Column{
 
 var tagCollisionWithSch1 = mustSchema([]Column{
 	strCol("a", 1, true),
-	{"collision", 2, types.IntKind, false, typeinfo.Int32Type, nil},
+	{"collision", 2, types.IntKind, false, typeinfo.Int32Type, nil, MergeStrategy{}},
+})
+
+var narrowIntSch = mustSchema([]Column{
+	strCol("a", 1, true),
+	{"i", 2, types.IntKind, false, typeinfo.Int32Type, nil, MergeStrategy{}},
+})
+
+var wideIntSch = mustSchema([]Column{
+	strCol("a", 1, true),
+	{"i", 2, types.IntKind, false, typeinfo.Int64Type, nil, MergeStrategy{}},
 })
 
 type SuperSchemaTest struct {
@@ -144,6 +154,18 @@ var SuperSchemaTests = []SuperSchemaTest{
 			strCol("b_22", 22, false),
 		}),
 	},
+	{
+		Name:    "SuperSchema keeps the widest type of a column",
+		Schemas: []Schema{narrowIntSch, wideIntSch, narrowIntSch},
+		ExpectedSuperSchema: SuperSchema{
+			allCols: mustColColl([]Column{
+				strCol("", 1, true),
+				{"", 2, types.IntKind, false, typeinfo.Int64Type, nil, MergeStrategy{}},
+			}),
+			tagNames: map[uint64][]string{1: {"a"}, 2: {"i"}},
+		},
+		ExpectedGeneratedSchema: wideIntSch,
+	},
 	{
 		Name:              "SuperSchema errors on tag collision",
 		Schemas:           []Schema{sch1, tagCollisionWithSch1},
@@ -238,5 +260,5 @@ func mustColColl(cols []Column) *ColCollection {
 }
 
 func strCol(name string, tag uint64, isPK bool) Column {
-	return Column{name, tag, types.StringKind, isPK, typeinfo.StringDefaultType, nil}
+	return Column{name, tag, types.StringKind, isPK, typeinfo.StringDefaultType, nil, MergeStrategy{}}
 }
@@ -0,0 +1,95 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/proto/query"
+)
+
+// numberTypeRanks orders the sql integer and floating point types from the narrowest to the widest
+var numberTypeRanks = map[query.Type]int{
+	sqltypes.Int8:    1,
+	sqltypes.Uint8:   1,
+	sqltypes.Int16:   2,
+	sqltypes.Uint16:  2,
+	sqltypes.Int24:   3,
+	sqltypes.Uint24:  3,
+	sqltypes.Int32:   4,
+	sqltypes.Uint32:  4,
+	sqltypes.Int64:   5,
+	sqltypes.Uint64:  5,
+	sqltypes.Float32: 1,
+	sqltypes.Float64: 2,
+}
+
+// IsWidening returns whether every value of the type from is also a valid value of the type to, without being
+// converted. Equal types are widenings of each other.
+func IsWidening(from, to TypeInfo) bool {
+	if from.Equals(to) {
+		return true
+	} else if from.NomsKind() != to.NomsKind() {
+		return false
+	}
+
+	switch fromTi := from.(type) {
+	case *intType:
+		toTi, ok := to.(*intType)
+		return ok && numberTypeRanks[fromTi.sqlIntType.Type()] <= numberTypeRanks[toTi.sqlIntType.Type()]
+	case *uintType:
+		toTi, ok := to.(*uintType)
+		return ok && numberTypeRanks[fromTi.sqlUintType.Type()] <= numberTypeRanks[toTi.sqlUintType.Type()]
+	case *floatType:
+		toTi, ok := to.(*floatType)
+		return ok && numberTypeRanks[fromTi.sqlFloatType.Type()] <= numberTypeRanks[toTi.sqlFloatType.Type()]
+	case *varStringType:
+		toTi, ok := to.(*varStringType)
+		if !ok || fromTi.sqlStringType.Collation() != toTi.sqlStringType.Collation() {
+			return false
+		}
+
+		// CHAR removes trailing spaces, so other string types can't be widened to it
+		if toTi.sqlStringType.Type() == sqltypes.Char && fromTi.sqlStringType.Type() != sqltypes.Char {
+			return false
+		}
+
+		return fromTi.sqlStringType.MaxCharacterLength() <= toTi.sqlStringType.MaxCharacterLength()
+	case *varBinaryType:
+		toTi, ok := to.(*varBinaryType)
+		if !ok {
+			return false
+		}
+
+		// BINARY pads values with zeros, so other binary types can't be widened to it
+		if toTi.sqlBinaryType.Type() == sqltypes.Binary && fromTi.sqlBinaryType.Type() != sqltypes.Binary {
+			return false
+		}
+
+		return fromTi.sqlBinaryType.MaxByteLength() <= toTi.sqlBinaryType.MaxByteLength()
+	case *inlineBlobType:
+		toTi, ok := to.(*inlineBlobType)
+		return ok && fromTi.sqlBinaryType.MaxByteLength() <= toTi.sqlBinaryType.MaxByteLength()
+	case *decimalType:
+		toTi, ok := to.(*decimalType)
+		if !ok {
+			return false
+		}
+
+		fromDec, toDec := fromTi.sqlDecimalType, toTi.sqlDecimalType
+		return fromDec.Scale() <= toDec.Scale() && fromDec.Precision()-fromDec.Scale() <= toDec.Precision()-toDec.Scale()
+	}
+
+	return false
+}
@@ -0,0 +1,57 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsWidening(t *testing.T) {
+	tests := []struct {
+		from     TypeInfo
+		to       TypeInfo
+		widening bool
+	}{
+		{Int8Type, Int8Type, true},
+		{Int8Type, Int64Type, true},
+		{Int64Type, Int8Type, false},
+		{Int8Type, Uint64Type, false},
+		{Uint8Type, Uint32Type, true},
+		{Uint32Type, Uint16Type, false},
+		{Float32Type, Float64Type, true},
+		{Float64Type, Float32Type, false},
+		{Int32Type, Float64Type, false},
+		{generateVarStringType(t, 10, false), generateVarStringType(t, 20, false), true},
+		{generateVarStringType(t, 20, false), generateVarStringType(t, 10, false), false},
+		{generateVarStringType(t, 10, true), generateVarStringType(t, 20, false), true},
+		{generateVarStringType(t, 10, false), generateVarStringType(t, 20, true), false},
+		{generateVarStringType(t, 10, false), StringDefaultType, true},
+		{generateVarBinaryType(t, 10, false), generateVarBinaryType(t, 20, false), true},
+		{generateVarBinaryType(t, 10, false), generateVarBinaryType(t, 20, true), false},
+		{generateDecimalType(t, 10, 2), generateDecimalType(t, 12, 4), true},
+		{generateDecimalType(t, 10, 2), generateDecimalType(t, 10, 4), false},
+		{BoolType, BoolType, true},
+		{BoolType, Int8Type, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.from.String(), test.to.String()), func(t *testing.T) {
+			assert.Equal(t, test.widening, IsWidening(test.from, test.to))
+		})
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	sqle "github.com/liquidata-inc/go-mysql-server"
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+var commitChecksCols, _ = schema.NewColCollection(
+	// CommitChecksNameCol is the name of the primary key column of the commit checks table
+	schema.NewColumn(doltdb.CommitChecksNameCol, doltdb.CommitChecksNameTag, types.StringKind, true, schema.NotNullConstraint{}),
+	// CommitChecksQueryCol is the name of the column containing the query of a commit check
+	schema.NewColumn(doltdb.CommitChecksQueryCol, doltdb.CommitChecksQueryTag, types.StringKind, false, schema.NotNullConstraint{}),
+)
+
+var CommitChecksSchema = schema.SchemaFromCols(commitChecksCols)
+
+var ErrCommitChecksFailed = errors.NewKind("commit checks failed:\n%s")
+
+// CommitCheck is a query stored in the commit checks table.  A commit fails the check if the query returns any rows
+// when run against the tables being committed.
+type CommitCheck struct {
+	Name  string
+	Query string
+}
+
+// CommitCheckFailure holds the rows returned by a commit check which failed.
+type CommitCheckFailure struct {
+	Check  CommitCheck
+	Schema sql.Schema
+	Rows   []sql.Row
+}
+
+// GetCommitChecks returns the commit checks stored in root, ordered by name.
+func GetCommitChecks(ctx context.Context, root *doltdb.RootValue) ([]CommitCheck, error) {
+	tbl, ok, err := root.GetTable(ctx, doltdb.CommitChecksTableName)
+
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	m, err := tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var checks []CommitCheck
+	err = m.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		r, err := row.FromNoms(CommitChecksSchema, key.(types.Tuple), value.(types.Tuple))
+
+		if err != nil {
+			return true, err
+		}
+
+		nameVal, _ := r.GetColVal(doltdb.CommitChecksNameTag)
+		queryVal, _ := r.GetColVal(doltdb.CommitChecksQueryTag)
+
+		check := CommitCheck{Name: string(nameVal.(types.String))}
+		if queryVal != nil {
+			check.Query = string(queryVal.(types.String))
+		}
+
+		checks = append(checks, check)
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return checks, nil
+}
+
+// RunCommitChecks runs the commit checks stored in root against the tables of root, and returns the checks which
+// failed.  Queries are run in a new session of db.
+func RunCommitChecks(ctx context.Context, db Database, root *doltdb.RootValue) ([]CommitCheckFailure, error) {
+	checks, err := GetCommitChecks(ctx, root)
+
+	if err != nil || len(checks) == 0 {
+		return nil, err
+	}
+
+	engine := sqle.NewDefault()
+	engine.AddDatabase(db)
+
+	sqlCtx := sql.NewContext(ctx,
+		sql.WithSession(DefaultDoltSession()),
+		sql.WithIndexRegistry(sql.NewIndexRegistry()),
+		sql.WithViewRegistry(sql.NewViewRegistry())).WithCurrentDB(db.Name())
+
+	err = DSessFromSess(sqlCtx.Session).AddDB(ctx, db)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.SetRoot(sqlCtx, root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = RegisterSchemaFragments(sqlCtx, db, root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []CommitCheckFailure
+	for _, check := range checks {
+		sch, rows, err := runCommitCheck(sqlCtx, engine, check)
+
+		if err != nil {
+			return nil, fmt.Errorf("commit check '%s' failed to run: %s", check.Name, err.Error())
+		}
+
+		if len(rows) > 0 {
+			failures = append(failures, CommitCheckFailure{check, sch, rows})
+		}
+	}
+
+	return failures, nil
+}
+
+func runCommitCheck(sqlCtx *sql.Context, engine *sqle.Engine, check CommitCheck) (sql.Schema, []sql.Row, error) {
+	sch, rowIter, err := engine.Query(sqlCtx, check.Query)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer rowIter.Close()
+
+	var rows []sql.Row
+	for {
+		r, err := rowIter.Next()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		rows = append(rows, r)
+	}
+
+	return sch, rows, nil
+}
+
+// FormatCommitCheckFailures returns a description of the failed checks and the rows they returned, with one line per
+// row.
+func FormatCommitCheckFailures(failures []CommitCheckFailure) string {
+	var lines []string
+	for _, failure := range failures {
+		lines = append(lines, fmt.Sprintf("commit check '%s' returned %d rows:", failure.Check.Name, len(failure.Rows)))
+
+		for _, r := range failure.Rows {
+			vals := make([]string, len(r))
+			for i, v := range r {
+				if v == nil {
+					vals[i] = "NULL"
+				} else {
+					vals[i] = fmt.Sprint(v)
+				}
+			}
+
+			lines = append(lines, "  ("+strings.Join(vals, ", ")+")")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// emptyCommitChecksTable stands in for the commit checks table before it has been created.  It has no rows, and the
+// table is created when rows are first inserted into it.
+type emptyCommitChecksTable struct {
+	db Database
+}
+
+var _ sql.InsertableTable = (*emptyCommitChecksTable)(nil)
+
+// Name implements sql.Table
+func (t *emptyCommitChecksTable) Name() string {
+	return doltdb.CommitChecksTableName
+}
+
+// String implements sql.Table
+func (t *emptyCommitChecksTable) String() string {
+	return doltdb.CommitChecksTableName
+}
+
+// Schema implements sql.Table
+func (t *emptyCommitChecksTable) Schema() sql.Schema {
+	sch, err := doltSchemaToSqlSchema(doltdb.CommitChecksTableName, CommitChecksSchema)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return sch
+}
+
+// Partitions implements sql.Table
+func (t *emptyCommitChecksTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return &doltTablePartitionIter{}, nil
+}
+
+// PartitionRows implements sql.Table
+func (t *emptyCommitChecksTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	return sql.RowsToRowIter(), nil
+}
+
+// Inserter implements sql.InsertableTable
+func (t *emptyCommitChecksTable) Inserter(*sql.Context) sql.RowInserter {
+	return &commitChecksInserter{db: t.db}
+}
+
+// commitChecksInserter creates the commit checks table before inserting the first row into it.
+type commitChecksInserter struct {
+	db Database
+	ed sql.RowInserter
+}
+
+// Insert implements sql.RowInserter
+func (ins *commitChecksInserter) Insert(ctx *sql.Context, r sql.Row) error {
+	if ins.ed == nil {
+		tbl, err := getOrCreateCommitChecksTable(ctx, ins.db)
+
+		if err != nil {
+			return err
+		}
+
+		ins.ed = tbl.Inserter(ctx)
+	}
+
+	return ins.ed.Insert(ctx, r)
+}
+
+// Close implements sql.RowInserter
+func (ins *commitChecksInserter) Close(ctx *sql.Context) error {
+	if ins.ed == nil {
+		return nil
+	}
+
+	return ins.ed.Close(ctx)
+}
+
+func getOrCreateCommitChecksTable(ctx *sql.Context, db Database) (*WritableDoltTable, error) {
+	// batched edits to other tables are lost if they aren't flushed before the root changes
+	err := db.Flush(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := db.GetRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, found, err := db.getTable(ctx, root, doltdb.CommitChecksTableName)
+
+	if err != nil {
+		return nil, err
+	} else if found {
+		return tbl.(*WritableDoltTable), nil
+	}
+
+	root, err = root.CreateEmptyTable(ctx, doltdb.CommitChecksTableName, CommitChecksSchema)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.SetRoot(ctx, root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, found, err = db.getTable(ctx, root, doltdb.CommitChecksTableName)
+
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, sql.ErrTableNotFound.New(doltdb.CommitChecksTableName)
+	}
+
+	return tbl.(*WritableDoltTable), nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sql/sqltestutil"
+)
+
+func TestRunCommitChecks(t *testing.T) {
+	dEnv := dtestutils.CreateTestEnv()
+	sqltestutil.CreateTestDatabase(dEnv, t)
+
+	ctx := context.Background()
+	root, _ := dEnv.WorkingRoot(ctx)
+	db := NewDatabase("dolt", dEnv.DoltDB, dEnv.RepoState, dEnv.RepoStateWriter())
+
+	failures, err := RunCommitChecks(ctx, db, root)
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+
+	root, err = ExecuteSql(dEnv, root, `insert into dolt_commit_checks values ('not_too_old', 'select id, age from people where age > 45');
+insert into dolt_commit_checks values ('has_people', 'select 1 from dual where (select count(*) from people) = 0');`)
+	require.NoError(t, err)
+
+	_, ok, err := root.GetTable(ctx, doltdb.CommitChecksTableName)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	checks, err := GetCommitChecks(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, []CommitCheck{
+		{"has_people", "select 1 from dual where (select count(*) from people) = 0"},
+		{"not_too_old", "select id, age from people where age > 45"},
+	}, checks)
+
+	failures, err = RunCommitChecks(ctx, db, root)
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, "not_too_old", failures[0].Check.Name)
+	assert.Equal(t, []sql.Row{{int64(sqltestutil.MoeId), int64(48)}}, failures[0].Rows)
+	assert.Equal(t, "commit check 'not_too_old' returned 1 rows:\n  (4, 48)", FormatCommitCheckFailures(failures))
+
+	root, err = ExecuteSql(dEnv, root, "insert into dolt_commit_checks values ('bad', 'select * from not_a_table');")
+	require.NoError(t, err)
+
+	_, err = RunCommitChecks(ctx, db, root)
+	assert.Error(t, err)
+}
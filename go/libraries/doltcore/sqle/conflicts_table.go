@@ -0,0 +1,516 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/row"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/rowconv"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const (
+	baseConflictVersion  = "base"
+	ourConflictVersion   = "our"
+	theirConflictVersion = "their"
+)
+
+var ErrConflictKeyModified = errors.New("the primary key of a conflict cannot be modified")
+
+func baseNamer(name string) string {
+	return baseConflictVersion + "_" + name
+}
+
+func ourNamer(name string) string {
+	return ourConflictVersion + "_" + name
+}
+
+func theirNamer(name string) string {
+	return theirConflictVersion + "_" + name
+}
+
+var _ sql.Table = (*ConflictsTable)(nil)
+var _ sql.UpdatableTable = (*ConflictsTable)(nil)
+var _ sql.DeletableTable = (*ConflictsTable)(nil)
+
+// ConflictsTable is a sql.Table implementation that implements a system table which shows the conflicts of a single
+// table. Each row holds the base, our, and their versions of a conflicting row. Deleting a row marks the conflict as
+// resolved, and updating the our_ columns writes the updated values to the working table.
+type ConflictsTable struct {
+	db      Database
+	tblName string
+	schemas doltdb.Conflict
+	joiner  *rowconv.Joiner
+	sqlSch  sql.Schema
+}
+
+// NewConflictsTable creates a ConflictsTable for the table with the given name. If the table does not exist, or does
+// not have conflicting rows, a sql.ErrTableNotFound error is returned.
+func NewConflictsTable(ctx *sql.Context, db Database, root *doltdb.RootValue, tblName string) (*ConflictsTable, error) {
+	conflictsTblName := doltdb.DoltConflictsTablePrefix + tblName
+	tbl, tblName, ok, err := root.GetTableInsensitive(ctx, tblName)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrTableNotFound.New(conflictsTblName)
+	}
+
+	schemas, _, err := tbl.GetConflicts(ctx)
+
+	if err == doltdb.ErrNoConflicts {
+		return nil, sql.ErrTableNotFound.New(conflictsTblName)
+	} else if err != nil {
+		return nil, err
+	}
+
+	base, sch, mergeSch, err := tbl.GetConflictSchemas(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := rowconv.NewJoiner(
+		[]rowconv.NamedSchema{
+			{Name: baseConflictVersion, Sch: base},
+			{Name: ourConflictVersion, Sch: sch},
+			{Name: theirConflictVersion, Sch: mergeSch},
+		},
+		map[string]rowconv.ColNamingFunc{
+			baseConflictVersion:  baseNamer,
+			ourConflictVersion:   ourNamer,
+			theirConflictVersion: theirNamer,
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sqlSch, err := doltSchemaToSqlSchema(doltdb.DoltConflictsTablePrefix+tblName, j.GetSchema())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConflictsTable{db, tblName, schemas, j, sqlSch}, nil
+}
+
+// Name is a sql.Table interface function which returns the name of the table
+func (ct *ConflictsTable) Name() string {
+	return doltdb.DoltConflictsTablePrefix + ct.tblName
+}
+
+// String is a sql.Table interface function which returns the name of the table
+func (ct *ConflictsTable) String() string {
+	return doltdb.DoltConflictsTablePrefix + ct.tblName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the conflicts table
+func (ct *ConflictsTable) Schema() sql.Schema {
+	return ct.sqlSch
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (ct *ConflictsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return &doltTablePartitionIter{}, nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (ct *ConflictsTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	tbl, err := ct.getTable(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, confData, err := tbl.GetConflicts(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	confItr, err := confData.Iterator(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &conflictsRowItr{ctx, ct.joiner, confItr}, nil
+}
+
+// getTable returns the table in conflict from the current working root
+func (ct *ConflictsTable) getTable(ctx *sql.Context) (*doltdb.Table, error) {
+	root, err := ct.db.GetRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, ok, err := root.GetTable(ctx, ct.tblName)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrTableNotFound.New(ct.tblName)
+	}
+
+	return tbl, nil
+}
+
+// Updater returns a RowUpdater for this table. The RowUpdater will have Update called once for each row to be
+// updated, followed by a call to Close() when all rows have been processed.
+func (ct *ConflictsTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return &conflictsWriter{ct: ct}
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (ct *ConflictsTable) Deleter(*sql.Context) sql.RowDeleter {
+	return &conflictsWriter{ct: ct}
+}
+
+// conflictsRowItr is a sql.RowIter implementation which iterates over each conflict as if it's a row in the table.
+type conflictsRowItr struct {
+	ctx     context.Context
+	joiner  *rowconv.Joiner
+	confItr types.MapIterator
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+func (itr *conflictsRowItr) Next() (sql.Row, error) {
+	key, value, err := itr.confItr.Next(itr.ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, io.EOF
+	}
+
+	conflict, err := doltdb.ConflictFromTuple(value.(types.Tuple))
+
+	if err != nil {
+		return nil, err
+	}
+
+	namedRows := make(map[string]row.Row)
+	versions := map[string]types.Value{
+		baseConflictVersion:  conflict.Base,
+		ourConflictVersion:   conflict.Value,
+		theirConflictVersion: conflict.MergeValue,
+	}
+
+	for name, val := range versions {
+		if types.IsNull(val) {
+			continue
+		}
+
+		namedRows[name], err = row.FromNoms(itr.joiner.SchemaForName(name), key.(types.Tuple), val.(types.Tuple))
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	joined, err := itr.joiner.Join(namedRows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return doltRowToSqlRow(joined, itr.joiner.GetSchema())
+}
+
+// Close closes the iterator.
+func (itr *conflictsRowItr) Close() error {
+	return nil
+}
+
+var _ sql.RowUpdater = (*conflictsWriter)(nil)
+var _ sql.RowDeleter = (*conflictsWriter)(nil)
+
+// conflictsWriter collects the resolved and updated conflicts of a single statement, and applies them to the working
+// root when it is closed.
+type conflictsWriter struct {
+	ct       *ConflictsTable
+	resolved []types.Value
+	updated  []conflictUpdate
+}
+
+type conflictUpdate struct {
+	key types.Tuple
+	our row.Row
+}
+
+// splitConflictRow splits a row of the conflicts table into its base, our and their rows, and returns them along with
+// the key of the conflict.
+func (cw *conflictsWriter) splitConflictRow(ctx *sql.Context, r sql.Row) (map[string]row.Row, types.Tuple, error) {
+	joined, err := SqlRowToDoltRow(cw.ct.db.ddb.Format(), r, cw.ct.joiner.GetSchema())
+
+	if err != nil {
+		return nil, types.EmptyTuple(cw.ct.db.ddb.Format()), err
+	}
+
+	namedRows, err := cw.ct.joiner.Split(joined)
+
+	if err != nil {
+		return nil, types.EmptyTuple(cw.ct.db.ddb.Format()), err
+	}
+
+	for _, name := range []string{ourConflictVersion, theirConflictVersion, baseConflictVersion} {
+		if r, ok := namedRows[name]; ok {
+			key, err := r.NomsMapKey(cw.ct.joiner.SchemaForName(name)).Value(ctx)
+
+			if err != nil {
+				return nil, types.EmptyTuple(cw.ct.db.ddb.Format()), err
+			}
+
+			return namedRows, key.(types.Tuple), nil
+		}
+	}
+
+	return nil, types.EmptyTuple(cw.ct.db.ddb.Format()), errors.New("conflict row has no values")
+}
+
+// Delete marks the conflict in the given row as resolved.
+func (cw *conflictsWriter) Delete(ctx *sql.Context, r sql.Row) error {
+	_, key, err := cw.splitConflictRow(ctx, r)
+
+	if err != nil {
+		return err
+	}
+
+	cw.resolved = append(cw.resolved, key)
+	return nil
+}
+
+// Update writes the values of the our_ columns of the new row to the working table. Setting all of the our_ columns
+// to NULL deletes the row from the working table.
+func (cw *conflictsWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	_, key, err := cw.splitConflictRow(ctx, old)
+
+	if err != nil {
+		return err
+	}
+
+	newJoined, err := SqlRowToDoltRow(cw.ct.db.ddb.Format(), new, cw.ct.joiner.GetSchema())
+
+	if err != nil {
+		return err
+	}
+
+	newRows, err := cw.ct.joiner.Split(newJoined)
+
+	if err != nil {
+		return err
+	}
+
+	our := newRows[ourConflictVersion]
+
+	if our != nil {
+		newKey, err := our.NomsMapKey(cw.ct.joiner.SchemaForName(ourConflictVersion)).Value(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if !newKey.Equals(key) {
+			return ErrConflictKeyModified
+		}
+	}
+
+	cw.updated = append(cw.updated, conflictUpdate{key, our})
+	return nil
+}
+
+// Close applies the updates to the working table, and removes the resolved conflicts.
+func (cw *conflictsWriter) Close(ctx *sql.Context) error {
+	if len(cw.updated) > 0 {
+		err := cw.updateWorkingTable(ctx)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	tbl, err := cw.ct.getTable(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	_, confData, err := tbl.GetConflicts(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	confEd := confData.Edit()
+	ourSch := cw.ct.joiner.SchemaForName(ourConflictVersion)
+	for _, upd := range cw.updated {
+		val, ok, err := confData.MaybeGet(ctx, upd.key)
+
+		if err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		conflict, err := doltdb.ConflictFromTuple(val.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		var ourVal types.Value
+		if upd.our != nil {
+			ourVal, err = upd.our.NomsMapValue(ourSch).Value(ctx)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		conflictTpl, err := doltdb.NewConflict(conflict.Base, ourVal, conflict.MergeValue).ToNomsList(tbl.ValueReadWriter())
+
+		if err != nil {
+			return err
+		}
+
+		confEd.Set(upd.key, conflictTpl)
+	}
+
+	for _, key := range cw.resolved {
+		confEd.Remove(key)
+	}
+
+	confData, err = confEd.Map(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	tbl, err = tbl.SetConflicts(ctx, cw.ct.schemas, confData)
+
+	if err != nil {
+		return err
+	}
+
+	root, err := cw.ct.db.GetRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	root, err = root.PutTable(ctx, cw.ct.tblName, tbl)
+
+	if err != nil {
+		return err
+	}
+
+	return cw.ct.db.SetRoot(ctx, root)
+}
+
+// updateWorkingTable writes the updated our rows to the working table, maintaining its indexes.
+func (cw *conflictsWriter) updateWorkingTable(ctx *sql.Context) error {
+	tbl, err := cw.ct.getTable(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	wt := &WritableDoltTable{DoltTable: DoltTable{name: cw.ct.tblName, table: tbl, sch: sch, db: cw.ct.db}}
+	te := newTableEditor(ctx, wt)
+	for _, upd := range cw.updated {
+		curr, ok, err := tbl.GetRow(ctx, upd.key, sch)
+
+		if err != nil {
+			return err
+		}
+
+		var currSqlRow sql.Row
+		if ok {
+			currSqlRow, err = doltRowToSqlRow(curr, sch)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if upd.our == nil {
+			if ok {
+				err = te.Delete(ctx, currSqlRow)
+			}
+		} else {
+			var newSqlRow sql.Row
+			newSqlRow, err = ourRowToSqlRow(upd.our, sch)
+
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				err = te.Update(ctx, currSqlRow, newSqlRow)
+			} else {
+				err = te.Insert(ctx, newSqlRow)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return te.flush(ctx)
+}
+
+// ourRowToSqlRow converts a row with the our schema of the conflicts into a sql row with the schema of the working
+// table. Values of columns which are not in the working table are dropped.
+func ourRowToSqlRow(r row.Row, sch schema.Schema) (sql.Row, error) {
+	taggedVals := make(row.TaggedValues)
+	_, err := r.IterCols(func(tag uint64, val types.Value) (stop bool, err error) {
+		if _, ok := sch.GetAllCols().GetByTag(tag); ok {
+			taggedVals[tag] = val
+		}
+
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	tblRow, err := row.New(r.Format(), sch, taggedVals)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return doltRowToSqlRow(tblRow, sch)
+}
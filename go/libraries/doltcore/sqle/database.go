@@ -51,6 +51,7 @@ const (
 const (
 	HeadKeySuffix    = "_head"
 	WorkingKeySuffix = "_working"
+	StagedKeySuffix  = "_staged"
 )
 
 func IsHeadKey(key string) (bool, string) {
@@ -69,6 +70,14 @@ func IsWorkingKey(key string) (bool, string) {
 	return false, ""
 }
 
+func IsStagedKey(key string) (bool, string) {
+	if strings.HasSuffix(key, StagedKeySuffix) {
+		return true, key[:len(key)-len(StagedKeySuffix)]
+	}
+
+	return false, ""
+}
+
 type tableCache struct {
 	mu     *sync.Mutex
 	tables map[*doltdb.RootValue]map[string]sql.Table
@@ -218,6 +227,27 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 		return dh, true, nil
 	}
 
+	if strings.HasPrefix(lwrName, doltdb.DoltConflictsTablePrefix) {
+		tblName = tblName[len(doltdb.DoltConflictsTablePrefix):]
+		ct, err := NewConflictsTable(ctx, db, root, tblName)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		return ct, true, nil
+	}
+
+	if lwrName == doltdb.ConflictsTableName {
+		ct, err := NewTableOfTablesInConflict(ctx, db)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		return ct, true, nil
+	}
+
 	if lwrName == doltdb.LogTableName {
 		lt, err := NewLogTable(ctx, db.Name())
 
@@ -238,7 +268,24 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 		return bt, true, nil
 	}
 
-	return db.getTable(ctx, root, tblName)
+	if lwrName == doltdb.StatusTableName {
+		st, err := NewStatusTable(ctx, db)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		return st, true, nil
+	}
+
+	tbl, ok, err := db.getTable(ctx, root, tblName)
+
+	// the commit checks table can be inserted into before it exists
+	if err == nil && !ok && lwrName == doltdb.CommitChecksTableName {
+		return &emptyCommitChecksTable{db}, true, nil
+	}
+
+	return tbl, ok, err
 }
 
 // GetTableInsensitiveAsOf implements sql.VersionedDatabase
@@ -435,6 +482,10 @@ func (db Database) WorkingKey() string {
 	return db.name + WorkingKeySuffix
 }
 
+func (db Database) StagedKey() string {
+	return db.name + StagedKeySuffix
+}
+
 var hashType = sql.MustCreateString(query.Type_TEXT, 32, sql.Collation_ascii_bin)
 
 func (db Database) GetRoot(ctx *sql.Context) (*doltdb.RootValue, error) {
@@ -0,0 +1,96 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"github.com/liquidata-inc/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+)
+
+const (
+	AddFuncName = "dolt_add"
+
+	// AddAllArg stages all tables
+	AddAllArg = "-a"
+)
+
+// AddFunc is the DOLT_ADD function. DOLT_ADD(table...) stages the tables given, and DOLT_ADD('.') or DOLT_ADD('-a')
+// stages all tables. Tables with unresolved conflicts are not staged, and the number of them is returned.
+type AddFunc struct {
+	vcFunc
+}
+
+// NewAddFunc creates a new AddFunc expression.
+func NewAddFunc(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) < 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(AddFuncName, "1 or more", len(children))
+	}
+
+	return &AddFunc{vcFunc{AddFuncName, children}}, nil
+}
+
+// Eval implements the Expression interface.
+func (af *AddFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := af.evalArgs(ctx, row)
+
+	if err != nil {
+		return nil, err
+	}
+
+	flags, tbls, err := af.parseArgs(args, AddAllArg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := getSessionRoots(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if flags[AddAllArg] || (len(tbls) == 1 && tbls[0] == ".") {
+		tbls, err = doltdb.UnionTableNames(ctx, sr.staged, sr.working)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	staged, working, err := actions.StageTablesInRoots(ctx, tbls, sr.staged, sr.working, false)
+
+	if err != nil {
+		if actions.IsTblInConflict(err) {
+			return int64(len(actions.GetTablesForError(err))), nil
+		}
+
+		return nil, err
+	}
+
+	err = sr.update(ctx, working, staged)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sr.numTablesInConflict(ctx)
+}
+
+// WithChildren implements the Expression interface.
+func (af *AddFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewAddFunc(children...)
+}
@@ -0,0 +1,50 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoltAdd(t *testing.T) {
+	vt := newVCTest(t)
+	head := vt.commitRoot(vt.head())
+
+	vt.exec("UPDATE test SET v = 10 WHERE pk = 0")
+	vt.exec("CREATE TABLE other (pk BIGINT NOT NULL, PRIMARY KEY (pk))")
+
+	rows := vt.exec("SELECT DOLT_ADD('test')")
+	assert.Equal(t, int64(0), rows[0][0])
+	working, staged := vt.roots()
+	assert.Equal(t, vt.tableHash(working, "test"), vt.tableHash(staged, "test"))
+	assert.NotEqual(t, vt.tableHash(head, "test"), vt.tableHash(staged, "test"))
+	assert.Equal(t, vt.tableHash(head, "other"), vt.tableHash(staged, "other"))
+
+	vt.exec("SELECT DOLT_ADD('.')")
+	working, staged = vt.roots()
+	assert.Equal(t, vt.tableHash(working, "other"), vt.tableHash(staged, "other"))
+
+	vt.exec("DROP TABLE other")
+	vt.exec("SELECT DOLT_ADD('-a')")
+	_, staged = vt.roots()
+	has, err := staged.HasTable(vt.ctx, "other")
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	_, err = vt.query("SELECT DOLT_ADD('missing')")
+	assert.Error(t, err)
+}
@@ -0,0 +1,178 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+)
+
+const (
+	BranchFuncName = "dolt_branch"
+
+	// DeleteBranchArg deletes the branches named, if they are merged into master
+	DeleteBranchArg = "-d"
+
+	// ForceBranchArg deletes branches that are not merged, or resets an existing branch to the start point given
+	ForceBranchArg = "-f"
+)
+
+var ErrCOBranchUpdate = errors.New("attempted to force update checked out branch")
+
+// BranchFunc is the DOLT_BRANCH function. DOLT_BRANCH(name[, start_point]) creates a branch at the start point, which
+// defaults to the session's head, and DOLT_BRANCH('-d', name...) deletes branches.
+type BranchFunc struct {
+	vcFunc
+}
+
+// NewBranchFunc creates a new BranchFunc expression.
+func NewBranchFunc(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) < 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(BranchFuncName, "1 or more", len(children))
+	}
+
+	return &BranchFunc{vcFunc{BranchFuncName, children}}, nil
+}
+
+// Eval implements the Expression interface.
+func (bf *BranchFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := bf.evalArgs(ctx, row)
+
+	if err != nil {
+		return nil, err
+	}
+
+	flags, names, err := bf.parseArgs(args, DeleteBranchArg, ForceBranchArg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := getSessionRoots(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if flags[DeleteBranchArg] {
+		if len(names) == 0 {
+			return nil, fmt.Errorf("%s requires the names of the branches to delete", DeleteBranchArg)
+		}
+
+		for _, name := range names {
+			err = deleteBranch(ctx, sr, name, flags[ForceBranchArg])
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return sr.numTablesInConflict(ctx)
+	}
+
+	if len(names) < 1 || len(names) > 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(BranchFuncName, "a branch name and an optional start point", len(names))
+	}
+
+	startPoint := "HEAD"
+	if len(names) == 2 {
+		startPoint = names[1]
+	}
+
+	err = createBranch(ctx, sr, names[0], startPoint, flags[ForceBranchArg])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sr.numTablesInConflict(ctx)
+}
+
+func deleteBranch(ctx *sql.Context, sr *sessionRoots, name string, force bool) error {
+	dref := ref.NewBranchRef(name)
+
+	if sr.isCheckedOut(dref) {
+		return actions.ErrCOBranchDelete
+	}
+
+	unlock, err := sr.lockWorktrees()
+
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	err = sr.checkBranchAvailable(dref)
+
+	if err != nil {
+		return err
+	}
+
+	return actions.DeleteBranchOnDB(ctx, sr.ddb, dref, force)
+}
+
+func createBranch(ctx *sql.Context, sr *sessionRoots, name, startPoint string, force bool) error {
+	if !doltdb.IsValidUserBranchName(name) {
+		return doltdb.ErrInvBranchName
+	}
+
+	brRef := ref.NewBranchRef(name)
+	hasRef, err := sr.ddb.HasRef(ctx, brRef)
+
+	if err != nil {
+		return err
+	} else if hasRef && !force {
+		return fmt.Errorf("branch '%s' %s", name, actions.ErrAlreadyExists.Error())
+	} else if hasRef {
+		// an existing branch is reset to the start point, which must not change a branch that is checked out
+		if sr.isCheckedOut(brRef) {
+			return ErrCOBranchUpdate
+		}
+
+		unlock, err := sr.lockWorktrees()
+
+		if err != nil {
+			return err
+		}
+
+		defer unlock()
+
+		err = sr.checkBranchAvailable(brRef)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	cm, err := sr.resolveCommit(ctx, startPoint)
+
+	if err != nil {
+		return err
+	}
+
+	return sr.ddb.NewBranchAtCommit(ctx, brRef, cm)
+}
+
+// WithChildren implements the Expression interface.
+func (bf *BranchFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewBranchFunc(children...)
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	dsqle "github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
+)
+
+func TestDoltBranch(t *testing.T) {
+	vt := newVCTest(t)
+	master := vt.branchHead("master")
+
+	vt.exec("SELECT DOLT_BRANCH('feature')")
+	assert.Equal(t, master, vt.branchHead("feature"))
+
+	_, err := vt.query("SELECT DOLT_BRANCH('feature')")
+	assert.EqualError(t, err, "branch 'feature' already exists")
+	_, err = vt.query("SELECT DOLT_BRANCH('-x', 'other')")
+	assert.EqualError(t, err, "unknown argument to DOLT_BRANCH: -x")
+	_, err = vt.query("SELECT DOLT_BRANCH('HEAD')")
+	assert.Equal(t, doltdb.ErrInvBranchName, err)
+	_, err = vt.query("SELECT DOLT_BRANCH('other', 'missing')")
+	assert.Error(t, err)
+	assert.False(t, vt.hasBranch("other"))
+
+	// a commit made in the session only moves the session's head, which is the default start point
+	vt.exec("INSERT INTO test VALUES (2, 2)")
+	vt.exec("SET @@dolt_head = COMMIT('added a row')")
+	committed := vt.head()
+	assert.NotEqual(t, master, committed)
+	assert.Equal(t, master, vt.branchHead("master"))
+
+	vt.exec("SELECT DOLT_BRANCH('other')")
+	assert.Equal(t, committed, vt.branchHead("other"))
+	vt.exec("SELECT DOLT_BRANCH('-f', 'feature', 'other')")
+	assert.Equal(t, committed, vt.branchHead("feature"))
+	vt.exec("SELECT DOLT_BRANCH('third', 'master')")
+	assert.Equal(t, master, vt.branchHead("third"))
+
+	// branches which aren't merged into master are only deleted with -f
+	_, err = vt.query("SELECT DOLT_BRANCH('-d', 'feature')")
+	assert.Equal(t, actions.ErrUnmergedBranchDelete, err)
+	vt.exec("SELECT DOLT_BRANCH('-d', '-f', 'feature', 'other')")
+	vt.exec("SELECT DOLT_BRANCH('-d', 'third')")
+	assert.False(t, vt.hasBranch("feature"))
+	assert.False(t, vt.hasBranch("other"))
+	assert.False(t, vt.hasBranch("third"))
+
+	_, err = vt.query("SELECT DOLT_BRANCH('-d', 'feature')")
+	assert.Equal(t, doltdb.ErrBranchNotFound, err)
+	_, err = vt.query("SELECT DOLT_BRANCH('-d')")
+	assert.Error(t, err)
+}
+
+func TestDoltBranchCheckedOut(t *testing.T) {
+	vt := newVCTest(t)
+	master := vt.branchHead("master")
+	vt.exec("SELECT DOLT_BRANCH('feature')")
+	vt.exec("SELECT DOLT_BRANCH('other')")
+
+	// the branch checked out in the repository can't be deleted or reset, even once the session has moved off of it
+	_, err := vt.query("SELECT DOLT_BRANCH('-d', '-f', 'master')")
+	assert.Equal(t, actions.ErrCOBranchDelete, err)
+	_, err = vt.query("SELECT DOLT_BRANCH('-f', 'master', 'feature')")
+	assert.Equal(t, ErrCOBranchUpdate, err)
+
+	vt.exec("SELECT DOLT_CHECKOUT('feature')")
+	_, err = vt.query("SELECT DOLT_BRANCH('-d', '-f', 'feature')")
+	assert.Equal(t, actions.ErrCOBranchDelete, err)
+	_, err = vt.query("SELECT DOLT_BRANCH('-f', 'feature', 'other')")
+	assert.Equal(t, ErrCOBranchUpdate, err)
+	_, err = vt.query("SELECT DOLT_BRANCH('-d', '-f', 'master')")
+	assert.Equal(t, actions.ErrCOBranchDelete, err)
+
+	assert.True(t, vt.hasBranch("feature"))
+	assert.Equal(t, master, vt.branchHead("master"))
+	vt.exec("SELECT DOLT_BRANCH('-d', 'other')")
+}
+
+// worktreeStateWriter is the RepoStateWriter of a repository which has another worktree with a branch checked out.
+type worktreeStateWriter struct {
+	env.RepoStateWriter
+	checkedOut ref.DoltRef
+	locked     bool
+}
+
+var _ env.BranchChecker = &worktreeStateWriter{}
+
+func (w *worktreeStateWriter) LockWorktrees() (func() error, error) {
+	w.locked = true
+	return func() error {
+		w.locked = false
+		return nil
+	}, nil
+}
+
+func (w *worktreeStateWriter) CheckBranchAvailable(dref ref.DoltRef) error {
+	if !w.locked {
+		return errors.New("the worktrees are not locked")
+	} else if ref.Equals(dref, w.checkedOut) {
+		return env.BranchCheckedOutError{Branch: dref.GetPath(), Dir: "/worktree"}
+	}
+
+	return nil
+}
+
+func TestDoltBranchCheckedOutInWorktree(t *testing.T) {
+	dEnv := newVCTestEnv(t)
+	rsw := &worktreeStateWriter{RepoStateWriter: dEnv.RepoStateWriter(), checkedOut: ref.NewBranchRef("feature")}
+	vt := newVCTestWithDB(t, dEnv, dsqle.NewDatabase(vcTestDB, dEnv.DoltDB, dEnv.RepoState, rsw))
+	vt.exec("SELECT DOLT_BRANCH('feature')")
+	vt.exec("SELECT DOLT_BRANCH('other')")
+
+	checkedOutErr := env.BranchCheckedOutError{Branch: "feature", Dir: "/worktree"}
+	_, err := vt.query("SELECT DOLT_BRANCH('-d', '-f', 'feature')")
+	assert.Equal(t, checkedOutErr, err)
+	_, err = vt.query("SELECT DOLT_BRANCH('-f', 'feature', 'HEAD')")
+	assert.Equal(t, checkedOutErr, err)
+	assert.True(t, vt.hasBranch("feature"))
+	assert.False(t, rsw.locked)
+
+	// branches which aren't checked out are checked with the worktrees locked
+	vt.exec("SELECT DOLT_BRANCH('-f', 'other', 'HEAD')")
+	vt.exec("SELECT DOLT_BRANCH('-d', 'other')")
+	assert.False(t, vt.hasBranch("other"))
+	assert.False(t, rsw.locked)
+}
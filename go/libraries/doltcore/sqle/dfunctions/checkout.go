@@ -0,0 +1,172 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+)
+
+const (
+	CheckoutFuncName = "dolt_checkout"
+
+	// CheckoutCoBranchArg creates a new branch and checks it out
+	CheckoutCoBranchArg = "-b"
+)
+
+// CheckoutFunc is the DOLT_CHECKOUT function. DOLT_CHECKOUT(branch) sets the session's head to the branch, carrying
+// over any working and staged changes, DOLT_CHECKOUT('-b', name[, start_point]) creates a branch and checks it out,
+// and DOLT_CHECKOUT(table...) discards the working changes to the tables given.
+type CheckoutFunc struct {
+	vcFunc
+}
+
+// NewCheckoutFunc creates a new CheckoutFunc expression.
+func NewCheckoutFunc(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) < 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(CheckoutFuncName, "1 or more", len(children))
+	}
+
+	return &CheckoutFunc{vcFunc{CheckoutFuncName, children}}, nil
+}
+
+// Eval implements the Expression interface.
+func (cf *CheckoutFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := cf.evalArgs(ctx, row)
+
+	if err != nil {
+		return nil, err
+	}
+
+	flags, names, err := cf.parseArgs(args, CheckoutCoBranchArg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := getSessionRoots(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if flags[CheckoutCoBranchArg] {
+		if len(names) < 1 || len(names) > 2 {
+			return nil, sql.ErrInvalidArgumentNumber.New(CheckoutFuncName, "a branch name and an optional start point", len(names))
+		}
+
+		startPoint := "HEAD"
+		if len(names) == 2 {
+			startPoint = names[1]
+		}
+
+		err = createBranch(ctx, sr, names[0], startPoint, false)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkoutBranch(ctx, sr, names[0])
+	} else if len(names) == 0 {
+		return nil, fmt.Errorf("%s requires a branch or the tables to check out", strings.ToUpper(CheckoutFuncName))
+	} else {
+		err = checkoutBranchOrTables(ctx, sr, names)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sr.numTablesInConflict(ctx)
+}
+
+func checkoutBranchOrTables(ctx *sql.Context, sr *sessionRoots, names []string) error {
+	if len(names) == 1 {
+		isBr, err := sr.ddb.HasRef(ctx, ref.NewBranchRef(names[0]))
+
+		if err != nil {
+			return err
+		} else if isBr {
+			return checkoutBranch(ctx, sr, names[0])
+		}
+	}
+
+	return checkoutTables(ctx, sr, names)
+}
+
+func checkoutBranch(ctx *sql.Context, sr *sessionRoots, brName string) error {
+	cm, err := sr.resolveCommit(ctx, brName)
+
+	if err != nil {
+		return err
+	}
+
+	newHead, err := cm.GetRootValue()
+
+	if err != nil {
+		return err
+	}
+
+	working, staged, err := actions.RootsForBranchCheckout(ctx, sr.ddb.ValueReadWriter(), sr.head, newHead, sr.working, sr.staged)
+
+	if err != nil {
+		if actions.IsCheckoutWouldOverwrite(err) {
+			tbls := actions.CheckoutWouldOverwriteTables(err)
+			return fmt.Errorf("your local changes to the following tables would be overwritten by checkout: %s", strings.Join(tbls, ", "))
+		}
+
+		return err
+	}
+
+	err = sr.setHead(ctx, cm)
+
+	if err != nil {
+		return err
+	}
+
+	sr.dSess.SetCheckedOutBranch(sr.dbName, ref.NewBranchRef(brName))
+	return sr.update(ctx, working, staged)
+}
+
+func checkoutTables(ctx *sql.Context, sr *sessionRoots, tbls []string) error {
+	for _, tbl := range tbls {
+		if doltdb.HasDoltPrefix(tbl) {
+			return fmt.Errorf("cannot check out the system table '%s'", tbl)
+		}
+	}
+
+	working, err := actions.CheckoutTablesInRoots(ctx, tbls, sr.working, sr.staged, sr.head)
+
+	if err != nil {
+		if actions.IsTblNotExist(err) {
+			return fmt.Errorf("'%s' did not match any table(s) known to dolt", strings.Join(actions.GetTablesForError(err), "', '"))
+		}
+
+		return err
+	}
+
+	return sr.update(ctx, working, sr.staged)
+}
+
+// WithChildren implements the Expression interface.
+func (cf *CheckoutFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewCheckoutFunc(children...)
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+)
+
+func TestDoltCheckoutTables(t *testing.T) {
+	vt := newVCTest(t)
+	head := vt.commitRoot(vt.head())
+
+	vt.exec("UPDATE test SET v = 10 WHERE pk = 0")
+	vt.exec("SELECT DOLT_CHECKOUT('test')")
+	working, _ := vt.roots()
+	assert.Equal(t, vt.tableHash(head, "test"), vt.tableHash(working, "test"))
+	assert.Equal(t, []interface{}{int64(0)}, []interface{}(vt.exec("SELECT v FROM test WHERE pk = 0")[0]))
+
+	_, err := vt.query("SELECT DOLT_CHECKOUT('not_a_table')")
+	assert.EqualError(t, err, "'not_a_table' did not match any table(s) known to dolt")
+	_, err = vt.query("SELECT DOLT_CHECKOUT('dolt_docs')")
+	assert.EqualError(t, err, "cannot check out the system table 'dolt_docs'")
+	_, err = vt.query("SELECT DOLT_CHECKOUT('-x', 'test')")
+	assert.EqualError(t, err, "unknown argument to DOLT_CHECKOUT: -x")
+}
+
+func TestDoltCheckoutBranch(t *testing.T) {
+	vt := newVCTest(t)
+	vt.exec("SELECT DOLT_BRANCH('feature')")
+
+	// working and staged changes are carried over to the branch checked out
+	vt.exec("UPDATE test SET v = 10 WHERE pk = 0")
+	vt.exec("SELECT DOLT_ADD('test')")
+	vt.exec("INSERT INTO test VALUES (2, 2)")
+	working, staged := vt.roots()
+
+	vt.exec("SELECT DOLT_CHECKOUT('feature')")
+	assert.Equal(t, vt.branchHead("feature"), vt.head())
+	br, ok := vt.dSess().GetCheckedOutBranch(vcTestDB)
+	require.True(t, ok)
+	assert.Equal(t, ref.NewBranchRef("feature"), br)
+
+	newWorking, newStaged := vt.roots()
+	assert.Equal(t, vt.tableHash(working, "test"), vt.tableHash(newWorking, "test"))
+	assert.Equal(t, vt.tableHash(staged, "test"), vt.tableHash(newStaged, "test"))
+
+	vt.exec("SELECT DOLT_CHECKOUT('-b', 'feature2')")
+	assert.True(t, vt.hasBranch("feature2"))
+	assert.Equal(t, vt.branchHead("feature"), vt.branchHead("feature2"))
+	br, _ = vt.dSess().GetCheckedOutBranch(vcTestDB)
+	assert.Equal(t, ref.NewBranchRef("feature2"), br)
+
+	_, err := vt.query("SELECT DOLT_CHECKOUT('-b', 'feature')")
+	assert.EqualError(t, err, "branch 'feature' already exists")
+}
+
+func TestDoltCheckoutWouldOverwrite(t *testing.T) {
+	vt := newVCTest(t)
+	vt.exec("SELECT DOLT_BRANCH('feature')")
+
+	// the branch changed moves test away from master
+	vt.exec("UPDATE test SET v = 10 WHERE pk = 0")
+	vt.exec("SET @@dolt_head = COMMIT('changed test')")
+	vt.exec("SELECT DOLT_BRANCH('changed')")
+
+	vt.exec("SELECT DOLT_CHECKOUT('feature')")
+	vt.exec("UPDATE test SET v = 11 WHERE pk = 0")
+	_, err := vt.query("SELECT DOLT_CHECKOUT('changed')")
+	assert.EqualError(t, err, "your local changes to the following tables would be overwritten by checkout: test")
+	assert.Equal(t, vt.branchHead("feature"), vt.head())
+}
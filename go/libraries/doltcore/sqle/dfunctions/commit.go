@@ -17,28 +17,43 @@ package dfunctions
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
 
 	"github.com/liquidata-inc/go-mysql-server/sql"
-	"github.com/liquidata-inc/go-mysql-server/sql/expression"
 )
 
-const CommitFuncName = "commit"
+const (
+	CommitFuncName = "commit"
+
+	// NoVerifyArg is the optional second argument to COMMIT which skips the commit checks
+	NoVerifyArg = "--no-verify"
+)
 
 type CommitFunc struct {
-	expression.UnaryExpression
+	children []sql.Expression
 }
 
-// NewCommitFunc creates a new CommitFunc expression.
-func NewCommitFunc(e sql.Expression) sql.Expression {
-	return &CommitFunc{expression.UnaryExpression{Child: e}}
+// NewCommitFunc creates a new CommitFunc expression.  It takes the commit message, and optionally --no-verify.
+func NewCommitFunc(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) < 1 || len(children) > 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(CommitFuncName, "1 or 2", len(children))
+	}
+
+	return &CommitFunc{children}, nil
 }
 
 // Eval implements the Expression interface.
 func (cf *CommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
-	val, err := cf.Child.Eval(ctx, row)
+	noVerify, err := cf.noVerify(ctx, row)
+
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := cf.children[0].Eval(ctx, row)
 
 	if err != nil {
 		return nil, err
@@ -74,6 +89,22 @@ func (cf *CommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, sql.ErrDatabaseNotFound.New(dbName)
 	}
 
+	if !noVerify {
+		db, ok := dSess.GetDatabase(dbName)
+
+		if !ok {
+			return nil, sql.ErrDatabaseNotFound.New(dbName)
+		}
+
+		failures, err := sqle.RunCommitChecks(ctx, db, root)
+
+		if err != nil {
+			return nil, err
+		} else if len(failures) > 0 {
+			return nil, sqle.ErrCommitChecksFailed.New(sqle.FormatCommitCheckFailures(failures))
+		}
+	}
+
 	h, err := ddb.WriteRootValue(ctx, root)
 
 	if err != nil {
@@ -90,7 +121,12 @@ func (cf *CommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
-	cm, err := ddb.WriteCommitDanglingCommit(ctx, h, []*doltdb.Commit{parent}, meta)
+	parents := []*doltdb.Commit{parent}
+	if mergeCm := dSess.GetMergeCommit(dbName); mergeCm != nil {
+		parents = append(parents, mergeCm)
+	}
+
+	cm, err := ddb.WriteCommitDanglingCommit(ctx, h, parents, meta)
 
 	if err != nil {
 		return nil, err
@@ -105,23 +141,59 @@ func (cf *CommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return h.String(), nil
 }
 
+// noVerify returns true if the commit checks should be skipped.
+func (cf *CommitFunc) noVerify(ctx *sql.Context, row sql.Row) (bool, error) {
+	if len(cf.children) < 2 {
+		return false, nil
+	}
+
+	val, err := cf.children[1].Eval(ctx, row)
+
+	if err != nil {
+		return false, err
+	}
+
+	if str, ok := val.(string); !ok || !strings.EqualFold(str, NoVerifyArg) {
+		return false, fmt.Errorf("unknown argument to %s: %v", strings.ToUpper(CommitFuncName), val)
+	}
+
+	return true, nil
+}
+
 // String implements the Stringer interface.
 func (cf *CommitFunc) String() string {
-	return fmt.Sprintf("COMMIT(%s)", cf.Child.String())
+	args := make([]string, len(cf.children))
+	for i, child := range cf.children {
+		args[i] = child.String()
+	}
+
+	return fmt.Sprintf("COMMIT(%s)", strings.Join(args, ", "))
 }
 
 // IsNullable implements the Expression interface.
 func (cf *CommitFunc) IsNullable() bool {
-	return cf.Child.IsNullable()
+	return cf.children[0].IsNullable()
 }
 
-// WithChildren implements the Expression interface.
-func (cf *CommitFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
-	if len(children) != 1 {
-		return nil, sql.ErrInvalidChildrenNumber.New(cf, len(children), 1)
+// Resolved implements the Expression interface.
+func (cf *CommitFunc) Resolved() bool {
+	for _, child := range cf.children {
+		if !child.Resolved() {
+			return false
+		}
 	}
 
-	return NewCommitFunc(children[0]), nil
+	return true
+}
+
+// Children implements the Expression interface.
+func (cf *CommitFunc) Children() []sql.Expression {
+	return cf.children
+}
+
+// WithChildren implements the Expression interface.
+func (cf *CommitFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewCommitFunc(children...)
 }
 
 // Type implements the Expression interface.
@@ -22,5 +22,10 @@ import (
 func init() {
 	// TODO: fix function registration
 	function.Defaults = append(function.Defaults, sql.Function1{Name: HashOfFuncName, Fn: NewHashOf})
-	function.Defaults = append(function.Defaults, sql.Function1{Name: CommitFuncName, Fn: NewCommitFunc})
+	function.Defaults = append(function.Defaults, sql.FunctionN{Name: CommitFuncName, Fn: NewCommitFunc})
+	function.Defaults = append(function.Defaults, sql.FunctionN{Name: BranchFuncName, Fn: NewBranchFunc})
+	function.Defaults = append(function.Defaults, sql.FunctionN{Name: CheckoutFuncName, Fn: NewCheckoutFunc})
+	function.Defaults = append(function.Defaults, sql.FunctionN{Name: MergeFuncName, Fn: NewMergeFunc})
+	function.Defaults = append(function.Defaults, sql.FunctionN{Name: ResetFuncName, Fn: NewResetFunc})
+	function.Defaults = append(function.Defaults, sql.FunctionN{Name: AddFuncName, Fn: NewAddFunc})
 }
@@ -0,0 +1,176 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/merge"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+const MergeFuncName = "dolt_merge"
+
+var ErrUnresolvedConflicts = errors.New("merging is not possible because you have unmerged tables; resolve the conflicts and add the tables")
+var ErrMergeActive = errors.New("merging is not possible because you have not committed an active merge")
+
+// MergeFunc is the DOLT_MERGE function. DOLT_MERGE(commit) merges the commit given into the session's working root.
+// If the merge results in conflicts the merged tables are left unstaged, otherwise the merged root is staged. The
+// merged commit becomes the second parent of the next commit created with COMMIT.
+type MergeFunc struct {
+	vcFunc
+}
+
+// NewMergeFunc creates a new MergeFunc expression.
+func NewMergeFunc(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(MergeFuncName, 1, len(children))
+	}
+
+	return &MergeFunc{vcFunc{MergeFuncName, children}}, nil
+}
+
+// Eval implements the Expression interface.
+func (mf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := mf.evalArgs(ctx, row)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := getSessionRoots(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if has, err := sr.working.HasConflicts(ctx); err != nil {
+		return nil, err
+	} else if has {
+		return nil, ErrUnresolvedConflicts
+	} else if sr.dSess.GetMergeCommit(sr.dbName) != nil {
+		return nil, ErrMergeActive
+	}
+
+	mergeCm, err := sr.resolveCommit(ctx, args[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	h1, err := sr.headCm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	h2, err := mergeCm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if h1 == h2 {
+		return sr.numTablesInConflict(ctx)
+	}
+
+	canFF, err := sr.headCm.CanFastForwardTo(ctx, mergeCm)
+
+	if err == doltdb.ErrUpToDate || err == doltdb.ErrIsAhead {
+		return sr.numTablesInConflict(ctx)
+	} else if err != nil {
+		return nil, err
+	}
+
+	mergeRoot, err := mergeCm.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	tblNames, workingDiffs, err := env.MergeWouldStompChanges(ctx, sr.head, sr.working, mergeRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tblNames) != 0 {
+		return nil, fmt.Errorf("your local changes to the following tables would be overwritten by merge: %s", strings.Join(tblNames, ", "))
+	}
+
+	// the session's head is not moved by a fast-forward, the merged commit is recorded as the second parent of the
+	// next commit in the same way as for any other merge
+	mergedRoot := mergeRoot
+	if !canFF {
+		mergedRoot, _, err = merge.MergeCommits(ctx, sr.ddb, sr.headCm, mergeCm, nil)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	working, err := applyChanges(ctx, mergedRoot, workingDiffs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hasConflicts, err := mergedRoot.HasConflicts(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	staged := sr.staged
+	if !hasConflicts {
+		staged = mergedRoot
+	}
+
+	err = sr.update(ctx, working, staged)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sr.dSess.SetMergeCommit(sr.dbName, mergeCm)
+
+	return sr.numTablesInConflict(ctx)
+}
+
+// applyChanges sets the tables changed in the working root back to their working values in the root given
+func applyChanges(ctx context.Context, root *doltdb.RootValue, workingDiffs map[string]hash.Hash) (*doltdb.RootValue, error) {
+	var err error
+	for tblName, h := range workingDiffs {
+		root, err = root.SetTableHash(ctx, tblName, h)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return root, nil
+}
+
+// WithChildren implements the Expression interface.
+func (mf *MergeFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewMergeFunc(children...)
+}
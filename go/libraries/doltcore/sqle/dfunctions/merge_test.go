@@ -0,0 +1,109 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitBranch commits an update of the row with the primary key 0 on top of the session's head, creates the branch
+// given at the new commit, and moves the session back to the commit it started from.
+func (vt *vcTest) commitBranch(name string, v int) {
+	start := vt.head()
+	vt.exec(fmt.Sprintf("UPDATE test SET v = %d WHERE pk = 0", v))
+	vt.exec(fmt.Sprintf("SET @@dolt_head = COMMIT('updated test on %s')", name))
+	vt.exec(fmt.Sprintf("SELECT DOLT_BRANCH('%s')", name))
+	vt.exec(fmt.Sprintf("SET @@dolt_head = '%s'", start.String()))
+}
+
+func TestDoltMergeFastForward(t *testing.T) {
+	vt := newVCTest(t)
+	master := vt.head()
+	vt.commitBranch("feature", 10)
+
+	rows := vt.exec("SELECT DOLT_MERGE('feature')")
+	assert.Equal(t, int64(0), rows[0][0])
+
+	// the merged tables are staged, and the head is only moved by the next commit
+	feature := vt.commitRoot(vt.branchHead("feature"))
+	working, staged := vt.roots()
+	assert.Equal(t, vt.tableHash(feature, "test"), vt.tableHash(working, "test"))
+	assert.Equal(t, vt.tableHash(feature, "test"), vt.tableHash(staged, "test"))
+	assert.Equal(t, master, vt.head())
+	require.NotNil(t, vt.dSess().GetMergeCommit(vcTestDB))
+
+	_, err := vt.query("SELECT DOLT_MERGE('feature')")
+	assert.Equal(t, ErrMergeActive, err)
+
+	vt.exec("SET @@dolt_head = COMMIT('merged feature')")
+	cm, err := vt.dSess().GetParentCommit(vt.ctx, vcTestDB)
+	require.NoError(t, err)
+	numParents, err := cm.NumParents()
+	require.NoError(t, err)
+	assert.Equal(t, 2, numParents)
+	assert.Nil(t, vt.dSess().GetMergeCommit(vcTestDB))
+
+	// merging a commit which is already merged does nothing
+	rows = vt.exec("SELECT DOLT_MERGE('feature')")
+	assert.Equal(t, int64(0), rows[0][0])
+	assert.Nil(t, vt.dSess().GetMergeCommit(vcTestDB))
+}
+
+func TestDoltMergeConflicts(t *testing.T) {
+	vt := newVCTest(t)
+	vt.commitBranch("feature", 10)
+	vt.commitBranch("other", 20)
+	vt.exec("SET @@dolt_head = HASHOF('other')")
+	_, staged := vt.roots()
+
+	rows := vt.exec("SELECT DOLT_MERGE('feature')")
+	assert.Equal(t, int64(1), rows[0][0])
+
+	// tables with conflicts are left unstaged
+	working, newStaged := vt.roots()
+	has, err := working.HasConflicts(vt.ctx)
+	require.NoError(t, err)
+	assert.True(t, has)
+	assert.Equal(t, vt.tableHash(staged, "test"), vt.tableHash(newStaged, "test"))
+
+	_, err = vt.query("SELECT DOLT_MERGE('feature')")
+	assert.Equal(t, ErrUnresolvedConflicts, err)
+	rows = vt.exec("SELECT DOLT_ADD('test')")
+	assert.Equal(t, int64(1), rows[0][0])
+
+	vt.exec("SELECT DOLT_RESET('--hard')")
+	working, _ = vt.roots()
+	has, err = working.HasConflicts(vt.ctx)
+	require.NoError(t, err)
+	assert.False(t, has)
+	assert.Nil(t, vt.dSess().GetMergeCommit(vcTestDB))
+}
+
+func TestDoltMergeWouldOverwrite(t *testing.T) {
+	vt := newVCTest(t)
+	vt.commitBranch("feature", 10)
+
+	vt.exec("UPDATE test SET v = 11 WHERE pk = 0")
+	_, err := vt.query("SELECT DOLT_MERGE('feature')")
+	assert.EqualError(t, err, "your local changes to the following tables would be overwritten by merge: test")
+	assert.Nil(t, vt.dSess().GetMergeCommit(vcTestDB))
+
+	_, err = vt.query("SELECT DOLT_MERGE('missing')")
+	assert.Error(t, err)
+}
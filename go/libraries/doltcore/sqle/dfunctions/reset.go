@@ -0,0 +1,153 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+)
+
+const (
+	ResetFuncName = "dolt_reset"
+
+	// ResetHardArg resets the working and staged roots to the session's head, keeping untracked tables
+	ResetHardArg = "--hard"
+
+	// ResetSoftArg resets the staged tables given to their values in the session's head
+	ResetSoftArg = "--soft"
+)
+
+// ResetFunc is the DOLT_RESET function. DOLT_RESET(table...) unstages the tables given, or all tables if none are
+// given, and DOLT_RESET('--hard') discards all changes to tracked tables and abandons an active merge.
+type ResetFunc struct {
+	vcFunc
+}
+
+// NewResetFunc creates a new ResetFunc expression.
+func NewResetFunc(children ...sql.Expression) (sql.Expression, error) {
+	return &ResetFunc{vcFunc{ResetFuncName, children}}, nil
+}
+
+// Eval implements the Expression interface.
+func (rf *ResetFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := rf.evalArgs(ctx, row)
+
+	if err != nil {
+		return nil, err
+	}
+
+	flags, tbls, err := rf.parseArgs(args, ResetHardArg, ResetSoftArg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := getSessionRoots(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if flags[ResetHardArg] && flags[ResetSoftArg] {
+		return nil, fmt.Errorf("%s and %s are mutually exclusive options", ResetHardArg, ResetSoftArg)
+	} else if flags[ResetHardArg] {
+		if len(tbls) != 0 {
+			return nil, fmt.Errorf("%s does not support additional params", ResetHardArg)
+		}
+
+		err = resetHard(ctx, sr)
+	} else {
+		err = resetSoft(ctx, sr, tbls)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sr.numTablesInConflict(ctx)
+}
+
+func resetHard(ctx *sql.Context, sr *sessionRoots) error {
+	wTblNames, err := sr.working.GetTableNames(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	// tables which are not in head are untracked, and are carried over to the new working root
+	working := sr.head
+	for _, tblName := range wTblNames {
+		if has, err := sr.head.HasTable(ctx, tblName); err != nil {
+			return err
+		} else if has || tblName == doltdb.DocTableName {
+			continue
+		}
+
+		tbl, _, err := sr.working.GetTable(ctx, tblName)
+
+		if err != nil {
+			return err
+		}
+
+		working, err = working.PutTable(ctx, tblName, tbl)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err = sr.update(ctx, working, sr.head)
+
+	if err != nil {
+		return err
+	}
+
+	sr.dSess.SetMergeCommit(sr.dbName, nil)
+	return nil
+}
+
+func resetSoft(ctx *sql.Context, sr *sessionRoots, tbls []string) error {
+	if len(tbls) == 0 || (len(tbls) == 1 && tbls[0] == ".") {
+		var err error
+		tbls, err = doltdb.UnionTableNames(ctx, sr.staged, sr.head)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err := actions.ValidateTables(ctx, tbls, sr.staged, sr.head)
+
+	if err != nil {
+		return err
+	}
+
+	staged, err := sr.staged.UpdateTablesFromOther(ctx, tbls, sr.head)
+
+	if err != nil {
+		return err
+	}
+
+	return sr.update(ctx, sr.working, staged)
+}
+
+// WithChildren implements the Expression interface.
+func (rf *ResetFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewResetFunc(children...)
+}
@@ -0,0 +1,67 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoltResetSoft(t *testing.T) {
+	vt := newVCTest(t)
+	head := vt.commitRoot(vt.head())
+
+	vt.exec("UPDATE test SET v = 10 WHERE pk = 0")
+	vt.exec("CREATE TABLE other (pk BIGINT NOT NULL, PRIMARY KEY (pk))")
+	vt.exec("SELECT DOLT_ADD('.')")
+	working, _ := vt.roots()
+
+	vt.exec("SELECT DOLT_RESET('test')")
+	newWorking, staged := vt.roots()
+	assert.Equal(t, vt.tableHash(head, "test"), vt.tableHash(staged, "test"))
+	assert.Equal(t, vt.tableHash(working, "other"), vt.tableHash(staged, "other"))
+	assert.Equal(t, vt.tableHash(working, "test"), vt.tableHash(newWorking, "test"))
+
+	vt.exec("SELECT DOLT_RESET()")
+	newWorking, staged = vt.roots()
+	assert.Equal(t, vt.tableHash(head, "other"), vt.tableHash(staged, "other"))
+	assert.Equal(t, vt.tableHash(working, "other"), vt.tableHash(newWorking, "other"))
+
+	_, err := vt.query("SELECT DOLT_RESET('missing')")
+	assert.Error(t, err)
+}
+
+func TestDoltResetHard(t *testing.T) {
+	vt := newVCTest(t)
+	head := vt.commitRoot(vt.head())
+
+	vt.exec("UPDATE test SET v = 10 WHERE pk = 0")
+	vt.exec("SELECT DOLT_ADD('test')")
+	vt.exec("CREATE TABLE other (pk BIGINT NOT NULL, PRIMARY KEY (pk))")
+	working, _ := vt.roots()
+
+	_, err := vt.query("SELECT DOLT_RESET('--hard', '--soft')")
+	assert.EqualError(t, err, "--hard and --soft are mutually exclusive options")
+	_, err = vt.query("SELECT DOLT_RESET('--hard', 'test')")
+	assert.EqualError(t, err, "--hard does not support additional params")
+
+	// tracked tables are reset to the head, and untracked tables are kept
+	vt.exec("SELECT DOLT_RESET('--hard')")
+	newWorking, staged := vt.roots()
+	assert.Equal(t, vt.tableHash(head, "test"), vt.tableHash(newWorking, "test"))
+	assert.Equal(t, vt.tableHash(head, "test"), vt.tableHash(staged, "test"))
+	assert.Equal(t, vt.tableHash(working, "other"), vt.tableHash(newWorking, "other"))
+}
@@ -0,0 +1,274 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
+)
+
+// vcFunc holds the arguments of a version control function. Version control functions take string arguments in the
+// style of the dolt command of the same name, operate on the session's roots of the current database, and return the
+// number of tables left in conflict in the session's working root.
+type vcFunc struct {
+	name     string
+	children []sql.Expression
+}
+
+// evalArgs evaluates the arguments of the function, each of which must be a string.
+func (vf vcFunc) evalArgs(ctx *sql.Context, row sql.Row) ([]string, error) {
+	args := make([]string, len(vf.children))
+	for i, child := range vf.children {
+		val, err := child.Eval(ctx, row)
+
+		if err != nil {
+			return nil, err
+		}
+
+		str, ok := val.(string)
+
+		if !ok {
+			return nil, fmt.Errorf("invalid argument to %s: %v", strings.ToUpper(vf.name), val)
+		}
+
+		args[i] = str
+	}
+
+	return args, nil
+}
+
+// parseArgs splits the arguments of the function into the flags given, which must be in the list of supported flags,
+// and the remaining positional arguments.
+func (vf vcFunc) parseArgs(args []string, supported ...string) (map[string]bool, []string, error) {
+	flags := make(map[string]bool)
+	var positional []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+			continue
+		}
+
+		found := false
+		for _, flag := range supported {
+			if arg == flag {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, nil, fmt.Errorf("unknown argument to %s: %s", strings.ToUpper(vf.name), arg)
+		}
+
+		flags[arg] = true
+	}
+
+	return flags, positional, nil
+}
+
+// String implements the Stringer interface.
+func (vf vcFunc) String() string {
+	args := make([]string, len(vf.children))
+	for i, child := range vf.children {
+		args[i] = child.String()
+	}
+
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(vf.name), strings.Join(args, ", "))
+}
+
+// IsNullable implements the Expression interface.
+func (vf vcFunc) IsNullable() bool {
+	return false
+}
+
+// Resolved implements the Expression interface.
+func (vf vcFunc) Resolved() bool {
+	for _, child := range vf.children {
+		if !child.Resolved() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Children implements the Expression interface.
+func (vf vcFunc) Children() []sql.Expression {
+	return vf.children
+}
+
+// Type implements the Expression interface.
+func (vf vcFunc) Type() sql.Type {
+	return sql.Int64
+}
+
+// sessionRoots holds the head commit and the head, staged and working roots of the current database of a session
+type sessionRoots struct {
+	dbName  string
+	dSess   *sqle.DoltSession
+	db      sqle.Database
+	ddb     *doltdb.DoltDB
+	headCm  *doltdb.Commit
+	head    *doltdb.RootValue
+	staged  *doltdb.RootValue
+	working *doltdb.RootValue
+}
+
+func getSessionRoots(ctx *sql.Context) (*sessionRoots, error) {
+	dbName := ctx.GetCurrentDatabase()
+	dSess := sqle.DSessFromSess(ctx.Session)
+	db, ok := dSess.GetDatabase(dbName)
+
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	headCm, err := dSess.GetParentCommit(ctx, dbName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := headCm.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	staged, err := dSess.GetStagedRoot(ctx, dbName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	working, err := db.GetRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &sessionRoots{dbName, dSess, db, db.GetDoltDB(), headCm, head, staged, working}, nil
+}
+
+// isCheckedOut returns true if the branch is checked out by the session, or is the branch checked out in the
+// repository the session's database was loaded from.
+func (sr *sessionRoots) isCheckedOut(dref ref.DoltRef) bool {
+	if br, ok := sr.dSess.GetCheckedOutBranch(sr.dbName); ok && ref.Equals(br, dref) {
+		return true
+	}
+
+	return ref.Equals(sr.db.GetStateReader().CWBHeadRef(), dref)
+}
+
+// lockWorktrees takes the lock which serializes changes to the branches checked out in the worktrees of the repository
+// of the session's database, if the database belongs to one.  The returned function releases the lock.
+func (sr *sessionRoots) lockWorktrees() (func() error, error) {
+	if bc, ok := sr.db.GetStateWriter().(env.BranchChecker); ok {
+		return bc.LockWorktrees()
+	}
+
+	return func() error { return nil }, nil
+}
+
+// checkBranchAvailable returns an error if the branch is checked out in another worktree of the repository of the
+// session's database.  The lock taken by lockWorktrees must be held.
+func (sr *sessionRoots) checkBranchAvailable(dref ref.DoltRef) error {
+	if bc, ok := sr.db.GetStateWriter().(env.BranchChecker); ok {
+		return bc.CheckBranchAvailable(dref)
+	}
+
+	return nil
+}
+
+// resolveCommit resolves a commit spec, in which HEAD refers to the session's head commit
+func (sr *sessionRoots) resolveCommit(ctx *sql.Context, cSpecStr string) (*doltdb.Commit, error) {
+	h, err := sr.headCm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := doltdb.NewCommitSpec(cSpecStr, h.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sr.ddb.Resolve(ctx, cs)
+}
+
+// setHead sets the head of the session to the commit given, which also resets the working and staged roots to the
+// root of the commit.
+func (sr *sessionRoots) setHead(ctx *sql.Context, cm *doltdb.Commit) error {
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	err = sr.dSess.Set(ctx, sr.dbName+sqle.HeadKeySuffix, sql.Text, h.String())
+
+	if err != nil {
+		return err
+	}
+
+	sr.headCm = cm
+	sr.head, err = cm.GetRootValue()
+
+	if err != nil {
+		return err
+	}
+
+	sr.staged = sr.head
+	sr.working = sr.head
+	return nil
+}
+
+// update sets the working and staged roots of the session
+func (sr *sessionRoots) update(ctx *sql.Context, working, staged *doltdb.RootValue) error {
+	err := sr.db.SetRoot(ctx, working)
+
+	if err != nil {
+		return err
+	}
+
+	err = sr.dSess.SetStagedRoot(ctx, sr.dbName, staged)
+
+	if err != nil {
+		return err
+	}
+
+	sr.working = working
+	sr.staged = staged
+	return nil
+}
+
+// numTablesInConflict returns the number of tables in conflict in the session's working root
+func (sr *sessionRoots) numTablesInConflict(ctx *sql.Context) (int64, error) {
+	tbls, err := sr.working.TablesInConflict(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(tbls)), nil
+}
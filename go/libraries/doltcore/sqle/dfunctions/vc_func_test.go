@@ -0,0 +1,208 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	sqle "github.com/liquidata-inc/go-mysql-server"
+	"github.com/liquidata-inc/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env/actions"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/schema"
+	dsqle "github.com/liquidata-inc/dolt/go/libraries/doltcore/sqle"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+const vcTestDB = "dolt"
+
+var vcTestSch = dtestutils.CreateSchema(
+	schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+	schema.NewColumn("v", 1, types.IntKind, false),
+)
+
+// vcTest is a repository and a sql engine with a session on it.  The session keeps its state between queries.
+type vcTest struct {
+	t      *testing.T
+	dEnv   *env.DoltEnv
+	engine *sqle.Engine
+	ctx    *sql.Context
+}
+
+// newVCTest creates a repository whose master branch has a commit adding the table test, with the rows (0, 0) and
+// (1, 1), and starts a session on it.
+func newVCTest(t *testing.T) *vcTest {
+	dEnv := newVCTestEnv(t)
+	return newVCTestWithDB(t, dEnv, dsqle.NewDatabase(vcTestDB, dEnv.DoltDB, dEnv.RepoState, dEnv.RepoStateWriter()))
+}
+
+func newVCTestEnv(t *testing.T) *env.DoltEnv {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	dtestutils.CreateTestTable(t, dEnv, "test", vcTestSch,
+		dtestutils.NewRow(vcTestSch, types.Int(0), types.Int(0)),
+		dtestutils.NewRow(vcTestSch, types.Int(1), types.Int(1)))
+	require.NoError(t, actions.StageAllTables(ctx, dEnv, false))
+	require.NoError(t, actions.CommitStaged(ctx, dEnv, "added test", time.Now(), false, nil))
+
+	return dEnv
+}
+
+func newVCTestWithDB(t *testing.T, dEnv *env.DoltEnv, db dsqle.Database) *vcTest {
+	root, err := dEnv.WorkingRoot(context.Background())
+	require.NoError(t, err)
+
+	engine, ctx, err := dsqle.NewTestEngine(context.Background(), db, root)
+	require.NoError(t, err)
+
+	dSess := dsqle.DSessFromSess(ctx.Session)
+	dSess.Username = "Test User"
+	dSess.Email = "test@liquidata.co"
+
+	return &vcTest{t, dEnv, engine, ctx}
+}
+
+// query runs a query and returns its rows.
+func (vt *vcTest) query(query string) ([]sql.Row, error) {
+	_, itr, err := vt.engine.Query(vt.ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for {
+		r, err := itr.Next()
+
+		if err == io.EOF {
+			return rows, itr.Close()
+		} else if err != nil {
+			_ = itr.Close()
+			return nil, err
+		}
+
+		rows = append(rows, r)
+	}
+}
+
+// exec runs a query which must succeed, and returns its rows.
+func (vt *vcTest) exec(query string) []sql.Row {
+	rows, err := vt.query(query)
+	require.NoError(vt.t, err, query)
+
+	return rows
+}
+
+func (vt *vcTest) dSess() *dsqle.DoltSession {
+	return dsqle.DSessFromSess(vt.ctx.Session)
+}
+
+// head returns the hash of the session's head commit.
+func (vt *vcTest) head() hash.Hash {
+	cm, err := vt.dSess().GetParentCommit(vt.ctx, vcTestDB)
+	require.NoError(vt.t, err)
+	h, err := cm.HashOf()
+	require.NoError(vt.t, err)
+
+	return h
+}
+
+// branchHead returns the hash of the head commit of a branch.
+func (vt *vcTest) branchHead(name string) hash.Hash {
+	cs, err := doltdb.NewCommitSpec("HEAD", name)
+	require.NoError(vt.t, err)
+	cm, err := vt.dEnv.DoltDB.Resolve(vt.ctx, cs)
+	require.NoError(vt.t, err)
+	h, err := cm.HashOf()
+	require.NoError(vt.t, err)
+
+	return h
+}
+
+func (vt *vcTest) hasBranch(name string) bool {
+	has, err := vt.dEnv.DoltDB.HasRef(vt.ctx, ref.NewBranchRef(name))
+	require.NoError(vt.t, err)
+
+	return has
+}
+
+// commitRoot returns the root of the commit with the hash given.
+func (vt *vcTest) commitRoot(h hash.Hash) *doltdb.RootValue {
+	cs, err := doltdb.NewCommitSpec(h.String(), "")
+	require.NoError(vt.t, err)
+	cm, err := vt.dEnv.DoltDB.Resolve(vt.ctx, cs)
+	require.NoError(vt.t, err)
+	root, err := cm.GetRootValue()
+	require.NoError(vt.t, err)
+
+	return root
+}
+
+// roots returns the session's working and staged roots.
+func (vt *vcTest) roots() (*doltdb.RootValue, *doltdb.RootValue) {
+	working, ok := vt.dSess().GetRoot(vcTestDB)
+	require.True(vt.t, ok)
+	staged, err := vt.dSess().GetStagedRoot(vt.ctx, vcTestDB)
+	require.NoError(vt.t, err)
+
+	return working, staged
+}
+
+// tableHash returns the hash of a table in a root, or the empty hash if the root does not have the table.
+func (vt *vcTest) tableHash(root *doltdb.RootValue, tblName string) hash.Hash {
+	tbl, ok, err := root.GetTable(vt.ctx, tblName)
+	require.NoError(vt.t, err)
+
+	if !ok {
+		return hash.Hash{}
+	}
+
+	h, err := tbl.HashOf()
+	require.NoError(vt.t, err)
+
+	return h
+}
+
+func TestParseArgs(t *testing.T) {
+	vf := vcFunc{name: "dolt_test"}
+
+	flags, positional, err := vf.parseArgs([]string{"-a", "one", "-b", "two"}, "-a", "-b", "-c")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"-a": true, "-b": true}, flags)
+	assert.Equal(t, []string{"one", "two"}, positional)
+
+	_, _, err = vf.parseArgs([]string{"one", "-x"}, "-a")
+	assert.EqualError(t, err, "unknown argument to DOLT_TEST: -x")
+}
+
+func TestEvalArgs(t *testing.T) {
+	vt := newVCTest(t)
+
+	_, err := vt.query("SELECT DOLT_BRANCH(1)")
+	assert.EqualError(t, err, "invalid argument to DOLT_BRANCH: 1")
+
+	_, err = vt.query("SELECT DOLT_BRANCH()")
+	assert.Error(t, err)
+}
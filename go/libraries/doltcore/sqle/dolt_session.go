@@ -21,6 +21,7 @@ import (
 
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
 	"github.com/liquidata-inc/dolt/go/libraries/doltcore/env"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/ref"
 	"github.com/liquidata-inc/dolt/go/store/hash"
 )
 
@@ -31,6 +32,7 @@ type dbRoot struct {
 
 type dbData struct {
 	ddb *doltdb.DoltDB
+	rsr env.RepoStateReader
 	rsw env.RepoStateWriter
 }
 
@@ -39,8 +41,10 @@ var _ sql.Session = &DoltSession{}
 // DoltSession is the sql.Session implementation used by dolt.  It is accessible through a *sql.Context instance
 type DoltSession struct {
 	sql.Session
-	dbRoots map[string]dbRoot
-	dbDatas map[string]dbData
+	dbRoots      map[string]dbRoot
+	dbDatas      map[string]dbData
+	mergeCommits map[string]*doltdb.Commit
+	branches     map[string]ref.DoltRef
 
 	Username string
 	Email    string
@@ -48,7 +52,7 @@ type DoltSession struct {
 
 // DefaultDoltSession creates a DoltSession object with default values
 func DefaultDoltSession() *DoltSession {
-	sess := &DoltSession{sql.NewBaseSession(), make(map[string]dbRoot), make(map[string]dbData), "", ""}
+	sess := &DoltSession{sql.NewBaseSession(), make(map[string]dbRoot), make(map[string]dbData), make(map[string]*doltdb.Commit), make(map[string]ref.DoltRef), "", ""}
 	return sess
 }
 
@@ -57,10 +61,10 @@ func NewDoltSession(ctx context.Context, sqlSess sql.Session, username, email st
 	dbRoots := make(map[string]dbRoot)
 	dbDatas := make(map[string]dbData)
 	for _, db := range dbs {
-		dbDatas[db.Name()] = dbData{rsw: db.rsw, ddb: db.ddb}
+		dbDatas[db.Name()] = dbData{rsr: db.rsr, rsw: db.rsw, ddb: db.ddb}
 	}
 
-	sess := &DoltSession{sqlSess, dbRoots, dbDatas, username, email}
+	sess := &DoltSession{sqlSess, dbRoots, dbDatas, make(map[string]*doltdb.Commit), make(map[string]ref.DoltRef), username, email}
 	for _, db := range dbs {
 		err := sess.AddDB(ctx, db)
 
@@ -96,7 +100,17 @@ func (sess *DoltSession) CommitTransaction(ctx *sql.Context) error {
 		return err
 	}
 
-	return dbData.rsw.SetWorkingHash(ctx, h)
+	err = dbData.rsw.SetWorkingHash(ctx, h)
+	if err != nil {
+		return err
+	}
+
+	_, value := sess.Session.Get(currentDb + StagedKeySuffix)
+	if stagedHashStr, ok := value.(string); ok && hash.IsValid(stagedHashStr) && stagedHashStr != dbData.rsr.StagedHash().String() {
+		return dbData.rsw.SetStagedHash(ctx, hash.Parse(stagedHashStr))
+	}
+
+	return nil
 }
 
 // GetDoltDB returns the *DoltDB for a given database by name
@@ -110,6 +124,18 @@ func (sess *DoltSession) GetDoltDB(dbName string) (*doltdb.DoltDB, bool) {
 	return d.ddb, true
 }
 
+// GetDatabase returns a new Database for a given database by name, which reads and writes the same repository state as
+// the database added to the session.
+func (sess *DoltSession) GetDatabase(dbName string) (Database, bool) {
+	d, ok := sess.dbDatas[dbName]
+
+	if !ok {
+		return Database{}, false
+	}
+
+	return NewDatabase(dbName, d.ddb, d.rsr, d.rsw), true
+}
+
 // GetRoot returns the current *RootValue for a given database associated with the session
 func (sess *DoltSession) GetRoot(dbName string) (*doltdb.RootValue, bool) {
 	dbRoot, ok := sess.dbRoots[dbName]
@@ -151,6 +177,65 @@ func (sess *DoltSession) GetParentCommit(ctx context.Context, dbName string) (*d
 	return cm, nil
 }
 
+// GetStagedRoot returns the staged *RootValue for a given database associated with the session
+func (sess *DoltSession) GetStagedRoot(ctx context.Context, dbName string) (*doltdb.RootValue, error) {
+	dbd, dbFound := sess.dbDatas[dbName]
+
+	if !dbFound {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	_, value := sess.Session.Get(dbName + StagedKeySuffix)
+	valStr, isStr := value.(string)
+
+	if !isStr || !hash.IsValid(valStr) {
+		return nil, doltdb.ErrInvalidHash
+	}
+
+	return dbd.ddb.ReadRootValue(ctx, hash.Parse(valStr))
+}
+
+// SetStagedRoot writes the root given and sets it as the staged *RootValue for a given database associated with the
+// session
+func (sess *DoltSession) SetStagedRoot(ctx context.Context, dbName string, root *doltdb.RootValue) error {
+	dbd, dbFound := sess.dbDatas[dbName]
+
+	if !dbFound {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	h, err := dbd.ddb.WriteRootValue(ctx, root)
+
+	if err != nil {
+		return err
+	}
+
+	return sess.Session.Set(ctx, dbName+StagedKeySuffix, sql.Text, h.String())
+}
+
+// GetMergeCommit returns the commit being merged into the working root of a given database, or nil if no merge is
+// in progress. The commit is recorded as the second parent of the next commit.
+func (sess *DoltSession) GetMergeCommit(dbName string) *doltdb.Commit {
+	return sess.mergeCommits[dbName]
+}
+
+// SetMergeCommit records the commit being merged into the working root of a given database. Setting the head of the
+// database ends the merge.
+func (sess *DoltSession) SetMergeCommit(dbName string, cm *doltdb.Commit) {
+	sess.mergeCommits[dbName] = cm
+}
+
+// GetCheckedOutBranch returns the branch most recently checked out in a given database, if any
+func (sess *DoltSession) GetCheckedOutBranch(dbName string) (ref.DoltRef, bool) {
+	br, ok := sess.branches[dbName]
+	return br, ok
+}
+
+// SetCheckedOutBranch records the branch checked out in a given database, after the head has been set to it
+func (sess *DoltSession) SetCheckedOutBranch(dbName string, br ref.DoltRef) {
+	sess.branches[dbName] = br
+}
+
 func (sess *DoltSession) Set(ctx context.Context, key string, typ sql.Type, value interface{}) error {
 	if isHead, dbName := IsHeadKey(key); isHead {
 		dbd, dbFound := sess.dbDatas[dbName]
@@ -202,7 +287,14 @@ func (sess *DoltSession) Set(ctx context.Context, key string, typ sql.Type, valu
 			return err
 		}
 
+		err = sess.Session.Set(ctx, dbName+StagedKeySuffix, sql.Text, hashStr)
+
+		if err != nil {
+			return err
+		}
+
 		sess.dbRoots[dbName] = dbRoot{hashStr, root}
+		delete(sess.mergeCommits, dbName)
 		return nil
 	}
 
@@ -215,7 +307,7 @@ func (sess *DoltSession) AddDB(ctx context.Context, db Database) error {
 	rsw := db.GetStateWriter()
 	ddb := db.GetDoltDB()
 
-	sess.dbDatas[db.Name()] = dbData{rsw: rsw, ddb: ddb}
+	sess.dbDatas[db.Name()] = dbData{rsr: rsr, rsw: rsw, ddb: ddb}
 
 	cs := rsr.CWBHeadSpec()
 
@@ -231,5 +323,15 @@ func (sess *DoltSession) AddDB(ctx context.Context, db Database) error {
 		return err
 	}
 
-	return sess.Set(ctx, name+HeadKeySuffix, sql.Text, h.String())
+	err = sess.Set(ctx, name+HeadKeySuffix, sql.Text, h.String())
+
+	if err != nil {
+		return err
+	}
+
+	if stagedHash := rsr.StagedHash(); !stagedHash.IsEmpty() {
+		return sess.Session.Set(ctx, name+StagedKeySuffix, sql.Text, stagedHash.String())
+	}
+
+	return nil
 }
@@ -164,7 +164,13 @@ func SqlSchemaToDoltSchema(ctx context.Context, root *doltdb.RootValue, tableNam
 		return nil, err
 	}
 
-	return schema.SchemaFromCols(colColl), nil
+	sch := schema.SchemaFromCols(colColl)
+	err = schema.ValidateMergeStrategies(sch)
+	if err != nil {
+		return nil, err
+	}
+
+	return sch, nil
 }
 
 // doltColToSqlCol returns the SQL column corresponding to the dolt column given.
@@ -177,7 +183,7 @@ func doltColToSqlCol(tableName string, col schema.Column) (*sql.Column, error) {
 		Nullable:   col.IsNullable(),
 		Source:     tableName,
 		PrimaryKey: col.IsPartOfPK,
-		Comment:    fmt.Sprintf("tag:%d", col.Tag),
+		Comment:    sqlfmt.FmtColComment(col),
 	}, nil
 }
 
@@ -192,7 +198,18 @@ func SqlColToDoltCol(tag uint64, col *sql.Column) (schema.Column, error) {
 		return schema.Column{}, err
 	}
 
-	return schema.NewColumnWithTypeInfo(col.Name, tag, typeInfo, col.PrimaryKey, constraints...)
+	mergeStrategy, err := extractMergeStrategy(col)
+	if err != nil {
+		return schema.Column{}, err
+	}
+
+	doltCol, err := schema.NewColumnWithTypeInfo(col.Name, tag, typeInfo, col.PrimaryKey, constraints...)
+	if err != nil {
+		return schema.Column{}, err
+	}
+
+	doltCol.MergeStrategy = mergeStrategy
+	return doltCol, nil
 }
 
 // Extracts the optional comment tag from a column type defn, or InvalidTag if it can't be extracted
@@ -204,7 +221,13 @@ func extractTag(col *sql.Column) uint64 {
 	i := strings.Index(col.Comment, sqlfmt.TagCommentPrefix)
 	if i >= 0 {
 		startIdx := i + len(sqlfmt.TagCommentPrefix)
-		tag, err := strconv.ParseUint(col.Comment[startIdx:], 10, 64)
+		endIdx := strings.IndexAny(col.Comment[startIdx:], " \t\n")
+		if endIdx < 0 {
+			endIdx = len(col.Comment)
+		} else {
+			endIdx += startIdx
+		}
+		tag, err := strconv.ParseUint(col.Comment[startIdx:endIdx], 10, 64)
 		if err != nil {
 			return schema.InvalidTag
 		}
@@ -213,3 +236,24 @@ func extractTag(col *sql.Column) uint64 {
 
 	return schema.InvalidTag
 }
+
+// Extracts the optional merge strategy from the comment of a column type defn, such as 'merge:max'. The merge strategy
+// runs to the end of the comment, or to the comment tag if it follows the merge strategy.
+func extractMergeStrategy(col *sql.Column) (schema.MergeStrategy, error) {
+	i := strings.Index(col.Comment, sqlfmt.MergeStrategyCommentPrefix)
+	if i < 0 {
+		return schema.MergeStrategy{}, nil
+	}
+
+	str := col.Comment[i+len(sqlfmt.MergeStrategyCommentPrefix):]
+	if j := strings.Index(str, sqlfmt.TagCommentPrefix); j >= 0 {
+		str = str[:j]
+	}
+
+	ms, err := schema.ParseMergeStrategy(str)
+	if err != nil {
+		return schema.MergeStrategy{}, fmt.Errorf("invalid merge strategy for column '%s': %s", col.Name, err.Error())
+	}
+
+	return ms, nil
+}
@@ -635,6 +635,11 @@ func TestModifyAndChangeColumn(t *testing.T) {
 			query:       "alter table people modify rating varchar(10)",
 			expectedErr: "column types cannot be changed",
 		},
+		{
+			name:        "alter modify column with narrowing type change",
+			query:       "alter table people modify age int",
+			expectedErr: "column types cannot be changed",
+		},
 		{
 			name:        "alter modify column not null, existing null values",
 			query:       "alter table people modify num_episodes bigint unsigned not null",
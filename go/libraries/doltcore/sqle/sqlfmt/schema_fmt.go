@@ -23,6 +23,10 @@ import (
 
 const TagCommentPrefix = "tag:"
 
+// MergeStrategyCommentPrefix is the prefix of the merge strategy of a column in its comment, such as
+// "merge:sum-of-deltas" or "merge:latest-by-column updated_at"
+const MergeStrategyCommentPrefix = "merge:"
+
 // FmtCol converts a column to a string with a given indent space count, name width, and type width.  If nameWidth or
 // typeWidth are 0 or less than the length of the name or type, then the length of the name or type will be used
 func FmtCol(indent, nameWidth, typeWidth int, col schema.Column) string {
@@ -47,7 +51,7 @@ func FmtColWithNameAndType(indent, nameWidth, typeWidth int, colName, typeStr st
 		}
 	}
 
-	return colStr + fmt.Sprintf(" COMMENT 'tag:%d'", col.Tag)
+	return colStr + fmt.Sprintf(" COMMENT '%s'", FmtColComment(col))
 }
 
 // FmtColPrimaryKey creates a string representing a primary key constraint within a sql create table statement with a
@@ -61,6 +65,18 @@ func FmtColTagComment(tag uint64) string {
 	return fmt.Sprintf("%s%d", TagCommentPrefix, tag)
 }
 
+// FmtColComment returns the comment of a column in a sql create table statement, which holds its tag and its merge
+// strategy if it has one.
+func FmtColComment(col schema.Column) string {
+	comment := FmtColTagComment(col.Tag)
+
+	if col.MergeStrategy.Type != schema.NoMergeStrategy {
+		comment += " " + MergeStrategyCommentPrefix + col.MergeStrategy.String()
+	}
+
+	return comment
+}
+
 // SchemaAsCreateStmt takes a Schema and returns a string representing a SQL create table command that could be used to
 // create this table
 func SchemaAsCreateStmt(tableName string, sch schema.Schema) string {
@@ -743,6 +743,20 @@ var BasicSelectTests = []SelectTest{
 			&sql.Column{Name: "latest_commit_message", Type: sql.Text},
 		},
 	},
+	{
+		Name:  "select * from status system table",
+		Query: "select * from dolt_status",
+		ExpectedRows: []sql.Row{
+			{"appearances", false, "new table"},
+			{"episodes", false, "new table"},
+			{"people", false, "new table"},
+		},
+		ExpectedSqlSchema: sql.Schema{
+			&sql.Column{Name: "table_name", Type: sql.Text},
+			&sql.Column{Name: "staged", Type: sql.Boolean},
+			&sql.Column{Name: "status", Type: sql.Text},
+		},
+	},
 }
 
 var sqlDiffSchema = sql.Schema{
@@ -0,0 +1,163 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"io"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/diff"
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+	"github.com/liquidata-inc/dolt/go/libraries/utils/set"
+)
+
+var _ sql.Table = (*StatusTable)(nil)
+
+const conflictStatus = "conflict"
+
+var tblDiffTypeToStatus = map[diff.TableDiffType]string{
+	diff.AddedTable:    "new table",
+	diff.ModifiedTable: "modified",
+	diff.RemovedTable:  "deleted",
+}
+
+// StatusTable is a sql.Table implementation that implements a system table which shows the tables which are changed
+// in the staged root relative to the head root, and in the working root relative to the staged root
+type StatusTable struct {
+	head    *doltdb.RootValue
+	staged  *doltdb.RootValue
+	working *doltdb.RootValue
+}
+
+// NewStatusTable creates a StatusTable for the head, staged and working roots of the database in the session
+func NewStatusTable(ctx *sql.Context, db Database) (*StatusTable, error) {
+	dSess := DSessFromSess(ctx.Session)
+	headCm, err := dSess.GetParentCommit(ctx, db.Name())
+
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := headCm.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	staged, err := dSess.GetStagedRoot(ctx, db.Name())
+
+	if err != nil {
+		return nil, err
+	}
+
+	working, err := db.GetRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &StatusTable{head, staged, working}, nil
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// StatusTableName
+func (st *StatusTable) Name() string {
+	return doltdb.StatusTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// StatusTableName
+func (st *StatusTable) String() string {
+	return doltdb.StatusTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the status system table.
+func (st *StatusTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: sql.Text, Source: doltdb.StatusTableName, PrimaryKey: true, Nullable: false},
+		{Name: "staged", Type: sql.Boolean, Source: doltdb.StatusTableName, PrimaryKey: true, Nullable: false},
+		{Name: "status", Type: sql.Text, Source: doltdb.StatusTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (st *StatusTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return &doltTablePartitionIter{}, nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (st *StatusTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	stagedDiffs, err := diff.NewTableDiffs(ctx, st.staged, st.head)
+
+	if err != nil {
+		return nil, err
+	}
+
+	notStagedDiffs, err := diff.NewTableDiffs(ctx, st.working, st.staged)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tblsInConflict, err := st.working.TablesInConflict(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for _, tblName := range stagedDiffs.Tables {
+		if !doltdb.IsReadOnlySystemTable(tblName) {
+			rows = append(rows, sql.NewRow(tblName, true, tblDiffTypeToStatus[stagedDiffs.TableToType[tblName]]))
+		}
+	}
+
+	for _, tblName := range tblsInConflict {
+		rows = append(rows, sql.NewRow(tblName, false, conflictStatus))
+	}
+
+	// tables in conflict are listed once, as conflicts
+	inCnfSet := set.NewStrSet(tblsInConflict)
+	for _, tblName := range notStagedDiffs.Tables {
+		if !doltdb.IsReadOnlySystemTable(tblName) && !inCnfSet.Contains(tblName) {
+			rows = append(rows, sql.NewRow(tblName, false, tblDiffTypeToStatus[notStagedDiffs.TableToType[tblName]]))
+		}
+	}
+
+	return &statusItr{rows}, nil
+}
+
+// statusItr is a sql.RowIter implementation which iterates over the changed tables
+type statusItr struct {
+	rows []sql.Row
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+func (itr *statusItr) Next() (sql.Row, error) {
+	if len(itr.rows) == 0 {
+		return nil, io.EOF
+	}
+
+	r := itr.rows[0]
+	itr.rows = itr.rows[1:]
+
+	return r, nil
+}
+
+// Close closes the iterator.
+func (itr *statusItr) Close() error {
+	return nil
+}
@@ -15,6 +15,7 @@
 package sqle
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -371,6 +372,18 @@ func (t *AlterableDoltTable) AddColumn(ctx *sql.Context, column *sql.Column, ord
 		return err
 	}
 
+	if col.MergeStrategy.Type != schema.NoMergeStrategy {
+		updatedTable, err = setMergeStrategy(ctx, updatedTable, col.Tag, col.MergeStrategy)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = validateMergeStrategies(ctx, updatedTable)
+	if err != nil {
+		return err
+	}
+
 	newRoot, err := root.PutTable(ctx, t.name, updatedTable)
 	if err != nil {
 		return err
@@ -379,6 +392,43 @@ func (t *AlterableDoltTable) AddColumn(ctx *sql.Context, column *sql.Column, ord
 	return t.db.SetRoot(ctx, newRoot)
 }
 
+// setMergeStrategy sets the merge strategy of the column with the tag given in the schema of the table given.
+func setMergeStrategy(ctx context.Context, tbl *doltdb.Table, tag uint64, ms schema.MergeStrategy) (*doltdb.Table, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var cols []schema.Column
+	_ = sch.GetAllCols().Iter(func(colTag uint64, col schema.Column) (stop bool, err error) {
+		if colTag == tag {
+			col.MergeStrategy = ms
+		}
+		cols = append(cols, col)
+		return false, nil
+	})
+
+	colColl, err := schema.NewColCollection(cols...)
+	if err != nil {
+		return nil, err
+	}
+
+	newSch := schema.SchemaFromCols(colColl)
+	newSch.Indexes().AddIndex(sch.Indexes().AllIndexes()...)
+	return tbl.UpdateSchema(ctx, newSch)
+}
+
+// validateMergeStrategies returns an error if the merge strategies of the columns of the table given can't be used,
+// such as after dropping or renaming a column compared by latest-by-column.
+func validateMergeStrategies(ctx context.Context, tbl *doltdb.Table) error {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+
+	return schema.ValidateMergeStrategies(sch)
+}
+
 func orderToOrder(order *sql.ColumnOrder) *alterschema.ColumnOrder {
 	if order == nil {
 		return nil
@@ -427,6 +477,11 @@ func (t *AlterableDoltTable) DropColumn(ctx *sql.Context, columnName string) err
 		return err
 	}
 
+	err = validateMergeStrategies(ctx, updatedTable)
+	if err != nil {
+		return err
+	}
+
 	newRoot, err := root.PutTable(ctx, t.name, updatedTable)
 	if err != nil {
 		return err
@@ -481,6 +536,11 @@ func (t *AlterableDoltTable) ModifyColumn(ctx *sql.Context, columnName string, c
 		return err
 	}
 
+	err = validateMergeStrategies(ctx, updatedTable)
+	if err != nil {
+		return err
+	}
+
 	newRoot, err := root.PutTable(ctx, t.name, updatedTable)
 	if err != nil {
 		return err
@@ -0,0 +1,129 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"io"
+
+	"github.com/liquidata-inc/go-mysql-server/sql"
+
+	"github.com/liquidata-inc/dolt/go/libraries/doltcore/doltdb"
+)
+
+var _ sql.Table = (*TableOfTablesInConflict)(nil)
+
+// TableOfTablesInConflict is a sql.Table implementation that implements a system table which shows the tables with
+// conflicts, and the number of conflicting rows in each
+type TableOfTablesInConflict struct {
+	root *doltdb.RootValue
+}
+
+// NewTableOfTablesInConflict creates a TableOfTablesInConflict for the working root of the database
+func NewTableOfTablesInConflict(ctx *sql.Context, db Database) (*TableOfTablesInConflict, error) {
+	root, err := db.GetRoot(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TableOfTablesInConflict{root}, nil
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ConflictsTableName
+func (dt *TableOfTablesInConflict) Name() string {
+	return doltdb.ConflictsTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ConflictsTableName
+func (dt *TableOfTablesInConflict) String() string {
+	return doltdb.ConflictsTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the conflicts system table.
+func (dt *TableOfTablesInConflict) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table", Type: sql.Text, Source: doltdb.ConflictsTableName, PrimaryKey: true, Nullable: false},
+		{Name: "num_conflicts", Type: sql.Uint64, Source: doltdb.ConflictsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "schema_conflict", Type: sql.Boolean, Source: doltdb.ConflictsTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (dt *TableOfTablesInConflict) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return &doltTablePartitionIter{}, nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (dt *TableOfTablesInConflict) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	tblNames, err := dt.root.TablesInConflict(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for _, tblName := range tblNames {
+		tbl, _, err := dt.root.GetTable(ctx, tblName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		num, err := tbl.NumRowsInConflict(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		hasSchCnf, err := tbl.HasSchemaConflict()
+
+		if err != nil {
+			return nil, err
+		}
+
+		// resolved conflicts are only cleared when the table is staged
+		if num == 0 && !hasSchCnf {
+			continue
+		}
+
+		rows = append(rows, sql.NewRow(tblName, num, hasSchCnf))
+	}
+
+	return &tablesInConflictItr{rows}, nil
+}
+
+// tablesInConflictItr is a sql.RowIter implementation which iterates over the tables in conflict
+type tablesInConflictItr struct {
+	rows []sql.Row
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+func (itr *tablesInConflictItr) Next() (sql.Row, error) {
+	if len(itr.rows) == 0 {
+		return nil, io.EOF
+	}
+
+	r := itr.rows[0]
+	itr.rows = itr.rows[1:]
+
+	return r, nil
+}
+
+// Close closes the iterator.
+func (itr *tablesInConflictItr) Close() error {
+	return nil
+}
@@ -50,6 +50,9 @@ type WritableFS interface {
 	// it will be overwritten.
 	OpenForWrite(fp string, perm os.FileMode) (io.WriteCloser, error)
 
+	// OpenForAppend opens a file for writing at the end of the file.  The file will be created if it does not exist.
+	OpenForAppend(fp string, perm os.FileMode) (io.WriteCloser, error)
+
 	// WriteFile writes the entire data buffer to a given file.  The file will be created if it does not exist,
 	// and if it does exist it will be overwritten.
 	WriteFile(fp string, data []byte) error
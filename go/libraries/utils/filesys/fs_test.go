@@ -86,6 +86,20 @@ func TestFilesystems(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, dataRead, data)
 
+			// Test appending to the file
+			wr, err := fs.OpenForAppend(fp, os.ModePerm)
+			require.NoError(t, err)
+			_, err = wr.Write([]byte(testString))
+			require.NoError(t, err)
+			err = wr.Close()
+			require.NoError(t, err)
+
+			// Test that the appended data follows the original data
+			dataRead, err = fs.ReadFile(fp)
+			require.NoError(t, err)
+			require.Equal(t, dataRead, append(data, testString...))
+			data = dataRead
+
 			// Test moving the file
 			err = fs.MoveFile(fp, movedFilePath)
 			require.NoError(t, err)
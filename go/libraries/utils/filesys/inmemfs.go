@@ -320,6 +320,34 @@ func (fs *InMemFS) OpenForWrite(fp string, perm os.FileMode) (io.WriteCloser, er
 	return &inMemFSWriteCloser{fp, parentDir, fs, bytes.NewBuffer(make([]byte, 0, 512)), fs.rwLock}, nil
 }
 
+// OpenForAppend opens a file for writing at the end of the file.  The file will be created if it does not exist.
+func (fs *InMemFS) OpenForAppend(fp string, perm os.FileMode) (io.WriteCloser, error) {
+	fs.rwLock.Lock()
+	defer fs.rwLock.Unlock()
+
+	fp = fs.getAbsPath(fp)
+
+	exists, isDir := fs.exists(fp)
+
+	if isDir {
+		return nil, ErrIsDir
+	}
+
+	dir := filepath.Dir(fp)
+	parentDir, err := fs.mkDirs(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, 512))
+	if exists {
+		buf.Write(fs.objs[fp].(*memFile).data)
+	}
+
+	return &inMemFSWriteCloser{fp, parentDir, fs, buf, fs.rwLock}, nil
+}
+
 // WriteFile writes the entire data buffer to a given file.  The file will be created if it does not exist,
 // and if it does exist it will be overwritten.
 func (fs *InMemFS) WriteFile(fp string, data []byte) error {
@@ -32,12 +32,16 @@ import (
 )
 
 const (
-	ParentsField = "parents"
-	ValueField   = "value"
-	MetaField    = "meta"
-	commitName   = "Commit"
+	ParentsField   = "parents"
+	ValueField     = "value"
+	MetaField      = "meta"
+	SignatureField = "signature"
+	commitName     = "Commit"
 )
 
+// CommitSigner signs the hash of an unsigned commit, returning the value to store in its signature field.
+type CommitSigner func(unsignedHash hash.Hash) (types.Value, error)
+
 var commitTemplate = types.MakeStructTemplate(commitName, []string{MetaField, ParentsField, ValueField})
 
 var valueCommitType = nomdl.MustParseType(`Struct Commit {
@@ -62,6 +66,40 @@ func NewCommit(value types.Value, parents types.Set, meta types.Struct) (types.S
 	return commitTemplate.NewStruct(meta.Format(), []types.Value{meta, parents, value})
 }
 
+// signCommit adds the signature returned by signer for the hash of commit to commit.  commit is returned unchanged if
+// signer is nil.
+func signCommit(commit types.Struct, signer CommitSigner) (types.Struct, error) {
+	if signer == nil {
+		return commit, nil
+	}
+
+	h, err := commit.Hash(commit.Format())
+
+	if err != nil {
+		return types.Struct{}, err
+	}
+
+	sig, err := signer(h)
+
+	if err != nil {
+		return types.Struct{}, err
+	}
+
+	return commit.Set(SignatureField, sig)
+}
+
+// UnsignedCommitHash returns the hash of commit without its signature, which is the hash that was given to the
+// CommitSigner that signed it.
+func UnsignedCommitHash(commit types.Struct) (hash.Hash, error) {
+	unsigned, err := commit.Delete(SignatureField)
+
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	return unsigned.Hash(commit.Format())
+}
+
 // FindCommonAncestor returns the most recent common ancestor of c1 and c2, if
 // one exists, setting ok to true. If there is no common ancestor, ok is set
 // to false.
@@ -115,6 +153,11 @@ func parentsToQueue(ctx context.Context, refs types.RefSlice, q *types.RefByHeig
 			return err
 		}
 
+		// the parents of commits on the boundary of a shallow clone are missing, and are skipped
+		if v == nil {
+			continue
+		}
+
 		c := v.(types.Struct)
 		ps, ok, err := c.MaybeGet(ParentsField)
 
@@ -41,4 +41,8 @@ type CommitOptions struct {
 	// be attempted. Note that because Commit() retries in some cases, Policy
 	// might also be called multiple times with different values.
 	Policy merge.Policy
+
+	// Signer, if provided, is called with the hash of the commit being created, and the value it returns is stored in
+	// the signature field of the commit.
+	Signer CommitSigner
 }
@@ -29,6 +29,7 @@ import (
 
 	"github.com/liquidata-inc/dolt/go/store/chunks"
 	"github.com/liquidata-inc/dolt/go/store/d"
+	"github.com/liquidata-inc/dolt/go/store/hash"
 	"github.com/liquidata-inc/dolt/go/store/nomdl"
 	"github.com/liquidata-inc/dolt/go/store/types"
 )
@@ -210,6 +211,54 @@ func TestCommitWithoutMetaField(t *testing.T) {
 	assert.False(IsCommit(noMetaCommit))
 }
 
+func TestSignedCommit(t *testing.T) {
+	assert := assert.New(t)
+
+	storage := &chunks.TestStorage{}
+	db := NewDatabase(storage.NewView())
+	defer db.Close()
+
+	ds, err := db.GetDataset(context.Background(), "ds")
+	assert.NoError(err)
+
+	var signedHash hash.Hash
+	signer := func(h hash.Hash) (types.Value, error) {
+		signedHash = h
+		return types.String("signed " + h.String()), nil
+	}
+
+	ds, err = db.Commit(context.Background(), ds, types.Float(1), CommitOptions{Signer: signer})
+	assert.NoError(err)
+
+	signed := mustHead(ds)
+	assert.True(IsCommit(signed))
+
+	sig, ok, err := signed.MaybeGet(SignatureField)
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal(types.String("signed "+signedHash.String()), sig)
+
+	unsignedHash, err := UnsignedCommitHash(signed)
+	assert.NoError(err)
+	assert.Equal(signedHash, unsignedHash)
+
+	// the signed commit is a valid parent of an unsigned commit
+	ds, err = db.CommitValue(context.Background(), ds, types.Float(2))
+	assert.NoError(err)
+
+	unsigned := mustHead(ds)
+	assert.True(IsCommit(unsigned))
+	_, ok, err = unsigned.MaybeGet(SignatureField)
+	assert.NoError(err)
+	assert.False(ok)
+
+	h, err := unsigned.Hash(types.Format_7_18)
+	assert.NoError(err)
+	unsignedHash, err = UnsignedCommitHash(unsigned)
+	assert.NoError(err)
+	assert.Equal(h, unsignedHash)
+}
+
 // Convert list of Struct's to Set<Ref>
 func toRefSet(vrw types.ValueReadWriter, commits ...types.Struct) (types.Set, error) {
 	s, err := types.NewSet(context.Background(), vrw)
@@ -184,7 +184,9 @@ func (db *database) doSetHead(ctx context.Context, ds Dataset, newHeadRef types.
 		return err
 	}
 
-	commitRef, err := db.WriteValue(ctx, commit) // will be orphaned if the tryCommitChunks() below fails
+	// the commit was read from the database by validateRefAsCommit, so it is not written again.  Writing it would require
+	// its parents to be present, which they are not for the oldest commits of a shallow clone.
+	commitRef, err := types.NewRef(commit, db.Format())
 
 	if err != nil {
 		return err
@@ -220,7 +222,12 @@ func (db *database) doFastForward(ctx context.Context, ds Dataset, newHeadRef ty
 		return nil
 	}
 
-	if ok && newHeadRef.Height() <= currentHeadRef.Height() {
+	if !ok {
+		// fast forwarding a dataset without a head is the same as setting its head
+		return db.doSetHead(ctx, ds, newHeadRef)
+	}
+
+	if newHeadRef.Height() <= currentHeadRef.Height() {
 		return ErrMergeNeeded
 	}
 
@@ -263,6 +270,12 @@ func (db *database) CommitDangling(ctx context.Context, v types.Value, opts Comm
 		return types.Struct{}, err
 	}
 
+	commitStruct, err = signCommit(commitStruct, opts.Signer)
+
+	if err != nil {
+		return types.Struct{}, err
+	}
+
 	_, err = db.WriteValue(ctx, commitStruct)
 
 	if err != nil {
@@ -552,7 +565,14 @@ func buildNewCommit(ctx context.Context, ds Dataset, v types.Value, opts CommitO
 	if meta.IsZeroValue() {
 		meta = types.EmptyStruct(ds.Database().Format())
 	}
-	return NewCommit(v, parents, meta)
+
+	commit, err := NewCommit(v, parents, meta)
+
+	if err != nil {
+		return types.EmptyStruct(ds.Database().Format()), err
+	}
+
+	return signCommit(commit, opts.Signer)
 }
 
 func (db *database) doHeadUpdate(ctx context.Context, ds Dataset, updateFunc func(ds Dataset) error) (Dataset, error) {
@@ -0,0 +1,209 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/liquidata-inc/dolt/go/store/chunks"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/nbs"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// ErrVerifyNotSupported is returned when the table files of a Database whose ChunkStore does not support verification
+// are verified.
+var ErrVerifyNotSupported = errors.New("the chunk store of this database does not support verification")
+
+// ChunkProblem describes a reachable chunk which is missing, or which can't be decoded.
+type ChunkProblem struct {
+	Hash hash.Hash
+
+	// Referrer is a chunk which references the chunk, or the empty hash if the chunk is one of the roots of the walk
+	Referrer hash.Hash
+
+	Err error
+}
+
+// ChunkReport describes the chunks checked by VerifyReachableChunks, and the problems found with them.
+type ChunkReport struct {
+	Reachable uint64
+
+	// Remote is the number of reachable chunks of a lazy clone which are not stored locally.  They are read from the
+	// remote when they are needed, and are not checked.
+	Remote uint64
+
+	Problems []ChunkProblem
+}
+
+// VerifyTableFiles checks the integrity of the table files of |db|.  For a lazy clone only the local table files are
+// checked.
+func VerifyTableFiles(ctx context.Context, db Database) (nbs.TableFileReport, error) {
+	cs := db.chunkStore()
+
+	if lazy, ok := cs.(*nbs.LazyStore); ok {
+		cs = lazy.Local()
+	}
+
+	v, ok := cs.(nbs.TableFileVerifier)
+
+	if !ok {
+		return nbs.TableFileReport{}, ErrVerifyNotSupported
+	}
+
+	return v.VerifyTableFiles(ctx)
+}
+
+// VerifyReachableChunks walks the chunk graph breadth first, starting at the root of |db| and at the values in
+// |extraRoots|, and checks that every chunk reached is present and can be decoded.  Chunks in |missingOK|, such as the
+// parents of the commits on the boundary of a shallow clone, are expected to be missing and are not reported.
+func VerifyReachableChunks(ctx context.Context, db Database, extraRoots, missingOK hash.HashSet) (ChunkReport, error) {
+	cs := db.chunkStore()
+	lazy, isLazy := cs.(*nbs.LazyStore)
+
+	if isLazy {
+		cs = lazy.Local()
+	}
+
+	root, err := cs.Root(ctx)
+
+	if err != nil {
+		return ChunkReport{}, err
+	}
+
+	// toVisit maps each chunk to be visited to a chunk which references it
+	toVisit := make(map[hash.Hash]hash.Hash)
+	if !root.IsEmpty() {
+		toVisit[root] = hash.Hash{}
+	}
+
+	for h := range extraRoots {
+		if !h.IsEmpty() {
+			toVisit[h] = hash.Hash{}
+		}
+	}
+
+	var report ChunkReport
+	visited := hash.NewHashSet()
+	for len(toVisit) > 0 {
+		hashes := hash.NewHashSet()
+		missing := hash.NewHashSet()
+		for h := range toVisit {
+			visited.Insert(h)
+			hashes.Insert(h)
+			missing.Insert(h)
+		}
+
+		next := make(map[hash.Hash]hash.Hash)
+		visit := func(c *chunks.Chunk) {
+			missing.Remove(c.Hash())
+			report.Reachable++
+
+			refs, err := decodeChunk(*c, db)
+
+			if err != nil {
+				report.Problems = append(report.Problems, ChunkProblem{c.Hash(), toVisit[c.Hash()], err})
+				return
+			}
+
+			for _, h := range refs {
+				if !visited.Has(h) {
+					next[h] = c.Hash()
+				}
+			}
+		}
+
+		found := make(chan *chunks.Chunk, 1024)
+		getErr := make(chan error, 1)
+
+		go func() {
+			defer close(found)
+			getErr <- cs.GetMany(ctx, hashes, found)
+		}()
+
+		for c := range found {
+			visit(c)
+		}
+
+		// a corrupt chunk fails the whole batch, so the chunks which weren't read are read one at a time to find the
+		// chunks which can't be read
+		if err := <-getErr; err != nil {
+			retry := make(hash.HashSlice, 0, len(missing))
+			for h := range missing {
+				retry = append(retry, h)
+			}
+
+			for _, h := range retry {
+				c, err := cs.Get(ctx, h)
+
+				if err != nil {
+					missing.Remove(h)
+					report.Reachable++
+					report.Problems = append(report.Problems, ChunkProblem{h, toVisit[h], err})
+				} else if !c.IsEmpty() {
+					visit(&c)
+				}
+			}
+		}
+
+		for h := range missing {
+			if missingOK.Has(h) {
+				continue
+			}
+
+			report.Reachable++
+
+			if isLazy {
+				report.Remote++
+				continue
+			}
+
+			report.Problems = append(report.Problems, ChunkProblem{h, toVisit[h], errors.New("chunk is missing")})
+		}
+
+		toVisit = next
+	}
+
+	return report, nil
+}
+
+// decodeChunk decodes the value in |c| and returns the hashes of the chunks it references.  Corrupt chunks can cause
+// the decoder to panic, so panics are recovered and returned as errors.
+func decodeChunk(c chunks.Chunk, vrw types.ValueReadWriter) (refs hash.HashSlice, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to decode chunk: %v", r)
+		}
+	}()
+
+	_, err = types.DecodeValue(c, vrw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = types.WalkRefs(c, vrw.Format(), func(r types.Ref) error {
+		refs = append(refs, r.TargetHash())
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/store/chunks"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestVerifyReachableChunks(t *testing.T) {
+	ctx := context.Background()
+	st := &chunks.TestStorage{}
+	cs := st.NewView()
+	db := NewDatabase(cs)
+	defer db.Close()
+
+	ds, err := db.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	l, err := types.NewList(ctx, db, types.String("a"), types.String("b"))
+	require.NoError(t, err)
+	_, err = db.CommitValue(ctx, ds, l)
+	require.NoError(t, err)
+
+	report, err := VerifyReachableChunks(ctx, db, nil, nil)
+	require.NoError(t, err)
+	assert.True(t, report.Reachable > 0)
+	assert.Zero(t, report.Remote)
+	assert.Empty(t, report.Problems)
+
+	garbage := chunks.NewChunk([]byte("not a noms value"))
+	err = cs.Put(ctx, garbage)
+	require.NoError(t, err)
+	missing := hash.Of([]byte("missing"))
+
+	report, err = VerifyReachableChunks(ctx, db, hash.NewHashSet(garbage.Hash(), missing), nil)
+	require.NoError(t, err)
+	require.Len(t, report.Problems, 2)
+
+	problems := make(map[hash.Hash]ChunkProblem)
+	for _, p := range report.Problems {
+		problems[p.Hash] = p
+	}
+
+	assert.Error(t, problems[garbage.Hash()].Err)
+	assert.Error(t, problems[missing].Err)
+	assert.Equal(t, hash.Hash{}, problems[missing].Referrer)
+
+	report, err = VerifyReachableChunks(ctx, db, hash.NewHashSet(missing), hash.NewHashSet(missing))
+	require.NoError(t, err)
+	assert.Empty(t, report.Problems)
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datas
+
+import (
+	"context"
+	"errors"
+
+	"github.com/liquidata-inc/dolt/go/store/chunks"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/nbs"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+// ErrGCNotSupported is returned when garbage collection is attempted on a Database whose ChunkStore does not support it.
+var ErrGCNotSupported = errors.New("the chunk store of this database does not support garbage collection")
+
+// GarbageCollect removes every chunk from the database which is not reachable from either the root of the database,
+// which references all of its datasets, or from one of the values in |extraRoots|.  Values which are written to the
+// database without being committed to a dataset must be included in |extraRoots| in order to be kept.  If |dryRun| is
+// true no chunks are removed, and the returned stats describe what would have been removed.
+func GarbageCollect(ctx context.Context, db Database, extraRoots hash.HashSet, dryRun bool) (nbs.GCStats, error) {
+	cs := db.chunkStore()
+	gc, ok := cs.(nbs.GarbageCollector)
+
+	if !ok {
+		return nbs.GCStats{}, ErrGCNotSupported
+	}
+
+	err := db.Rebase(ctx)
+
+	if err != nil {
+		return nbs.GCStats{}, err
+	}
+
+	root, lock, err := gc.RootAndManifestLock(ctx)
+
+	if err != nil {
+		return nbs.GCStats{}, err
+	}
+
+	roots := hash.NewHashSet(root)
+	for h := range extraRoots {
+		roots.Insert(h)
+	}
+
+	keepers, err := markReachableChunks(ctx, cs, db.Format(), roots)
+
+	if err != nil {
+		return nbs.GCStats{}, err
+	}
+
+	return gc.CollectGarbage(ctx, lock, keepers, dryRun)
+}
+
+// markReachableChunks walks the chunk graph breadth first starting at |roots| and returns the hashes of every chunk
+// which is reachable.  Roots which are not present in the ChunkStore are ignored.
+func markReachableChunks(ctx context.Context, cs chunks.ChunkStore, nbf *types.NomsBinFormat, roots hash.HashSet) (hash.HashSet, error) {
+	reachable := hash.NewHashSet()
+	toVisit := hash.NewHashSet()
+	for h := range roots {
+		if !h.IsEmpty() {
+			toVisit.Insert(h)
+		}
+	}
+
+	for len(toVisit) > 0 {
+		for h := range toVisit {
+			reachable.Insert(h)
+		}
+
+		found := make(chan *chunks.Chunk, 1024)
+		getErr := make(chan error, 1)
+
+		go func(hashes hash.HashSet) {
+			defer close(found)
+			getErr <- cs.GetMany(ctx, hashes, found)
+		}(toVisit)
+
+		next := hash.NewHashSet()
+
+		var err error
+		for c := range found {
+			if err != nil {
+				continue
+			}
+
+			err = types.WalkRefs(*c, nbf, func(r types.Ref) error {
+				if h := r.TargetHash(); !reachable.Has(h) {
+					next.Insert(h)
+				}
+
+				return nil
+			})
+		}
+
+		if gErr := <-getErr; err == nil {
+			err = gErr
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		toVisit = next
+	}
+
+	return reachable, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datas
+
+import (
+	"context"
+	"errors"
+
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/nbs"
+)
+
+// ErrNotLazy is returned when completing a lazy clone of a Database which is not lazy.
+var ErrNotLazy = errors.New("the database is not a lazy clone")
+
+// LazyClone sets the root of the empty |sinkDB| to the root of |srcDB| without pulling any chunks.  The sink must be
+// opened with NewLazyDatabase in order to read the chunks which are still in |srcDB|.
+func LazyClone(ctx context.Context, srcDB, sinkDB Database) error {
+	sinkTS, ok := sinkDB.chunkStore().(nbs.TableFileStore)
+
+	if !ok {
+		return errors.New("sink db is not a Table File Store")
+	}
+
+	sinkRoot, err := sinkDB.chunkStore().Root(ctx)
+
+	if err != nil {
+		return err
+	} else if !sinkRoot.IsEmpty() {
+		return errors.New("cannot lazily clone into a database which is not empty")
+	}
+
+	root, err := srcDB.chunkStore().Root(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return sinkTS.SetRootChunk(ctx, root, sinkRoot)
+}
+
+// NewLazyDatabase returns a Database which writes to |localDB|, and which reads chunks that are missing from |localDB|
+// from |remoteDB|.  Chunks read from |remoteDB| are persisted in |localDB|.
+func NewLazyDatabase(localDB, remoteDB Database) Database {
+	return NewDatabase(nbs.NewLazyStore(localDB.chunkStore(), remoteDB.chunkStore()))
+}
+
+// IsLazy returns whether |db| reads missing chunks from a remote database.
+func IsLazy(db Database) bool {
+	_, ok := db.chunkStore().(*nbs.LazyStore)
+	return ok
+}
+
+// LocalDatabase returns a Database which reads only the chunks of the lazy |db| which are stored locally, and never
+// reads from its remote database.  Values whose chunks have not been fetched read as missing.  If |db| is not lazy it is
+// returned as is.
+func LocalDatabase(db Database) Database {
+	cs, ok := db.chunkStore().(*nbs.LazyStore)
+
+	if !ok {
+		return db
+	}
+
+	return NewDatabase(cs.Local())
+}
+
+// PersistFetchedChunks writes the chunks which the lazy |db| has fetched from its remote database, and has yet to write,
+// to the local database.  It does nothing if |db| is not lazy.
+func PersistFetchedChunks(ctx context.Context, db Database) error {
+	cs, ok := db.chunkStore().(*nbs.LazyStore)
+
+	if !ok {
+		return nil
+	}
+
+	return cs.Persist(ctx, nil)
+}
+
+// CompleteLazyClone fetches every chunk which is reachable from the root of the lazy |db|, or from one of the values in
+// |extraRoots|, and is missing locally.  Once it returns the local database is complete, and no longer needs to be
+// opened lazily.
+func CompleteLazyClone(ctx context.Context, db Database, extraRoots hash.HashSet) error {
+	cs, ok := db.chunkStore().(*nbs.LazyStore)
+
+	if !ok {
+		return ErrNotLazy
+	}
+
+	root, err := cs.Root(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	roots := hash.NewHashSet(root)
+	for h := range extraRoots {
+		roots.Insert(h)
+	}
+
+	// every chunk is read through the lazy store, which persists the chunks it fetches locally in bounded batches
+	_, err = markReachableChunks(ctx, cs, db.Format(), roots)
+
+	return err
+}
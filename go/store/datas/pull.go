@@ -253,11 +253,17 @@ func pull(ctx context.Context, srcDB, sinkDB Database, sourceRef types.Ref, prog
 		return fmt.Errorf("cannot pull from src to sink; src version is %v and sink version is %v", srcDB.chunkStore().Version(), sinkDB.chunkStore().Version())
 	}
 
+	return pullChunks(ctx, srcDB, sinkDB, hash.HashSlice{sourceRef.TargetHash()}, nil, progressCh, batchSize)
+}
+
+// pullChunks copies the chunks in absent, and every chunk they reference which sinkDB doesn't already have, from srcDB to
+// sinkDB.  Chunks in skip are not copied, and neither are the chunks which are only reachable through them.
+func pullChunks(ctx context.Context, srcDB, sinkDB Database, absent hash.HashSlice, skip hash.HashSet, progressCh chan PullProgress, batchSize int) error {
 	var sampleSize, sampleCount uint64
 	updateProgress := makeProgTrack(progressCh)
 
 	// TODO: This batches based on limiting the _number_ of chunks processed at the same time. We really want to batch based on the _amount_ of chunk data being processed simultaneously. We also want to consider the chunks in a particular order, however, and the current GetMany() interface doesn't provide any ordering guarantees. Once BUG 3750 is fixed, we should be able to revisit this and do a better job.
-	absent := hash.HashSlice{sourceRef.TargetHash()}
+	var err error
 	for absentCount := len(absent); absentCount != 0; absentCount = len(absent) {
 		updateProgress(0, uint64(absentCount), 0)
 
@@ -278,7 +284,7 @@ func pull(ctx context.Context, srcDB, sinkDB Database, sourceRef types.Ref, prog
 				return err
 			}
 
-			uniqueOrdered, err = putChunks(ctx, sinkDB, batch, neededChunks, nextLevel, uniqueOrdered)
+			uniqueOrdered, err = putChunks(ctx, sinkDB, batch, neededChunks, skip, nextLevel, uniqueOrdered)
 
 			if err != nil {
 				return err
@@ -328,6 +334,138 @@ func PullWithoutBatching(ctx context.Context, srcDB, sinkDB Database, sourceRef
 	return pull(ctx, srcDB, sinkDB, sourceRef, progressCh, math.MaxInt32)
 }
 
+// PullShallow pulls the commits which are fewer than depth parent links away from one of the commits in heads, along
+// with the values they reference, from srcDB to sinkDB.  The parents of the oldest commits pulled are not pulled.  The
+// hashes of the pulled commits which have parents that were not pulled are returned.
+func PullShallow(ctx context.Context, srcDB, sinkDB Database, heads hash.HashSlice, depth int, progressCh chan PullProgress) (hash.HashSet, error) {
+	if depth < 1 {
+		return nil, errors.New("depth must be at least 1")
+	}
+
+	if srcDB.chunkStore().Version() != sinkDB.chunkStore().Version() {
+		return nil, fmt.Errorf("cannot pull from src to sink; src version is %v and sink version is %v", srcDB.chunkStore().Version(), sinkDB.chunkStore().Version())
+	}
+
+	included := hash.HashSet{}
+	parents := make(map[hash.Hash]hash.HashSlice)
+	level := heads
+	for i := 0; i < depth && len(level) > 0; i++ {
+		var nextLevel hash.HashSlice
+		for _, h := range level {
+			if included.Has(h) {
+				continue
+			}
+
+			v, err := srcDB.ReadValue(ctx, h)
+
+			if err != nil {
+				return nil, err
+			} else if v == nil {
+				return nil, fmt.Errorf("commit %s not found", h.String())
+			}
+
+			isCommit, err := IsCommit(v)
+
+			if err != nil {
+				return nil, err
+			} else if !isCommit {
+				return nil, fmt.Errorf("%s is not a commit", h.String())
+			}
+
+			included.Insert(h)
+
+			ps, ok, err := v.(types.Struct).MaybeGet(ParentsField)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				err = ps.(types.Set).IterAll(ctx, func(v types.Value) error {
+					parent := v.(types.Ref).TargetHash()
+					parents[h] = append(parents[h], parent)
+					nextLevel = append(nextLevel, parent)
+					return nil
+				})
+
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		level = nextLevel
+	}
+
+	boundary := hash.HashSet{}
+	excluded := hash.HashSet{}
+	for h, hParents := range parents {
+		for _, parent := range hParents {
+			if !included.Has(parent) {
+				boundary.Insert(h)
+				excluded.Insert(parent)
+			}
+		}
+	}
+
+	missing, err := sinkDB.chunkStore().HasMany(ctx, included)
+
+	if err != nil {
+		return nil, err
+	}
+
+	absent := make(hash.HashSlice, 0, len(missing))
+	for h := range missing {
+		absent = append(absent, h)
+	}
+
+	err = pullChunks(ctx, srcDB, sinkDB, absent, excluded, progressCh, defaultBatchSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return boundary, nil
+}
+
+// PullExcluding pulls the chunks reachable from the values in heads from srcDB to sinkDB, except for the chunks which
+// are also reachable from one of the values in exclude.  It is used to copy the changes made since the commits in
+// exclude, without the history they share with heads.
+func PullExcluding(ctx context.Context, srcDB, sinkDB Database, heads, exclude hash.HashSlice, progressCh chan PullProgress) error {
+	if srcDB.chunkStore().Version() != sinkDB.chunkStore().Version() {
+		return fmt.Errorf("cannot pull from src to sink; src version is %v and sink version is %v", srcDB.chunkStore().Version(), sinkDB.chunkStore().Version())
+	}
+
+	skip, err := markReachableChunks(ctx, srcDB.chunkStore(), srcDB.Format(), exclude.HashSet())
+
+	if err != nil {
+		return err
+	}
+
+	included := hash.HashSet{}
+	for _, h := range heads {
+		if !skip.Has(h) {
+			included.Insert(h)
+		}
+	}
+
+	missing, err := sinkDB.chunkStore().HasMany(ctx, included)
+
+	if err != nil {
+		return err
+	}
+
+	absent := make(hash.HashSlice, 0, len(missing))
+	for _, h := range heads {
+		if missing.Has(h) {
+			absent = append(absent, h)
+			missing.Remove(h)
+		}
+	}
+
+	return pullChunks(ctx, srcDB, sinkDB, absent, skip, progressCh, defaultBatchSize)
+}
+
 // concurrently pull all chunks from this batch that the sink is missing out of the source
 func getChunks(ctx context.Context, srcDB Database, batch hash.HashSlice, sampleSize uint64, sampleCount uint64, updateProgress func(moreDone uint64, moreKnown uint64, moreApproxBytesWritten uint64)) (map[hash.Hash]*chunks.Chunk, error) {
 	neededChunks := map[hash.Hash]*chunks.Chunk{}
@@ -364,7 +502,7 @@ func getChunks(ctx context.Context, srcDB Database, batch hash.HashSlice, sample
 
 // put the chunks that were downloaded into the sink IN ORDER and at the same time gather up an ordered, uniquified list
 // of all the children of the chunks and add them to the list of the next level tree chunks.
-func putChunks(ctx context.Context, sinkDB Database, hashes hash.HashSlice, neededChunks map[hash.Hash]*chunks.Chunk, nextLevel hash.HashSet, uniqueOrdered hash.HashSlice) (hash.HashSlice, error) {
+func putChunks(ctx context.Context, sinkDB Database, hashes hash.HashSlice, neededChunks map[hash.Hash]*chunks.Chunk, skip, nextLevel hash.HashSet, uniqueOrdered hash.HashSlice) (hash.HashSlice, error) {
 	for _, h := range hashes {
 		c := neededChunks[h]
 		err := sinkDB.chunkStore().Put(ctx, *c)
@@ -374,7 +512,7 @@ func putChunks(ctx context.Context, sinkDB Database, hashes hash.HashSlice, need
 		}
 
 		err = types.WalkRefs(*c, sinkDB.Format(), func(r types.Ref) error {
-			if !nextLevel.Has(r.TargetHash()) {
+			if !nextLevel.Has(r.TargetHash()) && !skip.Has(r.TargetHash()) {
 				uniqueOrdered = append(uniqueOrdered, r.TargetHash())
 				nextLevel.Insert(r.TargetHash())
 			}
@@ -24,6 +24,7 @@ package datas
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"reflect"
@@ -336,6 +337,112 @@ func (suite *PullSuite) TestPullUpdates() {
 	suite.True(srcL.Equals(mustGetValue(v.(types.Struct).MaybeGet(ValueField))))
 }
 
+// Source: C4 -> C3 -> C2 -> C1, each referencing a distinct value V1...V4
+//
+// Sink after a pull of depth 2 from C4: C4 -> C3
+func (suite *PullSuite) TestPullShallow() {
+	ctx := context.Background()
+	var commitRefs []types.Ref
+	var valueRefs []types.Ref
+	parents := mustSet(types.NewSet(ctx, suite.source))
+	for i := 1; i <= 4; i++ {
+		valRef := mustRef(suite.source.WriteValue(ctx, types.String(fmt.Sprintf("value %d", i))))
+		l, err := types.NewList(ctx, suite.source, valRef)
+		suite.NoError(err)
+		cmRef := suite.commitToSource(l, parents)
+		parents = mustSet(types.NewSet(ctx, suite.source, cmRef))
+		commitRefs = append(commitRefs, cmRef)
+		valueRefs = append(valueRefs, valRef)
+	}
+
+	pt := startProgressTracker()
+
+	boundary, err := PullShallow(ctx, suite.source, suite.sink, hash.HashSlice{commitRefs[3].TargetHash()}, 2, pt.Ch)
+	suite.NoError(err)
+	pt.Validate(suite)
+
+	suite.Equal(hash.NewHashSet(commitRefs[2].TargetHash()), boundary)
+
+	for i := 0; i < 4; i++ {
+		cm, err := suite.sink.ReadValue(ctx, commitRefs[i].TargetHash())
+		suite.NoError(err)
+		val, err := suite.sink.ReadValue(ctx, valueRefs[i].TargetHash())
+		suite.NoError(err)
+
+		if i >= 2 {
+			suite.NotNil(cm)
+			suite.NotNil(val)
+		} else {
+			suite.Nil(cm)
+			suite.Nil(val)
+		}
+	}
+
+	// pulling a depth which reaches the first commit leaves no boundary
+	boundary, err = PullShallow(ctx, suite.source, suite.sink, hash.HashSlice{commitRefs[2].TargetHash()}, 5, nil)
+	suite.NoError(err)
+	suite.Empty(boundary)
+
+	cm, err := suite.sink.ReadValue(ctx, commitRefs[0].TargetHash())
+	suite.NoError(err)
+	suite.NotNil(cm)
+}
+
+// Source: C4 -> C3 -> C2 -> C1, each referencing a distinct value V1...V4, and C3 also referencing V1
+//
+// Sink after a pull of C4 excluding C2: C4 -> C3, without V1
+func (suite *PullSuite) TestPullExcluding() {
+	ctx := context.Background()
+	var commitRefs []types.Ref
+	var valueRefs []types.Ref
+	parents := mustSet(types.NewSet(ctx, suite.source))
+	for i := 1; i <= 4; i++ {
+		valRef := mustRef(suite.source.WriteValue(ctx, types.String(fmt.Sprintf("value %d", i))))
+		vals := []types.Value{valRef}
+		if i == 3 {
+			vals = append(vals, valueRefs[0])
+		}
+
+		l, err := types.NewList(ctx, suite.source, vals...)
+		suite.NoError(err)
+		cmRef := suite.commitToSource(l, parents)
+		parents = mustSet(types.NewSet(ctx, suite.source, cmRef))
+		commitRefs = append(commitRefs, cmRef)
+		valueRefs = append(valueRefs, valRef)
+	}
+
+	pt := startProgressTracker()
+
+	err := PullExcluding(ctx, suite.source, suite.sink, hash.HashSlice{commitRefs[3].TargetHash()}, hash.HashSlice{commitRefs[1].TargetHash()}, pt.Ch)
+	suite.NoError(err)
+	pt.Validate(suite)
+
+	for i := 0; i < 4; i++ {
+		cm, err := suite.sink.ReadValue(ctx, commitRefs[i].TargetHash())
+		suite.NoError(err)
+		val, err := suite.sink.ReadValue(ctx, valueRefs[i].TargetHash())
+		suite.NoError(err)
+
+		if i >= 2 {
+			suite.NotNil(cm)
+			suite.NotNil(val)
+		} else {
+			suite.Nil(cm)
+			suite.Nil(val)
+		}
+	}
+
+	// excluding the head itself pulls nothing
+	suite.sinkCS, _ = makeTestStoreViews()
+	suite.sink = NewDatabase(suite.sinkCS)
+	err = PullExcluding(ctx, suite.source, suite.sink, hash.HashSlice{commitRefs[3].TargetHash()}, hash.HashSlice{commitRefs[3].TargetHash()}, nil)
+	suite.NoError(err)
+
+	cm, err := suite.sink.ReadValue(ctx, commitRefs[3].TargetHash())
+	suite.NoError(err)
+	suite.Nil(cm)
+}
+
 func (suite *PullSuite) commitToSource(v types.Value, p types.Set) types.Ref {
 	ds, err := suite.source.GetDataset(context.Background(), datasetID)
 	suite.NoError(err)
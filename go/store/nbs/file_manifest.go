@@ -49,6 +49,10 @@ const (
 // | nbs version:Noms version:Base32-encoded lock hash:Base32-encoded root hash:table 1 hash:table 1 cnt:...:table N hash:table N cnt|
 type fileManifest struct {
 	dir string
+
+	// lockHeld is set when the manifest file lock is held for the lifetime of the fileManifest, in which case it is
+	// not taken again when reading or updating the manifest.
+	lockHeld bool
 }
 
 func newLock(dir string) *fslock.Lock {
@@ -56,6 +60,45 @@ func newLock(dir string) *fslock.Lock {
 	return fslock.New(lockPath)
 }
 
+// lock takes the manifest file lock, unless it is already held by |fm|, and returns a func which releases it.
+func (fm fileManifest) lock() (unlock func() error, err error) {
+	if fm.lockHeld {
+		return func() error { return nil }, nil
+	}
+
+	lck := newLock(fm.dir)
+	err = lck.Lock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return lck.Unlock, nil
+}
+
+// lockForGC takes the manifest file lock and returns a manifest which reads and updates the manifest while the lock is
+// held, so that no other process is able to read or update the manifest until |unlock| is called.
+func (fm fileManifest) lockForGC() (locked manifest, unlock func() error, err error) {
+	exists, err := lockFileExists(fm.dir)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !exists {
+		// the store is uninitialized
+		return fm, func() error { return nil }, nil
+	}
+
+	unlock, err = fm.lock()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return fileManifest{dir: fm.dir, lockHeld: true}, unlock, nil
+}
+
 func lockFileExists(dir string) (bool, error) {
 	lockPath := filepath.Join(dir, lockFileName)
 	info, err := os.Stat(lockPath)
@@ -100,15 +143,14 @@ func (fm fileManifest) ParseIfExists(ctx context.Context, stats *Stats, readHook
 	if locked {
 		var f io.ReadCloser
 		err = func() (ferr error) {
-			lck := newLock(fm.dir)
-			ferr = lck.Lock()
+			unlock, ferr := fm.lock()
 
 			if ferr != nil {
 				return ferr
 			}
 
 			defer func() {
-				unlockErr := lck.Unlock()
+				unlockErr := unlock()
 
 				if ferr == nil {
 					ferr = unlockErr
@@ -251,15 +293,14 @@ func (fm fileManifest) Update(ctx context.Context, lastLock addr, newContents ma
 	defer os.Remove(tempManifestPath) // If we rename below, this will be a no-op
 
 	// Take manifest file lock
-	lck := newLock(fm.dir)
-	err = lck.Lock()
+	unlock, err := fm.lock()
 
 	if err != nil {
 		return manifestContents{}, err
 	}
 
 	defer func() {
-		unlockErr := lck.Unlock()
+		unlockErr := unlock()
 
 		if err == nil {
 			err = unlockErr
@@ -0,0 +1,169 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// TableFileProblem describes a table file, or a chunk within a table file, which failed verification.
+type TableFileProblem struct {
+	// FileID is the id of the table file
+	FileID string
+
+	// Chunk is the address of the chunk which failed verification, or the empty hash if the problem is with the table
+	// file as a whole
+	Chunk hash.Hash
+
+	Err error
+}
+
+// TableFileReport describes the table files checked by VerifyTableFiles, and the problems found in them.
+type TableFileReport struct {
+	TableFiles int
+	Chunks     uint64
+	Problems   []TableFileProblem
+}
+
+// TableFileVerifier is a ChunkStore which is able to check the integrity of its table files.
+type TableFileVerifier interface {
+	// VerifyTableFiles reads every table file in the manifest of the store.  It checks that the index of each file can
+	// be read and agrees with the manifest and with the name of the file, and that every chunk in the file passes its
+	// checksum, decompresses, and has the address the index gives it.  Problems found are returned in the report, and
+	// only failures to carry out the checks are returned as errors.
+	VerifyTableFiles(ctx context.Context) (TableFileReport, error)
+}
+
+var _ TableFileVerifier = &NomsBlockStore{}
+
+// VerifyTableFiles checks the integrity of the table files in the manifest of the store.
+func (nbs *NomsBlockStore) VerifyTableFiles(ctx context.Context) (TableFileReport, error) {
+	nbs.mu.RLock()
+	specs := make([]tableSpec, len(nbs.upstream.specs))
+	copy(specs, nbs.upstream.specs)
+	nbs.mu.RUnlock()
+
+	var report TableFileReport
+	for _, spec := range specs {
+		problems, err := nbs.verifyTableFile(ctx, spec)
+
+		if err != nil {
+			return TableFileReport{}, err
+		}
+
+		report.TableFiles++
+		report.Chunks += uint64(spec.chunkCount)
+		report.Problems = append(report.Problems, problems...)
+	}
+
+	return report, nil
+}
+
+func (nbs *NomsBlockStore) verifyTableFile(ctx context.Context, spec tableSpec) ([]TableFileProblem, error) {
+	fileID := spec.name.String()
+	fileProblem := func(err error) []TableFileProblem {
+		return []TableFileProblem{{FileID: fileID, Err: err}}
+	}
+
+	// failures to open the file are most likely due to a missing file or a corrupt index, and are reported as problems
+	cs, err := nbs.p.Open(ctx, spec.name, spec.chunkCount, nbs.stats)
+
+	if err != nil {
+		return fileProblem(err), nil
+	}
+
+	index, err := cs.index()
+
+	if err != nil {
+		return fileProblem(err), nil
+	}
+
+	if index.chunkCount != spec.chunkCount {
+		return fileProblem(fmt.Errorf("index has %d chunks, but the manifest records %d", index.chunkCount, spec.chunkCount)), nil
+	}
+
+	if index.chunkCount == 0 {
+		return nil, nil
+	}
+
+	if nameFromSuffixes(index.suffixes) != spec.name {
+		return fileProblem(errors.New("the name of the table file does not match the chunks in its index")), nil
+	}
+
+	rd, err := cs.reader(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []TableFileProblem
+	var buff []byte
+	for ordinal, a := range index.addrsByOrdinal() {
+		length := index.lengths[ordinal]
+
+		if uint32(cap(buff)) < length {
+			buff = make([]byte, length)
+		}
+
+		buff = buff[:length]
+		_, err = io.ReadFull(rd, buff)
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return append(problems, fileProblem(errors.New("table file is truncated"))...), nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if err := verifyChunkRecord(a, buff); err != nil {
+			problems = append(problems, TableFileProblem{FileID: fileID, Chunk: hash.Hash(a), Err: err})
+		}
+	}
+
+	return problems, nil
+}
+
+// verifyChunkRecord checks that |buff|, the compressed data of a chunk followed by its checksum, holds the chunk with
+// address |a|.
+func verifyChunkRecord(a addr, buff []byte) error {
+	if len(buff) < checksumSize {
+		return errors.New("chunk record is too short")
+	}
+
+	dataLen := len(buff) - checksumSize
+
+	if crc(buff[:dataLen]) != binary.BigEndian.Uint32(buff[dataLen:]) {
+		return errors.New("checksum error")
+	}
+
+	data, err := snappy.Decode(nil, buff[:dataLen])
+
+	if err != nil {
+		return err
+	}
+
+	if actual := computeAddr(data); actual != a {
+		return fmt.Errorf("chunk data hashes to %s", hash.Hash(actual).String())
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/store/chunks"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestVerifyTableFiles(t *testing.T) {
+	ctx := context.Background()
+	testDir := filepath.Join(os.TempDir(), uuid.New().String())
+	err := os.MkdirAll(testDir, os.ModePerm)
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	st, err := NewLocalStore(ctx, types.Format_Default.VersionString(), testDir, defaultMemTableSize)
+	require.NoError(t, err)
+
+	var last chunks.Chunk
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 8; j++ {
+			last = chunks.NewChunk([]byte(fmt.Sprintf("chunk %d:%d", i, j)))
+			err = st.Put(ctx, last)
+			require.NoError(t, err)
+		}
+
+		root, err := st.Root(ctx)
+		require.NoError(t, err)
+		ok, err := st.Commit(ctx, last.Hash(), root)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	report, err := st.VerifyTableFiles(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.TableFiles)
+	assert.Equal(t, uint64(16), report.Chunks)
+	assert.Empty(t, report.Problems)
+
+	// the data of the first chunk in a table file starts at the beginning of the file
+	fileID := st.upstream.specs[0].name.String()
+	f, err := os.OpenFile(filepath.Join(testDir, fileID), os.O_RDWR, 0)
+	require.NoError(t, err)
+	buff := make([]byte, 1)
+	_, err = f.ReadAt(buff, 0)
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte{buff[0] ^ 0xff}, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	report, err = st.VerifyTableFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, report.Problems, 1)
+	assert.Equal(t, fileID, report.Problems[0].FileID)
+	assert.NotEqual(t, hash.Hash{}, report.Problems[0].Chunk)
+	assert.Error(t, report.Problems[0].Err)
+}
@@ -0,0 +1,408 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// ErrUnpersistedChunks is returned when garbage collection is attempted on a store that has chunks which have not yet
+// been committed.
+var ErrUnpersistedChunks = errors.New("cannot collect garbage while there are chunks which have not been committed")
+
+// ErrManifestChangedDuringGC is returned when the manifest of a store is updated by another writer while garbage is being
+// collected, or after the chunks to keep were marked.  This includes updates which add table files without changing the
+// root.  No changes are made to the store when this happens, and collection can be retried.
+var ErrManifestChangedDuringGC = errors.New("the store was updated while garbage was being collected")
+
+// GCStats describes the contents of a store before and after garbage collection.  Byte counts are the sizes of the
+// table files, including their indexes.
+type GCStats struct {
+	TableFilesBefore int
+	ChunksBefore     uint64
+	BytesBefore      uint64
+	TableFilesAfter  int
+	ChunksAfter      uint64
+	BytesAfter       uint64
+}
+
+// ReclaimedBytes returns the number of bytes which are, or would be, freed by garbage collection.
+func (s GCStats) ReclaimedBytes() uint64 {
+	if s.BytesAfter > s.BytesBefore {
+		return 0
+	}
+
+	return s.BytesBefore - s.BytesAfter
+}
+
+// GarbageCollector is a ChunkStore which is able to remove chunks which are no longer needed.
+type GarbageCollector interface {
+	// RootAndManifestLock returns the root of the store along with the lock of the manifest it was read from.  The lock
+	// changes whenever the root or the table files of the store are updated.
+	RootAndManifestLock(ctx context.Context) (root hash.Hash, lock hash.Hash, err error)
+
+	// CollectGarbage rewrites the table files of the store so that they contain exactly the chunks in |keepers| which
+	// are present in the store, and removes the table files which are no longer referenced.  |markedLock| is the
+	// manifest lock returned by RootAndManifestLock along with the root that |keepers| were marked from, and
+	// ErrManifestChangedDuringGC is returned if the manifest has been updated since.  If |dryRun| is true the store is
+	// left unchanged and the returned stats describe what would have been done.
+	CollectGarbage(ctx context.Context, markedLock hash.Hash, keepers hash.HashSet, dryRun bool) (GCStats, error)
+}
+
+var _ GarbageCollector = &NomsBlockStore{}
+
+// tableFilePruner is implemented by tablePersisters that are able to remove table files which are no longer
+// referenced by the manifest.
+type tableFilePruner interface {
+	pruneTableFiles(ctx context.Context, gone []tableSpec) error
+}
+
+// gcLockingManifest is implemented by manifests which can be locked against reads and updates by other processes for
+// the duration of garbage collection.  The returned manifest reads and updates the manifest while the lock is held.
+type gcLockingManifest interface {
+	lockForGC() (locked manifest, unlock func() error, err error)
+}
+
+// lockForGC locks the manifest against other processes if it supports it, and returns a manifestManager which reads
+// and updates the manifest while it is locked.  |unlock| must be called once garbage collection is complete.
+func (mm manifestManager) lockForGC() (locked manifestManager, unlock func() error, err error) {
+	lm, ok := mm.m.(gcLockingManifest)
+
+	if !ok {
+		return mm, func() error { return nil }, nil
+	}
+
+	m, unlock, err := lm.lockForGC()
+
+	if err != nil {
+		return manifestManager{}, nil, err
+	}
+
+	return manifestManager{m, mm.cache, mm.locks}, unlock, nil
+}
+
+// RootAndManifestLock returns the root of the store along with the lock of the manifest it was read from.
+func (nbs *NomsBlockStore) RootAndManifestLock(ctx context.Context) (hash.Hash, hash.Hash, error) {
+	nbs.mu.RLock()
+	defer nbs.mu.RUnlock()
+	return nbs.upstream.root, hash.Hash(nbs.upstream.lock), nil
+}
+
+// CollectGarbage rewrites the table files of the store so that they contain exactly the chunks in |keepers| which are
+// present in the store. The new table files replace the old ones in a single manifest update, and the old table files
+// are then deleted if the tablePersister supports it.  Table files which were not part of the manifest that was
+// rewritten, such as those persisted by another writer which has yet to commit, are left alone.  The manifest is
+// locked against other processes from the time it is read until the old table files are deleted.
+func (nbs *NomsBlockStore) CollectGarbage(ctx context.Context, markedLock hash.Hash, keepers hash.HashSet, dryRun bool) (stats GCStats, err error) {
+	nbs.mm.LockForUpdate()
+	defer func() {
+		unlockErr := nbs.mm.UnlockForUpdate()
+
+		if err == nil {
+			err = unlockErr
+		}
+	}()
+
+	mm, unlockManifest, err := nbs.mm.lockForGC()
+
+	if err != nil {
+		return GCStats{}, err
+	}
+
+	defer func() {
+		unlockErr := unlockManifest()
+
+		if err == nil {
+			err = unlockErr
+		}
+	}()
+
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+
+	if nbs.mt != nil {
+		cnt, err := nbs.mt.count()
+
+		if err != nil {
+			return GCStats{}, err
+		}
+
+		if cnt > 0 {
+			return GCStats{}, ErrUnpersistedChunks
+		}
+	}
+
+	if nbs.tables.Novel() > 0 {
+		return GCStats{}, ErrUnpersistedChunks
+	}
+
+	exists, contents, err := mm.Fetch(ctx, nbs.stats)
+
+	if err != nil {
+		return GCStats{}, err
+	}
+
+	if !exists {
+		return GCStats{}, nil
+	}
+
+	// chunks written by commits made since |keepers| was marked, and table files added without moving the root, would
+	// not be kept
+	if contents.lock != addr(markedLock) {
+		return GCStats{}, ErrManifestChangedDuringGC
+	}
+
+	tables, err := nbs.tables.Rebase(ctx, contents.specs, nbs.stats)
+
+	if err != nil {
+		return GCStats{}, err
+	}
+
+	nbs.upstream = contents
+	nbs.tables = tables
+
+	stats.TableFilesBefore = len(tables.upstream)
+	live := make(map[addr]struct{})
+	var liveLen uint64
+	for _, src := range tables.upstream {
+		index, err := src.index()
+
+		if err != nil {
+			return GCStats{}, err
+		}
+
+		stats.ChunksBefore += uint64(index.chunkCount)
+		stats.BytesBefore += index.tableFileSize()
+
+		addrs := index.addrsByOrdinal()
+		for ordinal, a := range addrs {
+			if _, ok := live[a]; ok || !keepers.Has(hash.Hash(a)) {
+				continue
+			}
+
+			live[a] = struct{}{}
+			liveLen += uint64(index.lengths[ordinal])
+		}
+	}
+
+	stats.ChunksAfter = uint64(len(live))
+
+	if stats.ChunksAfter == stats.ChunksBefore {
+		// there is no garbage
+		stats.TableFilesAfter = stats.TableFilesBefore
+		stats.BytesAfter = stats.BytesBefore
+		return stats, nil
+	}
+
+	if dryRun {
+		if len(live) > 0 {
+			stats.TableFilesAfter = 1
+			stats.BytesAfter = liveLen + indexSize(uint32(len(live))) + footerSize
+		}
+
+		return stats, nil
+	}
+
+	specs, err := nbs.writeLiveChunks(ctx, tables.upstream, live)
+
+	if err != nil {
+		return GCStats{}, err
+	}
+
+	newContents := manifestContents{
+		vers:  contents.vers,
+		root:  contents.root,
+		lock:  generateLockHash(contents.root, specs),
+		specs: specs,
+	}
+
+	upstream, err := mm.Update(ctx, contents.lock, newContents, nbs.stats, nil)
+
+	if err != nil {
+		return GCStats{}, err
+	}
+
+	if upstream.lock != newContents.lock {
+		return GCStats{}, ErrManifestChangedDuringGC
+	}
+
+	newTables, err := tables.Rebase(ctx, specs, nbs.stats)
+
+	if err != nil {
+		return GCStats{}, err
+	}
+
+	nbs.upstream = newContents
+	nbs.tables = newTables
+
+	stats.TableFilesAfter = len(specs)
+	for _, src := range newTables.upstream {
+		index, err := src.index()
+
+		if err != nil {
+			return GCStats{}, err
+		}
+
+		stats.BytesAfter += index.tableFileSize()
+	}
+
+	if pruner, ok := nbs.p.(tableFilePruner); ok {
+		err = pruner.pruneTableFiles(ctx, replacedSpecs(contents.specs, specs))
+
+		if err != nil {
+			return GCStats{}, err
+		}
+	}
+
+	return stats, nil
+}
+
+// writeLiveChunks copies the chunks in |live| out of |sources| and into new tables, returning the specs of the tables
+// written.
+func (nbs *NomsBlockStore) writeLiveChunks(ctx context.Context, sources chunkSources, live map[addr]struct{}) ([]tableSpec, error) {
+	var specs []tableSpec
+	mt := newMemTable(nbs.mtSize)
+
+	flush := func() error {
+		cs, err := nbs.p.Persist(ctx, mt, nil, nbs.stats)
+
+		if err != nil {
+			return err
+		}
+
+		cnt, err := cs.count()
+
+		if err != nil {
+			return err
+		}
+
+		if cnt > 0 {
+			h, err := cs.hash()
+
+			if err != nil {
+				return err
+			}
+
+			specs = append(specs, tableSpec{h, cnt})
+		}
+
+		mt = newMemTable(nbs.mtSize)
+		return nil
+	}
+
+	written := make(map[addr]struct{}, len(live))
+	for _, src := range sources {
+		recs := make(chan extractRecord)
+		extractErr := make(chan error, 1)
+
+		go func() {
+			defer close(recs)
+			extractErr <- src.extract(ctx, recs)
+		}()
+
+		var err error
+		for rec := range recs {
+			if err != nil {
+				continue
+			}
+
+			if rec.err != nil {
+				err = rec.err
+				continue
+			}
+
+			if _, ok := live[rec.a]; !ok {
+				continue
+			} else if _, ok := written[rec.a]; ok {
+				continue
+			}
+
+			if !mt.addChunk(rec.a, rec.data) {
+				err = flush()
+
+				if err == nil && !mt.addChunk(rec.a, rec.data) {
+					err = errors.New("chunk " + rec.a.String() + " is too large to be written to a table")
+				}
+			}
+
+			written[rec.a] = struct{}{}
+		}
+
+		if extErr := <-extractErr; err == nil {
+			err = extErr
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := flush()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return specs, nil
+}
+
+// addrsByOrdinal returns the addresses of the chunks in the table, in the order in which they are stored.
+func (ti tableIndex) addrsByOrdinal() []addr {
+	addrs := make([]addr, ti.chunkCount)
+	for idx, prefix := range ti.prefixes {
+		ordinal := ti.prefixIdxToOrdinal(uint32(idx))
+		binary.BigEndian.PutUint64(addrs[ordinal][:], prefix)
+		li := uint64(ordinal) * addrSuffixSize
+		copy(addrs[ordinal][addrPrefixSize:], ti.suffixes[li:li+addrSuffixSize])
+	}
+
+	return addrs
+}
+
+// replacedSpecs returns the specs in |old| which are not in |new|.
+func replacedSpecs(old, new []tableSpec) []tableSpec {
+	kept := make(map[addr]struct{}, len(new))
+	for _, spec := range new {
+		kept[spec.name] = struct{}{}
+	}
+
+	var replaced []tableSpec
+	for _, spec := range old {
+		if _, ok := kept[spec.name]; !ok {
+			replaced = append(replaced, spec)
+		}
+	}
+
+	return replaced
+}
+
+// pruneTableFiles removes the table files in the directory of the persister for the specs in |gone|.
+func (ftp *fsTablePersister) pruneTableFiles(ctx context.Context, gone []tableSpec) error {
+	for _, spec := range gone {
+		err := os.Remove(filepath.Join(ftp.dir, spec.name.String()))
+
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
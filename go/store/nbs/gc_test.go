@@ -0,0 +1,243 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/store/chunks"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func countTableFiles(t *testing.T, dir string) int {
+	infos, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+
+	cnt := 0
+	for _, info := range infos {
+		if hash.IsValid(info.Name()) {
+			cnt++
+		}
+	}
+
+	return cnt
+}
+
+func TestCollectGarbage(t *testing.T) {
+	ctx := context.Background()
+	testDir := filepath.Join(os.TempDir(), uuid.New().String())
+	err := os.MkdirAll(testDir, os.ModePerm)
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	st, err := NewLocalStore(ctx, types.Format_Default.VersionString(), testDir, defaultMemTableSize)
+	require.NoError(t, err)
+
+	// write the chunks across several commits so that there are several table files
+	var all []chunks.Chunk
+	keepers := hash.NewHashSet()
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			c := chunks.NewChunk([]byte(fmt.Sprintf("chunk %d:%d", i, j)))
+			all = append(all, c)
+
+			if j%2 == 0 {
+				keepers.Insert(c.Hash())
+			}
+
+			err = st.Put(ctx, c)
+			require.NoError(t, err)
+		}
+
+		root, err := st.Root(ctx)
+		require.NoError(t, err)
+		ok, err := st.Commit(ctx, all[len(all)-1].Hash(), root)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	require.Equal(t, 4, countTableFiles(t, testDir))
+
+	// a table file which is not in the manifest, like one written by a writer which has yet to commit, is left alone
+	uncommitted := filepath.Join(testDir, hash.Of([]byte("uncommitted")).String())
+	err = ioutil.WriteFile(uncommitted, []byte("uncommitted"), os.ModePerm)
+	require.NoError(t, err)
+
+	_, markedLock, err := st.RootAndManifestLock(ctx)
+	require.NoError(t, err)
+
+	stats, err := st.CollectGarbage(ctx, markedLock, keepers, true)
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats.TableFilesBefore)
+	assert.Equal(t, 1, stats.TableFilesAfter)
+	assert.Equal(t, uint64(len(all)), stats.ChunksBefore)
+	assert.Equal(t, uint64(len(keepers)), stats.ChunksAfter)
+	assert.True(t, stats.ReclaimedBytes() > 0)
+	assert.Equal(t, 5, countTableFiles(t, testDir))
+
+	for _, c := range all {
+		has, err := st.Has(ctx, c.Hash())
+		require.NoError(t, err)
+		assert.True(t, has)
+	}
+
+	dryRunStats := stats
+	stats, err = st.CollectGarbage(ctx, markedLock, keepers, false)
+	require.NoError(t, err)
+	assert.Equal(t, dryRunStats, stats)
+	assert.Equal(t, 2, countTableFiles(t, testDir))
+	assert.FileExists(t, uncommitted)
+
+	for _, c := range all {
+		has, err := st.Has(ctx, c.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, keepers.Has(c.Hash()), has)
+	}
+
+	err = st.Close()
+	require.NoError(t, err)
+
+	// the collected store can be reopened, and collecting again finds no garbage
+	st, err = NewLocalStore(ctx, types.Format_Default.VersionString(), testDir, defaultMemTableSize)
+	require.NoError(t, err)
+	defer st.Close()
+
+	for h := range keepers {
+		c, err := st.Get(ctx, h)
+		require.NoError(t, err)
+		assert.Equal(t, h, c.Hash())
+	}
+
+	_, markedLock, err = st.RootAndManifestLock(ctx)
+	require.NoError(t, err)
+	stats, err = st.CollectGarbage(ctx, markedLock, keepers, false)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), stats.ReclaimedBytes())
+	assert.Equal(t, stats.ChunksBefore, stats.ChunksAfter)
+}
+
+func TestCollectGarbageWithUnpersistedChunks(t *testing.T) {
+	ctx := context.Background()
+	testDir := filepath.Join(os.TempDir(), uuid.New().String())
+	err := os.MkdirAll(testDir, os.ModePerm)
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	st, err := NewLocalStore(ctx, types.Format_Default.VersionString(), testDir, defaultMemTableSize)
+	require.NoError(t, err)
+	defer st.Close()
+
+	err = st.Put(ctx, chunks.NewChunk([]byte("uncommitted")))
+	require.NoError(t, err)
+
+	_, err = st.CollectGarbage(ctx, hash.Hash{}, hash.NewHashSet(), false)
+	assert.Equal(t, ErrUnpersistedChunks, err)
+}
+
+func TestCollectGarbageAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	testDir := filepath.Join(os.TempDir(), uuid.New().String())
+	err := os.MkdirAll(testDir, os.ModePerm)
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	st, err := NewLocalStore(ctx, types.Format_Default.VersionString(), testDir, defaultMemTableSize)
+	require.NoError(t, err)
+	defer st.Close()
+
+	commitChunk := func(data string) chunks.Chunk {
+		c := chunks.NewChunk([]byte(data))
+		err := st.Put(ctx, c)
+		require.NoError(t, err)
+
+		root, err := st.Root(ctx)
+		require.NoError(t, err)
+		ok, err := st.Commit(ctx, c.Hash(), root)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		return c
+	}
+
+	marked := commitChunk("marked")
+	_, markedLock, err := st.RootAndManifestLock(ctx)
+	require.NoError(t, err)
+
+	// a commit made after the keepers were marked writes chunks which they do not include
+	committed := commitChunk("committed after marking")
+
+	_, err = st.CollectGarbage(ctx, markedLock, hash.NewHashSet(marked.Hash()), false)
+	assert.Equal(t, ErrManifestChangedDuringGC, err)
+
+	for _, c := range []chunks.Chunk{marked, committed} {
+		has, err := st.Has(ctx, c.Hash())
+		require.NoError(t, err)
+		assert.True(t, has)
+	}
+}
+
+func TestCollectGarbageAfterTableFilesAdded(t *testing.T) {
+	ctx := context.Background()
+	testDir := filepath.Join(os.TempDir(), uuid.New().String())
+	err := os.MkdirAll(testDir, os.ModePerm)
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	st, err := NewLocalStore(ctx, types.Format_Default.VersionString(), testDir, defaultMemTableSize)
+	require.NoError(t, err)
+	defer st.Close()
+
+	marked := chunks.NewChunk([]byte("marked"))
+	err = st.Put(ctx, marked)
+	require.NoError(t, err)
+	root, err := st.Root(ctx)
+	require.NoError(t, err)
+	ok, err := st.Commit(ctx, marked.Hash(), root)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	markedRoot, markedLock, err := st.RootAndManifestLock(ctx)
+	require.NoError(t, err)
+
+	// another writer persists a table file without moving the root
+	other, err := NewLocalStore(ctx, types.Format_Default.VersionString(), testDir, defaultMemTableSize)
+	require.NoError(t, err)
+	defer other.Close()
+
+	unmarked := chunks.NewChunk([]byte("persisted after marking"))
+	err = other.Put(ctx, unmarked)
+	require.NoError(t, err)
+	ok, err = other.Commit(ctx, markedRoot, markedRoot)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = st.CollectGarbage(ctx, markedLock, hash.NewHashSet(marked.Hash()), false)
+	assert.Equal(t, ErrManifestChangedDuringGC, err)
+
+	has, err := other.Has(ctx, unmarked.Hash())
+	require.NoError(t, err)
+	assert.True(t, has)
+	assert.Equal(t, 2, countTableFiles(t, testDir))
+}
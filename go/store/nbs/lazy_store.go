@@ -0,0 +1,290 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"sync"
+
+	"github.com/liquidata-inc/dolt/go/store/chunks"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+)
+
+// maxUnpersistedChunks is the number of chunks fetched from the remote store which are held in memory before they are
+// written to the table files of the local store.
+const maxUnpersistedChunks = 4096
+
+// LazyStore is a ChunkStore which reads chunks that are missing from a local store from a remote store.  Every chunk
+// read from the remote store is persisted in the local store, so it is only fetched once.  Writes, and the root of the
+// store, are handled by the local store only.
+type LazyStore struct {
+	local  chunks.ChunkStore
+	remote chunks.ChunkStore
+
+	// mu serializes the persisting of chunks fetched from the remote store, and guards unpersisted
+	mu *sync.Mutex
+
+	// unpersisted is the number of chunks fetched from the remote store which have been put in the local store but not
+	// yet written to its table files.  They are written in a batch once there are maxUnpersistedChunks of them, or by
+	// the next Persist, Commit, GetMany or Close.
+	unpersisted int
+}
+
+// NewLazyStore returns a LazyStore which reads chunks missing from local from remote.
+func NewLazyStore(local, remote chunks.ChunkStore) *LazyStore {
+	return &LazyStore{local, remote, &sync.Mutex{}, 0}
+}
+
+var _ chunks.ChunkStore = &LazyStore{}
+
+// Local returns the store that chunks fetched from the remote store are persisted in.
+func (ls *LazyStore) Local() chunks.ChunkStore {
+	return ls.local
+}
+
+// Remote returns the store that chunks missing from the local store are fetched from.
+func (ls *LazyStore) Remote() chunks.ChunkStore {
+	return ls.remote
+}
+
+// Get gets the Chunk for the value of the hash from the local store, or from the remote store if it is missing locally.
+// A chunk fetched from the remote store is put in the local store, and written to its table files along with the other
+// chunks fetched by Get once there are maxUnpersistedChunks of them, or when the store is persisted, committed or closed.
+func (ls *LazyStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, error) {
+	c, err := ls.local.Get(ctx, h)
+
+	if err != nil || !c.IsEmpty() {
+		return c, err
+	}
+
+	c, err = ls.remote.Get(ctx, h)
+
+	if err != nil || c.IsEmpty() {
+		return c, err
+	}
+
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	err = ls.local.Put(ctx, c)
+
+	if err != nil {
+		return chunks.EmptyChunk, err
+	}
+
+	ls.unpersisted++
+
+	if ls.unpersisted >= maxUnpersistedChunks {
+		err = ls.persist(ctx)
+
+		if err != nil {
+			return chunks.EmptyChunk, err
+		}
+	}
+
+	return c, nil
+}
+
+// GetMany gets the Chunks with |hashes| from the local store, and any which are missing locally from the remote store.
+// The chunks fetched from the remote store are persisted in the local store in batches of at most maxUnpersistedChunks.
+func (ls *LazyStore) GetMany(ctx context.Context, hashes hash.HashSet, foundChunks chan<- *chunks.Chunk) error {
+	missing := hash.HashSet{}
+	for h := range hashes {
+		missing.Insert(h)
+	}
+
+	err := ls.getManyFrom(ctx, ls.local, hashes, func(c *chunks.Chunk) {
+		missing.Remove(c.Hash())
+		foundChunks <- c
+	})
+
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+
+	var fetched []chunks.Chunk
+	var persistErr error
+	err = ls.getManyFrom(ctx, ls.remote, missing, func(c *chunks.Chunk) {
+		foundChunks <- c
+
+		if persistErr != nil {
+			return
+		}
+
+		fetched = append(fetched, *c)
+
+		if len(fetched) >= maxUnpersistedChunks {
+			persistErr = ls.Persist(ctx, fetched)
+			fetched = nil
+		}
+	})
+
+	if err != nil {
+		return err
+	} else if persistErr != nil {
+		return persistErr
+	}
+
+	return ls.Persist(ctx, fetched)
+}
+
+func (ls *LazyStore) getManyFrom(ctx context.Context, cs chunks.ChunkStore, hashes hash.HashSet, cb func(c *chunks.Chunk)) error {
+	found := make(chan *chunks.Chunk, 128)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(found)
+		errCh <- cs.GetMany(ctx, hashes, found)
+	}()
+
+	for c := range found {
+		cb(c)
+	}
+
+	return <-errCh
+}
+
+// Persist puts chunks in the local store, and writes them to its table files along with any other chunks fetched from
+// the remote store which have yet to be written.  The root of the local store is not changed.
+func (ls *LazyStore) Persist(ctx context.Context, chnks []chunks.Chunk) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	for _, c := range chnks {
+		err := ls.local.Put(ctx, c)
+
+		if err != nil {
+			return err
+		}
+
+		ls.unpersisted++
+	}
+
+	return ls.persist(ctx)
+}
+
+// persist writes the chunks which have been put in the local store to its table files.  Callers must hold mu.
+func (ls *LazyStore) persist(ctx context.Context) error {
+	if ls.unpersisted == 0 {
+		return nil
+	}
+
+	for {
+		root, err := ls.local.Root(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		success, err := ls.local.Commit(ctx, root, root)
+
+		if err != nil {
+			return err
+		} else if success {
+			ls.unpersisted = 0
+			return nil
+		}
+
+		// the root was moved by another writer
+		err = ls.local.Rebase(ctx)
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Has returns true iff the value at the address |h| is contained in the local or remote store.
+func (ls *LazyStore) Has(ctx context.Context, h hash.Hash) (bool, error) {
+	has, err := ls.local.Has(ctx, h)
+
+	if err != nil || has {
+		return has, err
+	}
+
+	return ls.remote.Has(ctx, h)
+}
+
+// HasMany returns a new HashSet containing any members of |hashes| that are absent from both the local and remote
+// stores.
+func (ls *LazyStore) HasMany(ctx context.Context, hashes hash.HashSet) (hash.HashSet, error) {
+	absent, err := ls.local.HasMany(ctx, hashes)
+
+	if err != nil || len(absent) == 0 {
+		return absent, err
+	}
+
+	return ls.remote.HasMany(ctx, absent)
+}
+
+// Put caches c in the local store.
+func (ls *LazyStore) Put(ctx context.Context, c chunks.Chunk) error {
+	return ls.local.Put(ctx, c)
+}
+
+// Version returns the NomsVersion of the local store.
+func (ls *LazyStore) Version() string {
+	return ls.local.Version()
+}
+
+// Rebase brings the local store into sync with its persistent storage's current root.
+func (ls *LazyStore) Rebase(ctx context.Context) error {
+	return ls.local.Rebase(ctx)
+}
+
+// Root returns the root of the local store.
+func (ls *LazyStore) Root(ctx context.Context) (hash.Hash, error) {
+	return ls.local.Root(ctx)
+}
+
+// Commit persists all novel chunks, including those fetched from the remote store, and updates the root of the local
+// store.
+func (ls *LazyStore) Commit(ctx context.Context, current, last hash.Hash) (bool, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	success, err := ls.local.Commit(ctx, current, last)
+
+	if err == nil && success {
+		ls.unpersisted = 0
+	}
+
+	return success, err
+}
+
+// Stats returns the statistics of the local store.
+func (ls *LazyStore) Stats() interface{} {
+	return ls.local.Stats()
+}
+
+// StatsSummary returns the summarized statistics of the local store.
+func (ls *LazyStore) StatsSummary() string {
+	return ls.local.StatsSummary()
+}
+
+// Close persists the chunks fetched from the remote store which have yet to be written, and closes both the local
+// and remote stores.
+func (ls *LazyStore) Close() error {
+	err := ls.Persist(context.Background(), nil)
+
+	if closeErr := ls.local.Close(); err == nil {
+		err = closeErr
+	}
+
+	if remoteErr := ls.remote.Close(); err == nil {
+		err = remoteErr
+	}
+
+	return err
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 Liquidata, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liquidata-inc/dolt/go/store/chunks"
+	"github.com/liquidata-inc/dolt/go/store/hash"
+	"github.com/liquidata-inc/dolt/go/store/types"
+)
+
+func TestLazyStore(t *testing.T) {
+	ctx := context.Background()
+	testDir := filepath.Join(os.TempDir(), uuid.New().String())
+	err := os.MkdirAll(testDir, os.ModePerm)
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	remote := (&chunks.MemoryStorage{}).NewView()
+	remoteChunks := []chunks.Chunk{
+		chunks.NewChunk([]byte("remote 1")),
+		chunks.NewChunk([]byte("remote 2")),
+		chunks.NewChunk([]byte("remote 3")),
+	}
+
+	for _, c := range remoteChunks {
+		require.NoError(t, remote.Put(ctx, c))
+	}
+
+	local, err := NewLocalStore(ctx, types.Format_Default.VersionString(), testDir, defaultMemTableSize)
+	require.NoError(t, err)
+
+	localChunk := chunks.NewChunk([]byte("local"))
+	require.NoError(t, local.Put(ctx, localChunk))
+
+	ls := NewLazyStore(local, remote)
+	missing := chunks.NewChunk([]byte("missing"))
+
+	has, err := ls.Has(ctx, remoteChunks[0].Hash())
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	absent, err := ls.HasMany(ctx, hash.NewHashSet(localChunk.Hash(), remoteChunks[1].Hash(), missing.Hash()))
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(missing.Hash()), absent)
+
+	c, err := ls.Get(ctx, remoteChunks[0].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, remoteChunks[0].Data(), c.Data())
+
+	found := make(chan *chunks.Chunk, 8)
+	err = ls.GetMany(ctx, hash.NewHashSet(localChunk.Hash(), remoteChunks[1].Hash(), missing.Hash()), found)
+	require.NoError(t, err)
+	close(found)
+
+	foundHashes := hash.NewHashSet()
+	for c := range found {
+		foundHashes.Insert(c.Hash())
+	}
+	assert.Equal(t, hash.NewHashSet(localChunk.Hash(), remoteChunks[1].Hash()), foundHashes)
+
+	// the chunks read from the remote store were persisted in the table files of the local store
+	reopened, err := NewLocalStore(ctx, types.Format_Default.VersionString(), testDir, defaultMemTableSize)
+	require.NoError(t, err)
+
+	absent, err = reopened.HasMany(ctx, hash.NewHashSet(remoteChunks[0].Hash(), remoteChunks[1].Hash(), remoteChunks[2].Hash()))
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(remoteChunks[2].Hash()), absent)
+}
+
+func TestLazyStoreBatchesPersists(t *testing.T) {
+	ctx := context.Background()
+	testDir := filepath.Join(os.TempDir(), uuid.New().String())
+	err := os.MkdirAll(testDir, os.ModePerm)
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	remote := (&chunks.MemoryStorage{}).NewView()
+	remoteHashes := hash.NewHashSet()
+	for _, data := range []string{"remote 1", "remote 2", "remote 3"} {
+		c := chunks.NewChunk([]byte(data))
+		remoteHashes.Insert(c.Hash())
+		require.NoError(t, remote.Put(ctx, c))
+	}
+
+	local, err := NewLocalStore(ctx, types.Format_Default.VersionString(), testDir, defaultMemTableSize)
+	require.NoError(t, err)
+
+	ls := NewLazyStore(local, remote)
+	for h := range remoteHashes {
+		c, err := ls.Get(ctx, h)
+		require.NoError(t, err)
+		assert.Equal(t, h, c.Hash())
+	}
+
+	// chunks read one at a time are not written until there are maxUnpersistedChunks of them, or until the store is
+	// persisted, committed or closed
+	assert.Equal(t, 0, countTableFiles(t, testDir))
+
+	err = ls.Close()
+	require.NoError(t, err)
+	assert.Equal(t, 1, countTableFiles(t, testDir))
+
+	reopened, err := NewLocalStore(ctx, types.Format_Default.VersionString(), testDir, defaultMemTableSize)
+	require.NoError(t, err)
+	defer reopened.Close()
+
+	absent, err := reopened.HasMany(ctx, remoteHashes)
+	require.NoError(t, err)
+	assert.Empty(t, absent)
+}
+
+func TestLazyStoreBoundsGetManyBatches(t *testing.T) {
+	ctx := context.Background()
+	testDir := filepath.Join(os.TempDir(), uuid.New().String())
+	err := os.MkdirAll(testDir, os.ModePerm)
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	remote := (&chunks.MemoryStorage{}).NewView()
+	remoteHashes := hash.NewHashSet()
+	for i := 0; i < maxUnpersistedChunks+1; i++ {
+		c := chunks.NewChunk([]byte(fmt.Sprintf("remote %d", i)))
+		remoteHashes.Insert(c.Hash())
+		require.NoError(t, remote.Put(ctx, c))
+	}
+
+	local, err := NewLocalStore(ctx, types.Format_Default.VersionString(), testDir, defaultMemTableSize)
+	require.NoError(t, err)
+
+	ls := NewLazyStore(local, remote)
+	defer ls.Close()
+
+	found := make(chan *chunks.Chunk, len(remoteHashes))
+	err = ls.GetMany(ctx, remoteHashes, found)
+	require.NoError(t, err)
+	close(found)
+	assert.Len(t, found, len(remoteHashes))
+
+	// the chunks fetched by a single GetMany are written in batches of at most maxUnpersistedChunks
+	assert.Equal(t, 2, countTableFiles(t, testDir))
+}
@@ -40,6 +40,8 @@ func NewNBSMetricWrapper(nbs *NomsBlockStore) *NBSMetricWrapper {
 }
 
 var _ TableFileStore = &NBSMetricWrapper{}
+var _ GarbageCollector = &NBSMetricWrapper{}
+var _ TableFileVerifier = &NBSMetricWrapper{}
 
 // Sources retrieves the current root hash, and a list of all the table files
 func (nbsMW *NBSMetricWrapper) Sources(ctx context.Context) (hash.Hash, []TableFile, error) {
@@ -68,3 +70,18 @@ func (nbsMW *NBSMetricWrapper) GetManyCompressed(ctx context.Context, hashes has
 	atomic.AddInt32(&nbsMW.TotalChunkGets, int32(len(hashes)))
 	return nbsMW.nbs.GetManyCompressed(ctx, hashes, cmpChChan)
 }
+
+// RootAndManifestLock forwards to the wrapped block store.
+func (nbsMW *NBSMetricWrapper) RootAndManifestLock(ctx context.Context) (hash.Hash, hash.Hash, error) {
+	return nbsMW.nbs.RootAndManifestLock(ctx)
+}
+
+// CollectGarbage forwards garbage collection to the wrapped block store.
+func (nbsMW *NBSMetricWrapper) CollectGarbage(ctx context.Context, markedLock hash.Hash, keepers hash.HashSet, dryRun bool) (GCStats, error) {
+	return nbsMW.nbs.CollectGarbage(ctx, markedLock, keepers, dryRun)
+}
+
+// VerifyTableFiles forwards table file verification to the wrapped block store.
+func (nbsMW *NBSMetricWrapper) VerifyTableFiles(ctx context.Context) (TableFileReport, error) {
+	return nbsMW.nbs.VerifyTableFiles(ctx)
+}
@@ -281,7 +281,7 @@ func NewLocalStore(ctx context.Context, nbfVerStr string, dir string, memTableSi
 		return nil, err
 	}
 
-	mm := makeManifestManager(fileManifest{dir})
+	mm := makeManifestManager(fileManifest{dir: dir})
 	p := newFSTablePersister(dir, globalFDCache, globalIndexCache)
 	nbs, err := newNomsBlockStore(ctx, nbfVerStr, mm, p, inlineConjoiner{defaultMaxTables}, memTableSize)
 
@@ -968,6 +968,12 @@ func (nbs *NomsBlockStore) WriteTableFile(ctx context.Context, fileId string, nu
 		return errors.New("Not implemented")
 	}
 
+	fileIdHash, ok := hash.MaybeParse(fileId)
+
+	if !ok {
+		return errors.New("invalid base32 encoded hash: " + fileId)
+	}
+
 	path := filepath.Join(fsPersister.dir, fileId)
 
 	err := func() (err error) {
@@ -995,12 +1001,6 @@ func (nbs *NomsBlockStore) WriteTableFile(ctx context.Context, fileId string, nu
 		return err
 	}
 
-	fileIdHash, ok := hash.MaybeParse(fileId)
-
-	if !ok {
-		return errors.New("invalid base32 encoded hash: " + fileId)
-	}
-
 	_, err = nbs.UpdateManifest(ctx, map[hash.Hash]uint32{fileIdHash: uint32(numChunks)})
 
 	return err
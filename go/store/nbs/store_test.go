@@ -77,3 +77,27 @@ func TestNBSAsTableFileStore(t *testing.T) {
 		assert.Equal(t, expected, data)
 	}
 }
+
+func TestWriteTableFileRejectsInvalidFileID(t *testing.T) {
+	ctx := context.Background()
+	testDir := filepath.Join(os.TempDir(), uuid.New().String())
+	storeDir := filepath.Join(testDir, "store")
+
+	err := os.MkdirAll(storeDir, os.ModePerm)
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	st, err := NewLocalStore(ctx, types.Format_Default.VersionString(), storeDir, defaultMemTableSize)
+	require.NoError(t, err)
+	defer st.Close()
+
+	data, _, err := buildTable([][]byte{[]byte("chunk")})
+	require.NoError(t, err)
+
+	err = st.WriteTableFile(ctx, "../escaped", 1, bytes.NewReader(data), 0, nil)
+	assert.Error(t, err)
+
+	// nothing is written outside of the store
+	_, err = os.Stat(filepath.Join(testDir, "escaped"))
+	assert.True(t, os.IsNotExist(err))
+}